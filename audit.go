@@ -0,0 +1,217 @@
+package bitcocheck
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditRequest One request to the Coincheck API made by an audited call, with
+// its secrets redacted.
+type AuditRequest struct {
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	Body      string `json:"body,omitempty"`
+	Status    int    `json:"status"`
+	Response  string `json:"response,omitempty"`
+	ElapsedMs int64  `json:"elapsed_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewAuditRequest returns the audit record of a request event.
+func NewAuditRequest(e RequestEvent) AuditRequest {
+	r := AuditRequest{
+		Method:    e.Method,
+		URL:       e.URL,
+		Body:      string(RedactJSON([]byte(e.Body))),
+		Status:    e.Status,
+		Response:  string(RedactJSON(e.Response)),
+		ElapsedMs: int64(e.Elapsed / time.Millisecond),
+	}
+	if e.Err != nil {
+		r.Error = e.Err.Error()
+	}
+	return r
+}
+
+// AuditEntry One audited call. Hash is the SHA-256 of the entry without Hash,
+// whose PrevHash is the Hash of the entry before, so changing, removing or
+// reordering entries breaks the chain.
+type AuditEntry struct {
+	Seq      uint64          `json:"seq"`
+	Time     time.Time       `json:"time"`
+	Caller   string          `json:"caller"`
+	Role     string          `json:"role,omitempty"`
+	Peer     string          `json:"peer,omitempty"`
+	Method   string          `json:"method"`
+	Params   json.RawMessage `json:"params,omitempty"`
+	Upstream []AuditRequest  `json:"upstream,omitempty"`
+	Outcome  string          `json:"outcome"`
+	Error    string          `json:"error,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"`
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash"`
+}
+
+// Audit outcomes.
+const (
+	AuditOK    = "ok"
+	AuditError = "error"
+)
+
+func (e AuditEntry) digest() (string, error) {
+	e.Hash = ""
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// redactedKeys are the JSON keys whose values never reach the audit log.
+var redactedKeys = []string{"secret", "token", "password", "signature", "access_key", "api_key", "address", "account_number"}
+
+// RedactJSON replaces the values of secret looking keys of a JSON document
+// with "[redacted]". Anything that is not JSON is returned as is.
+func RedactJSON(data []byte) []byte {
+	var v interface{}
+	if len(data) == 0 || json.Unmarshal(data, &v) != nil {
+		return data
+	}
+	b, err := json.Marshal(redact(v))
+	if err != nil {
+		return data
+	}
+	return b
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			lower := strings.ToLower(k)
+			secret := false
+			for _, key := range redactedKeys {
+				if strings.Contains(lower, key) {
+					secret = true
+					break
+				}
+			}
+			if secret {
+				v[k] = "[redacted]"
+			} else {
+				v[k] = redact(val)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return v
+}
+
+// AuditLog An append-only, hash-chained audit log of JSON lines.
+type AuditLog struct {
+	mu   sync.Mutex
+	f    *os.File
+	seq  uint64
+	last string
+}
+
+// OpenAuditLog opens or creates an audit log. The existing entries are
+// verified first, so entries are never chained to a tampered log.
+func OpenAuditLog(name string) (*AuditLog, error) {
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	last, err := verifyAuditLog(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("audit log %s: %v", name, err)
+	}
+	return &AuditLog{f: f, seq: last.Seq, last: last.Hash}, nil
+}
+
+// Append chains the entry to the log and writes it to disk before returning.
+// Seq, PrevHash and Hash are set by the log, Time when it is zero.
+func (l *AuditLog) Append(e AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e.Seq = l.seq + 1
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Time = e.Time.UTC()
+	e.PrevHash = l.last
+	hash, err := e.digest()
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := l.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	if err := l.f.Sync(); err != nil {
+		return err
+	}
+	l.seq, l.last = e.Seq, e.Hash
+	return nil
+}
+
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Close()
+}
+
+// VerifyAuditLog checks the hash chain of an audit log and returns the
+// number of entries. The error names the first entry that does not verify.
+func VerifyAuditLog(r io.Reader) (int, error) {
+	last, err := verifyAuditLog(r)
+	return int(last.Seq), err
+}
+
+// verifyAuditLog returns the last entry of a verified log, a zero entry for
+// an empty one.
+func verifyAuditLog(r io.Reader) (AuditEntry, error) {
+	var last AuditEntry
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for sc.Scan() {
+		line++
+		var e AuditEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return last, fmt.Errorf("line %d: %v", line, err)
+		}
+		if e.Seq != last.Seq+1 {
+			return last, fmt.Errorf("line %d: seq %d follows %d", line, e.Seq, last.Seq)
+		}
+		if e.PrevHash != last.Hash {
+			return last, fmt.Errorf("line %d: seq %d is not chained to seq %d", line, e.Seq, last.Seq)
+		}
+		hash, err := e.digest()
+		if err != nil {
+			return last, fmt.Errorf("line %d: %v", line, err)
+		}
+		if hash != e.Hash {
+			return last, fmt.Errorf("line %d: seq %d was modified", line, e.Seq)
+		}
+		last = e
+	}
+	return last, sc.Err()
+}
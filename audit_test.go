@@ -0,0 +1,91 @@
+package bitcocheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "audit.jsonl")
+	entries := []AuditEntry{
+		{Caller: "bitcobuy", Role: "trade", Method: "LimitBuy", Params: json.RawMessage(`{"pair":"btc_jpy","rate":"1000000"}`), Outcome: AuditOK},
+		{Caller: "bitcobuy", Role: "trade", Method: "DeleteExchangeOrder", Params: json.RawMessage(`{"id":12}`), Outcome: AuditError, Error: "not found"},
+		{Caller: "ops", Role: "admin", Method: "MarketSell", Upstream: []AuditRequest{{Method: "POST", URL: "https://coincheck.com/api/exchange/orders", Status: 200}}, Outcome: AuditOK},
+	}
+	// The log is reopened between the entries, which continue the chain.
+	for _, e := range entries {
+		l, err := OpenAuditLog(name)
+		if err != nil {
+			t.Fatalf("OpenAuditLog() error = %v", err)
+		}
+		if err := l.Append(e); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+		if err := l.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := VerifyAuditLog(bytes.NewReader(data)); err != nil || n != len(entries) {
+		t.Fatalf("VerifyAuditLog() = %v, %v, want %d", n, err, len(entries))
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	tests := []struct {
+		name    string
+		log     string
+		wantErr string
+	}{
+		{name: "modified", log: lines[0] + strings.Replace(lines[1], "not found", "found", 1) + lines[2], wantErr: "seq 2 was modified"},
+		{name: "removed", log: lines[0] + lines[2], wantErr: "seq 3 follows 1"},
+		{name: "reordered", log: lines[1] + lines[0] + lines[2], wantErr: "seq 2 follows 0"},
+		{name: "truncated head", log: lines[1] + lines[2], wantErr: "seq 2 follows 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyAuditLog(strings.NewReader(tt.log))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("VerifyAuditLog() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+	if err := ioutil.WriteFile(name, []byte(tests[0].log), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenAuditLog(name); err == nil {
+		t.Errorf("OpenAuditLog() of a modified log error = nil, want an error")
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "order", in: `{"pair":"btc_jpy","rate":"1000"}`, want: `{"pair":"btc_jpy","rate":"1000"}`},
+		{name: "nested", in: `{"data":[{"address":"1abc","amount":"0.1"}],"api_key":"k"}`, want: `{"api_key":"[redacted]","data":[{"address":"[redacted]","amount":"0.1"}]}`},
+		{name: "case", in: `{"Access_Key":"k"}`, want: `{"Access_Key":"[redacted]"}`},
+		{name: "not json", in: `<html>bad gateway</html>`, want: `<html>bad gateway</html>`},
+		{name: "empty", in: ``, want: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(RedactJSON([]byte(tt.in))); got != tt.want {
+				t.Errorf("RedactJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package bitcocheck
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Role What a caller of the gRPC API may do. Each role may do everything the
+// roles before it may.
+type Role int
+
+const (
+	RoleNone Role = iota
+	// RoleRead reads market data and the collected history.
+	RoleRead
+	// RoleTrade also places and cancels orders and reads the account.
+	RoleTrade
+	// RoleAdmin also operates the server.
+	RoleAdmin
+)
+
+var roleNames = []string{"none", "read", "trade", "admin"}
+
+func (r Role) String() string {
+	if r < 0 || int(r) >= len(roleNames) {
+		return fmt.Sprintf("Role(%d)", int(r))
+	}
+	return roleNames[r]
+}
+
+// Allows tells whether the role may call what needs the role need.
+func (r Role) Allows(need Role) bool {
+	return r >= need
+}
+
+// ParseRole returns the role of a name, read, trade or admin.
+func ParseRole(s string) (Role, error) {
+	for i, name := range roleNames {
+		if i > 0 && s == name {
+			return Role(i), nil
+		}
+	}
+	return RoleNone, fmt.Errorf("unknown role: %q", s)
+}
+
+// TokenConfig A static token and the caller it identifies.
+type TokenConfig struct {
+	Name  string `toml:"name"`
+	Token string `toml:"token"`
+	Role  string `toml:"role"`
+}
+
+// AuthConfig Authentication of the gRPC API. Callers present a static token
+// or a JWT signed with HS256 by JWTSecret, whose "sub" claim names the caller
+// and "role" claim gives the role. With neither the API is open to anyone.
+type AuthConfig struct {
+	Tokens      []TokenConfig `toml:"tokens"`
+	JWTSecret   string        `toml:"jwt_secret"`
+	JWTIssuer   string        `toml:"jwt_issuer"`
+	JWTAudience string        `toml:"jwt_audience"`
+}
+
+// Enabled tells whether callers must authenticate.
+func (c AuthConfig) Enabled() bool {
+	return len(c.Tokens) > 0 || c.JWTSecret != ""
+}
+
+// Identity An authenticated caller.
+type Identity struct {
+	Name string
+	Role Role
+}
+
+// Authenticator Verifies the tokens of the callers.
+type Authenticator struct {
+	tokens   map[[sha256.Size]byte]Identity
+	secret   []byte
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// NewAuthenticator checks the config and returns its authenticator.
+func NewAuthenticator(conf AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{
+		tokens:   map[[sha256.Size]byte]Identity{},
+		secret:   []byte(conf.JWTSecret),
+		issuer:   conf.JWTIssuer,
+		audience: conf.JWTAudience,
+		now:      time.Now,
+	}
+	for _, t := range conf.Tokens {
+		if t.Name == "" || t.Token == "" {
+			return nil, errors.New("auth token needs a name and a token")
+		}
+		role, err := ParseRole(t.Role)
+		if err != nil {
+			return nil, fmt.Errorf("auth token %s: %v", t.Name, err)
+		}
+		sum := sha256.Sum256([]byte(t.Token))
+		if _, ok := a.tokens[sum]; ok {
+			return nil, fmt.Errorf("auth token %s: token is used twice", t.Name)
+		}
+		a.tokens[sum] = Identity{Name: t.Name, Role: role}
+	}
+	return a, nil
+}
+
+// Authenticate returns the caller of a static token or a JWT.
+func (a *Authenticator) Authenticate(token string) (Identity, error) {
+	if token == "" {
+		return Identity{}, errors.New("no token")
+	}
+	// The tokens are looked up by hash, so the lookup time does not tell
+	// how much of a guessed token is right.
+	if id, ok := a.tokens[sha256.Sum256([]byte(token))]; ok {
+		return id, nil
+	}
+	if len(a.secret) > 0 && strings.Count(token, ".") == 2 {
+		return a.verifyJWT(token)
+	}
+	return Identity{}, errors.New("unknown token")
+}
+
+// jwtClaims The claims of a JWT that are checked. Audience is a string or a
+// list of strings.
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Role      string          `json:"role"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt int64           `json:"exp"`
+	NotBefore int64           `json:"nbf"`
+}
+
+func (c jwtClaims) hasAudience(aud string) bool {
+	var one string
+	if json.Unmarshal(c.Audience, &one) == nil {
+		return one == aud
+	}
+	var list []string
+	if json.Unmarshal(c.Audience, &list) == nil {
+		for _, a := range list {
+			if a == aud {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *Authenticator) verifyJWT(token string) (Identity, error) {
+	parts := strings.Split(token, ".")
+	enc := base64.RawURLEncoding
+	head, err := enc.DecodeString(parts[0])
+	if err != nil {
+		return Identity{}, fmt.Errorf("jwt header: %v", err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(head, &header); err != nil {
+		return Identity{}, fmt.Errorf("jwt header: %v", err)
+	}
+	if header.Alg != "HS256" {
+		return Identity{}, fmt.Errorf("jwt algorithm %q is not HS256", header.Alg)
+	}
+	sig, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return Identity{}, fmt.Errorf("jwt signature: %v", err)
+	}
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return Identity{}, errors.New("jwt signature mismatch")
+	}
+	payload, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return Identity{}, fmt.Errorf("jwt claims: %v", err)
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Identity{}, fmt.Errorf("jwt claims: %v", err)
+	}
+	now := a.now().Unix()
+	if claims.ExpiresAt == 0 || now >= claims.ExpiresAt {
+		return Identity{}, errors.New("jwt expired or without exp")
+	}
+	if now < claims.NotBefore {
+		return Identity{}, errors.New("jwt not valid yet")
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return Identity{}, fmt.Errorf("jwt issuer %q", claims.Issuer)
+	}
+	if a.audience != "" && !claims.hasAudience(a.audience) {
+		return Identity{}, errors.New("jwt audience mismatch")
+	}
+	if claims.Subject == "" {
+		return Identity{}, errors.New("jwt without sub")
+	}
+	role, err := ParseRole(claims.Role)
+	if err != nil {
+		return Identity{}, fmt.Errorf("jwt: %v", err)
+	}
+	return Identity{Name: claims.Subject, Role: role}, nil
+}
+
+// tokenCredentials Sends a bearer token with every RPC.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity is false so a token also reaches a server on
+// localhost without TLS.
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// TokenCredentials returns the dial option sending the token with every RPC,
+// none for an empty token.
+func TokenCredentials(token string) []grpc.DialOption {
+	if token == "" {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithPerRPCCredentials(tokenCredentials(token))}
+}
@@ -0,0 +1,105 @@
+package bitcocheck
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+// signJWT returns a JWT of the claims JSON with the header alg, signed with
+// HS256 by secret.
+func signJWT(alg, claims, secret string) string {
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString([]byte(`{"alg":"`+alg+`","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthenticate(t *testing.T) {
+	a, err := NewAuthenticator(AuthConfig{
+		Tokens: []TokenConfig{
+			{Name: "bitcocli", Token: "read-token", Role: "read"},
+			{Name: "bitcobuy", Token: "trade-token", Role: "trade"},
+		},
+		JWTSecret:   "secret",
+		JWTIssuer:   "bitco-auth",
+		JWTAudience: "bitcocheck",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.now = func() time.Time { return time.Unix(1600000000, 0) }
+	tests := []struct {
+		name    string
+		token   string
+		want    Identity
+		wantErr bool
+	}{
+		{name: "static read", token: "read-token", want: Identity{Name: "bitcocli", Role: RoleRead}},
+		{name: "static trade", token: "trade-token", want: Identity{Name: "bitcobuy", Role: RoleTrade}},
+		{name: "unknown", token: "read-token2", wantErr: true},
+		{name: "empty", token: "", wantErr: true},
+		{name: "jwt", token: signJWT("HS256", `{"sub":"ops","role":"admin","iss":"bitco-auth","aud":"bitcocheck","exp":1600000100}`, "secret"), want: Identity{Name: "ops", Role: RoleAdmin}},
+		{name: "jwt audience list", token: signJWT("HS256", `{"sub":"ops","role":"read","iss":"bitco-auth","aud":["x","bitcocheck"],"exp":1600000100}`, "secret"), want: Identity{Name: "ops", Role: RoleRead}},
+		{name: "jwt expired", token: signJWT("HS256", `{"sub":"ops","role":"admin","iss":"bitco-auth","aud":"bitcocheck","exp":1600000000}`, "secret"), wantErr: true},
+		{name: "jwt without exp", token: signJWT("HS256", `{"sub":"ops","role":"admin","iss":"bitco-auth","aud":"bitcocheck"}`, "secret"), wantErr: true},
+		{name: "jwt not yet", token: signJWT("HS256", `{"sub":"ops","role":"admin","iss":"bitco-auth","aud":"bitcocheck","nbf":1600000050,"exp":1600000100}`, "secret"), wantErr: true},
+		{name: "jwt other secret", token: signJWT("HS256", `{"sub":"ops","role":"admin","iss":"bitco-auth","aud":"bitcocheck","exp":1600000100}`, "guess"), wantErr: true},
+		{name: "jwt alg none", token: signJWT("none", `{"sub":"ops","role":"admin","iss":"bitco-auth","aud":"bitcocheck","exp":1600000100}`, "secret"), wantErr: true},
+		{name: "jwt other issuer", token: signJWT("HS256", `{"sub":"ops","role":"admin","iss":"evil","aud":"bitcocheck","exp":1600000100}`, "secret"), wantErr: true},
+		{name: "jwt other audience", token: signJWT("HS256", `{"sub":"ops","role":"admin","iss":"bitco-auth","aud":"other","exp":1600000100}`, "secret"), wantErr: true},
+		{name: "jwt unknown role", token: signJWT("HS256", `{"sub":"ops","role":"root","iss":"bitco-auth","aud":"bitcocheck","exp":1600000100}`, "secret"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.Authenticate(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAuthenticatorErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		conf AuthConfig
+	}{
+		{name: "unknown role", conf: AuthConfig{Tokens: []TokenConfig{{Name: "a", Token: "t", Role: "root"}}}},
+		{name: "no token", conf: AuthConfig{Tokens: []TokenConfig{{Name: "a", Role: "read"}}}},
+		{name: "same token", conf: AuthConfig{Tokens: []TokenConfig{{Name: "a", Token: "t", Role: "read"}, {Name: "b", Token: "t", Role: "admin"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewAuthenticator(tt.conf); err == nil {
+				t.Errorf("NewAuthenticator() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role Role
+		need Role
+		want bool
+	}{
+		{RoleRead, RoleRead, true},
+		{RoleRead, RoleTrade, false},
+		{RoleTrade, RoleRead, true},
+		{RoleTrade, RoleAdmin, false},
+		{RoleAdmin, RoleTrade, true},
+		{RoleNone, RoleRead, false},
+	}
+	for _, tt := range tests {
+		if got := tt.role.Allows(tt.need); got != tt.want {
+			t.Errorf("%v.Allows(%v) = %v, want %v", tt.role, tt.need, got, tt.want)
+		}
+	}
+}
@@ -1,14 +1,64 @@
 package bitcocheck
 
 import (
+	"context"
 	"encoding/json"
 	fmt "fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
 
 type Config struct {
-	Main MainConfig `toml:"main"`
+	Main    MainConfig    `toml:"main"`
+	Ticker  TickerConfig  `toml:"ticker"`
+	Candles CandlesConfig `toml:"candles"`
+	Store   StoreConfig   `toml:"store"`
+	Server  ServerConfig  `toml:"server"`
+	Metrics MetricsConfig `toml:"metrics"`
+	Tracing TracingConfig `toml:"tracing"`
+	Risk    RiskConfig    `toml:"risk"`
+	Paper   PaperConfig   `toml:"paper"`
+	Orders  OrdersConfig  `toml:"orders"`
+
+	Recurring []RecurringConfig `toml:"recurring"`
+
+	// recorder also gets the requests made with this config.
+	recorder func(RequestEvent)
+	// ctx is the parent of the trace spans of the requests.
+	ctx context.Context
+}
+
+// WithContext returns a copy of the config whose requests to the Coincheck
+// API are traced as children of the span in ctx. The requests are not
+// cancelled with ctx: an order that was sent gets its answer.
+func (c Config) WithContext(ctx context.Context) Config {
+	c.ctx = ctx
+	return c
+}
+
+// WithRecorder returns a copy of the config whose requests to the Coincheck
+// API are also passed to fn, e.g. to record the requests of one RPC. The
+// recorder of c, if any, still gets them.
+func (c Config) WithRecorder(fn func(RequestEvent)) Config {
+	if prev := c.recorder; prev != nil {
+		c.recorder = func(e RequestEvent) {
+			prev(e)
+			fn(e)
+		}
+		return c
+	}
+	c.recorder = fn
+	return c
+}
+
+func (c Config) apiInfo(url, body string) APIInfo {
+	a := NewAPIInfo(c.Main.Access, c.Main.Secret, url, body, c.Main.Debug)
+	a.Recorder = c.recorder
+	a.Ctx = c.ctx
+	return a
 }
 
 type MainConfig struct {
@@ -17,6 +67,161 @@ type MainConfig struct {
 	Debug  bool   `toml:"debug"`
 }
 
+// TickerConfig Settings for the ticker history collection.
+type TickerConfig struct {
+	Pairs          []string        `toml:"pairs"`
+	Schedule       string          `toml:"schedule"`
+	RollupSchedule string          `toml:"rollup_schedule"`
+	Retention      RetentionConfig `toml:"retention"`
+}
+
+// RetentionConfig How long each ticker history table is kept. Empty keeps it forever.
+type RetentionConfig struct {
+	Raw string `toml:"raw"`
+	M1  string `toml:"1m"`
+	M5  string `toml:"5m"`
+	H1  string `toml:"1h"`
+	D1  string `toml:"1d"`
+}
+
+// Lookup returns the retention of a resolution name, "raw" for tickhist itself.
+func (r RetentionConfig) Lookup(name string) (time.Duration, error) {
+	switch name {
+	case "raw":
+		return ParseRetention(r.Raw)
+	case "1m":
+		return ParseRetention(r.M1)
+	case "5m":
+		return ParseRetention(r.M5)
+	case "1h":
+		return ParseRetention(r.H1)
+	case "1d":
+		return ParseRetention(r.D1)
+	}
+	return 0, fmt.Errorf("unknown resolution: %s", name)
+}
+
+// CollectSpec returns the cron spec of the ticker collection.
+func (t TickerConfig) CollectSpec() string {
+	if t.Schedule == "" {
+		return "@every 1h"
+	}
+	return t.Schedule
+}
+
+// RollupSpec returns the cron spec of the roll-up and retention job.
+func (t TickerConfig) RollupSpec() string {
+	if t.RollupSchedule == "" {
+		return "@every 1m"
+	}
+	return t.RollupSchedule
+}
+
+// ServerConfig Settings of the gRPC server.
+type ServerConfig struct {
+	ShutdownTimeout string     `toml:"shutdown_timeout"`
+	TLS             TLSConfig  `toml:"tls"`
+	Auth            AuthConfig `toml:"auth"`
+	AuditLog        string     `toml:"audit_log"`
+	HaltFile        string     `toml:"halt_file"`
+}
+
+// HaltFilePath returns the file keeping the trading halt,
+// bitcocheck-halt.json when unset.
+func (c ServerConfig) HaltFilePath() string {
+	if c.HaltFile == "" {
+		return "bitcocheck-halt.json"
+	}
+	return c.HaltFile
+}
+
+// AuditLogPath returns the audit log file of the trading RPCs,
+// bitcocheck-audit.jsonl when unset.
+func (c ServerConfig) AuditLogPath() string {
+	if c.AuditLog == "" {
+		return "bitcocheck-audit.jsonl"
+	}
+	return c.AuditLog
+}
+
+// ShutdownTimeoutDuration returns how long a shutdown waits for the running
+// RPCs and jobs, 30s when unset.
+func (c ServerConfig) ShutdownTimeoutDuration() (time.Duration, error) {
+	if c.ShutdownTimeout == "" {
+		return 30 * time.Second, nil
+	}
+	return time.ParseDuration(c.ShutdownTimeout)
+}
+
+// MetricsConfig Settings of the Prometheus metrics endpoint.
+type MetricsConfig struct {
+	Addr            string `toml:"addr"`
+	AccountSchedule string `toml:"account_schedule"`
+}
+
+// ListenAddr returns the address of /metrics, localhost:9101 when unset.
+func (c MetricsConfig) ListenAddr() string {
+	if c.Addr == "" {
+		return "localhost:9101"
+	}
+	return c.Addr
+}
+
+// AccountSpec returns the cron spec of the balance and open order gauges.
+func (c MetricsConfig) AccountSpec() string {
+	if c.AccountSchedule == "" {
+		return "@every 1m"
+	}
+	return c.AccountSchedule
+}
+
+// CandlesConfig Settings for the candles built from trades of the ticker pairs.
+type CandlesConfig struct {
+	Schedule  string   `toml:"schedule"`
+	Intervals []string `toml:"intervals"`
+}
+
+// Spec returns the cron spec of the trade polling.
+func (c CandlesConfig) Spec() string {
+	if c.Schedule == "" {
+		return "@every 10s"
+	}
+	return c.Schedule
+}
+
+// IntervalList returns the intervals whose candles are persisted, 1m, 5m,
+// 1h and 1d when none are set.
+func (c CandlesConfig) IntervalList() ([]Resolution, error) {
+	if len(c.Intervals) == 0 {
+		return Resolutions, nil
+	}
+	intervals := []Resolution{}
+	for _, name := range c.Intervals {
+		d, err := ParseInterval(name)
+		if err != nil {
+			return intervals, err
+		}
+		intervals = append(intervals, Resolution{Name: name, Interval: d})
+	}
+	return intervals, nil
+}
+
+// PairList returns the configured pairs, btc_jpy when none are set.
+func (t TickerConfig) PairList() ([]Pair, error) {
+	if len(t.Pairs) == 0 {
+		return []Pair{Btcjpy}, nil
+	}
+	pairs := []Pair{}
+	for _, p := range t.Pairs {
+		pair, err := ParsePair(p)
+		if err != nil {
+			return pairs, err
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs, nil
+}
+
 // DecodeConfigToml ...
 func DecodeConfigToml(tomlfile string) (Config, error) {
 	var config Config
@@ -34,11 +239,11 @@ func targetAPI(api string) string {
 }
 
 // Tickercc You can get the latest information easily.
-func Tickercc(conf Config) (TickerItem, error) {
+func Tickercc(conf Config, pair Pair) (TickerItem, error) {
 	var tickerItem TickerItem
-	url := targetAPI("/api/ticker")
+	url := targetAPI(fmt.Sprintf("/api/ticker?pair=%s", pair.String()))
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Request()
 	if err != nil {
 		return tickerItem, err
@@ -60,20 +265,114 @@ func (p Pair) String() string {
 	return [...]string{"btc_jpy", "fct_jpy"}[p]
 }
 
+// ParsePair converts a pair name into a Pair. An empty name means btc_jpy.
+func ParsePair(s string) (Pair, error) {
+	switch s {
+	case "", "btc_jpy":
+		return Btcjpy, nil
+	case "fct_jpy", "ftc_jpy":
+		return Fctjpy, nil
+	}
+	return Btcjpy, fmt.Errorf("unknown pair: %s", s)
+}
+
+type tradeIntermediate struct {
+	ID        uint32          `json:"id"`
+	Amount    string          `json:"amount"`
+	Rate      json.RawMessage `json:"rate"`
+	Pair      string          `json:"pair"`
+	OrderType string          `json:"order_type"`
+	CreatedAt string          `json:"created_at"`
+}
+
+type TradesItemIntermediate struct {
+	Success    bool `json:"success"`
+	Pagination struct {
+		Limit         uint32 `json:"limit"`
+		Order         string `json:"order"`
+		StartingAfter string `json:"starting_after"`
+		EndingBefore  string `json:"ending_before"`
+	} `json:"pagination"`
+	Data []tradeIntermediate `json:"data"`
+}
+
+// decodeTrades reads the snake_case trades response. The rate may be a
+// number or a quoted number.
+func decodeTrades(jsonBlob []byte) (TradesItem, error) {
+	var item TradesItem
+	var intermediate TradesItemIntermediate
+	if err := json.Unmarshal(jsonBlob, &intermediate); err != nil {
+		return item, err
+	}
+	item.Success = intermediate.Success
+	item.Pagination = &Pagenation{
+		Limit:         intermediate.Pagination.Limit,
+		Order:         intermediate.Pagination.Order,
+		StartingAfter: intermediate.Pagination.StartingAfter,
+		EndingBefore:  intermediate.Pagination.EndingBefore,
+	}
+	for _, d := range intermediate.Data {
+		rate, err := strconv.ParseFloat(strings.Trim(string(d.Rate), `"`), 64)
+		if err != nil {
+			return item, err
+		}
+		trade := &TradeData{
+			ID:        d.ID,
+			Amount:    d.Amount,
+			Pair:      d.Pair,
+			OrderType: d.OrderType,
+			CreatedAt: d.CreatedAt,
+		}
+		trade.setRate(rate)
+		item.Data = append(item.Data, trade)
+	}
+	return item, nil
+}
+
+// setRate sets the rate of a trade, and the float Rate still read by the
+// clients built before RateV2.
+func (t *TradeData) setRate(rate float64) {
+	t.RateV2, t.Rate = rate, float32(rate)
+}
+
 // Tradescc You can get the latest transaction history.
 func Tradescc(conf Config, pair Pair) (TradesItem, error) {
 	var item TradesItem
 	url := targetAPI(fmt.Sprintf("/api/trades?pair=%s", pair.String()))
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Request()
 	if err != nil {
 		return item, err
 	}
-	if err := json.Unmarshal(jsonBlob, &item); err != nil {
+	return decodeTrades(jsonBlob)
+}
+
+// TradesPagecc You can get a page of the transaction history. Order is "desc"
+// (newest first) or "asc", StartingAfter and EndingBefore are trade IDs.
+func TradesPagecc(conf Config, pair Pair, page Pagenation) (TradesItem, error) {
+	var item TradesItem
+	query := fmt.Sprintf("pair=%s", pair.String())
+	if page.Limit > 0 {
+		query += fmt.Sprintf("&limit=%d", page.Limit)
+	}
+	if page.Order != "" {
+		query += fmt.Sprintf("&order=%s", page.Order)
+	}
+	if page.StartingAfter != "" {
+		query += fmt.Sprintf("&starting_after=%s", page.StartingAfter)
+	}
+	if page.EndingBefore != "" {
+		query += fmt.Sprintf("&ending_before=%s", page.EndingBefore)
+	}
+	url := targetAPI(fmt.Sprintf("/api/trades?%s", query))
+	body := ""
+	apiInfo := conf.apiInfo(url, body)
+	jsonBlob, err := apiInfo.Request()
+	if err != nil {
 		return item, err
 	}
-	return item, nil
+	return decodeTrades(jsonBlob)
 }
 
 type OrderBooksItemIntermediate struct {
@@ -81,19 +380,29 @@ type OrderBooksItemIntermediate struct {
 	Bids [][]string `json:"bids"`
 }
 
-// OrderBookscc Board information can be obtained.
-func OrderBookscc(conf Config) (OrderBooksItem, error) {
-	var item OrderBooksItem
-	url := targetAPI("/api/order_books")
+func orderBooks(conf Config, pair Pair) (OrderBooksItemIntermediate, error) {
+	var intermediate OrderBooksItemIntermediate
+	url := targetAPI(fmt.Sprintf("/api/order_books?pair=%s", pair.String()))
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Request()
 	if err != nil {
-		return item, err
+		return intermediate, err
 	}
 	// fmt.Println(string(jsonBlob))
-	var intermediate OrderBooksItemIntermediate
 	if err := json.Unmarshal(jsonBlob, &intermediate); err != nil {
+		return intermediate, err
+	}
+	return intermediate, nil
+}
+
+// OrderBookscc Board information can be obtained.
+//
+// Deprecated: use OrderBooksV2cc, which returns typed price levels.
+func OrderBookscc(conf Config, pair Pair) (OrderBooksItem, error) {
+	var item OrderBooksItem
+	intermediate, err := orderBooks(conf, pair)
+	if err != nil {
 		return item, err
 	}
 	asksOrderArray := []*OrderArray{}
@@ -119,6 +428,40 @@ func OrderBookscc(conf Config) (OrderBooksItem, error) {
 	return item, nil
 }
 
+// priceLevels converts the [rate, amount] pairs of the board into price levels.
+func priceLevels(orders [][]string) ([]*PriceLevel, error) {
+	levels := []*PriceLevel{}
+	for _, order := range orders {
+		if len(order) != 2 {
+			return levels, fmt.Errorf("unexpected order book entry: %v", order)
+		}
+		levels = append(levels, &PriceLevel{Rate: order[0], Amount: order[1]})
+	}
+	return levels, nil
+}
+
+// OrderBooksV2cc Board information with typed price levels.
+func OrderBooksV2cc(conf Config, pair Pair) (OrderBooksV2Item, error) {
+	var item OrderBooksV2Item
+	intermediate, err := orderBooks(conf, pair)
+	if err != nil {
+		return item, err
+	}
+	asks, err := priceLevels(intermediate.Asks)
+	if err != nil {
+		return item, err
+	}
+	bids, err := priceLevels(intermediate.Bids)
+	if err != nil {
+		return item, err
+	}
+	item.Pair = pair.String()
+	item.Timestamp = uint64(time.Now().Unix())
+	item.Asks = asks
+	item.Bids = bids
+	return item, nil
+}
+
 // OrderType Note method
 type OrderType int
 
@@ -153,7 +496,7 @@ func ExchangeOrdersRatecc(conf Config, order OrderType, pair Pair, amountprice A
 	var exchangeOrdersRateItem ExchangeOrdersRateItem
 	url := targetAPI(fmt.Sprintf("/api/exchange/orders/rate?order_type=%s&pair=%s&%s=%s", order.String(), pair.String(), amountprice, value))
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Request()
 	if err != nil {
 		return exchangeOrdersRateItem, err
@@ -171,7 +514,7 @@ func RatePaircc(conf Config, pair Pair) (RatePairItem, error) {
 	var ratePairItem RatePairItem
 	url := targetAPI(fmt.Sprintf("/api/rate/%s", pair.String()))
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Request()
 	if err != nil {
 		return ratePairItem, err
@@ -202,7 +545,7 @@ func MarketBuycc(conf Config, pair Pair, amount uint32) (MarketItem, error) {
 		return marketItem, err
 	}
 	body := string(payloadBytes)
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.PostRequest()
 	if err != nil {
 		return marketItem, err
@@ -235,7 +578,7 @@ func MarketSellcc(conf Config, pair Pair, amount uint32) (MarketItem, error) {
 		return marketItem, err
 	}
 	body := string(payloadBytes)
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.PostRequest()
 	if err != nil {
 		return marketItem, err
@@ -252,9 +595,9 @@ type LimitOrderPayload struct {
 	Pair      string `json:"pair"`
 	OrderType string `json:"order_type"`
 	Rate      string `json:"rate"`
-	Amount    string `json:"string"`
+	Amount    string `json:"amount"`
 	// Positonid    int64  `json:"position_id,omitempy"`
-	StopLossRate string `json"stop_loss_rate,omitempty"`
+	StopLossRate string `json:"stop_loss_rate,omitempty"`
 }
 
 // LimitOrdercc Limit order, spot trading, buy.
@@ -275,7 +618,7 @@ func LimitOrdercc(conf Config, pair Pair, ordertype OrderType, rate, amount, sto
 		return marketItem, err
 	}
 	body := string(payloadBytes)
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.PostRequest()
 	if err != nil {
 		return marketItem, err
@@ -293,7 +636,7 @@ func ExchangeOrdersOpenscc(conf Config) (OrdersOpensItem, error) {
 	var item OrdersOpensItem
 	url := targetAPI("/api/exchange/orders/opens")
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Request()
 	if err != nil {
 		return item, err
@@ -308,7 +651,7 @@ func DeleteExchangeOrdercc(conf Config, id uint32) (DeleteOrderItem, error) {
 	var item DeleteOrderItem
 	url := targetAPI(fmt.Sprintf("/api/exchange/orders/%d", id))
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Delete()
 	if err != nil {
 		return item, err
@@ -326,7 +669,7 @@ func ExchangeOrdersTransactionscc(conf Config) (OrdersTransactionsItem, error) {
 	var item OrdersTransactionsItem
 	url := targetAPI("/api/exchange/orders/transactions")
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Request()
 	if err != nil {
 		return item, err
@@ -334,15 +677,90 @@ func ExchangeOrdersTransactionscc(conf Config) (OrdersTransactionsItem, error) {
 	if err := json.Unmarshal(jsonBlob, &item); err != nil {
 		return item, err
 	}
+	var funds struct {
+		Transactions []struct {
+			Funds map[string]string `json:"funds"`
+		} `json:"transactions"`
+	}
+	if err := json.Unmarshal(jsonBlob, &funds); err != nil {
+		return item, err
+	}
+	for i, t := range funds.Transactions {
+		setFundsBase(item.Transactions[i], t.Funds)
+	}
 	return item, nil
 }
 
+// ExchangeOrdersTransactionsPagecc You can get a page of your transaction
+// history. Order is "desc" (newest first) or "asc", StartingAfter and
+// EndingBefore are transaction IDs.
+func ExchangeOrdersTransactionsPagecc(conf Config, page Pagenation) (OrdersTransactionsItem, error) {
+	var item OrdersTransactionsItem
+	query := []string{}
+	if page.Limit > 0 {
+		query = append(query, fmt.Sprintf("limit=%d", page.Limit))
+	}
+	if page.Order != "" {
+		query = append(query, fmt.Sprintf("order=%s", page.Order))
+	}
+	if page.StartingAfter != "" {
+		query = append(query, fmt.Sprintf("starting_after=%s", page.StartingAfter))
+	}
+	if page.EndingBefore != "" {
+		query = append(query, fmt.Sprintf("ending_before=%s", page.EndingBefore))
+	}
+	path := "/api/exchange/orders/transactions_pagination"
+	if len(query) > 0 {
+		path += "?" + strings.Join(query, "&")
+	}
+	url := targetAPI(path)
+	body := ""
+	apiInfo := conf.apiInfo(url, body)
+	jsonBlob, err := apiInfo.Request()
+	if err != nil {
+		return item, err
+	}
+	var intermediate struct {
+		Success bool                `json:"success"`
+		Data    []*TransactionsItem `json:"data"`
+	}
+	if err := json.Unmarshal(jsonBlob, &intermediate); err != nil {
+		return item, err
+	}
+	var funds struct {
+		Data []struct {
+			Funds map[string]string `json:"funds"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(jsonBlob, &funds); err != nil {
+		return item, err
+	}
+	for i, t := range funds.Data {
+		setFundsBase(intermediate.Data[i], t.Funds)
+	}
+	item.Success, item.Transactions = intermediate.Success, intermediate.Data
+	return item, nil
+}
+
+// setFundsBase sets the amount of the base currency of the pair of a
+// transaction from its funds, which are keyed by currency.
+func setFundsBase(t *TransactionsItem, funds map[string]string) {
+	if t == nil || funds == nil {
+		return
+	}
+	if t.Funds == nil {
+		t.Funds = &Funds{}
+	}
+	base, _ := currencies(t.Pair)
+	t.Funds.Base = funds[base]
+}
+
 // AccountsBalancecc You can check the balance of your account.
 func AccountsBalancecc(conf Config) (AccountsBalanceItem, error) {
 	var item AccountsBalanceItem
 	url := targetAPI("/api/accounts/balance")
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Request()
 	if err != nil {
 		return item, err
@@ -358,7 +776,7 @@ func Accountscc(conf Config) (AccountsItem, error) {
 	var item AccountsItem
 	url := targetAPI("/api/accounts")
 	body := ""
-	apiInfo := NewAPIInfo(conf.Main.Access, conf.Main.Secret, url, body, conf.Main.Debug)
+	apiInfo := conf.apiInfo(url, body)
 	jsonBlob, err := apiInfo.Request()
 	if err != nil {
 		return item, err
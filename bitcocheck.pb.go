@@ -55,6 +55,45 @@ func (m *Empty) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Empty proto.InternalMessageInfo
 
+type TickerParams struct {
+	Pair                 string   `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TickerParams) Reset()         { *m = TickerParams{} }
+func (m *TickerParams) String() string { return proto.CompactTextString(m) }
+func (*TickerParams) ProtoMessage()    {}
+func (*TickerParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{1}
+}
+
+func (m *TickerParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TickerParams.Unmarshal(m, b)
+}
+func (m *TickerParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TickerParams.Marshal(b, m, deterministic)
+}
+func (m *TickerParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickerParams.Merge(m, src)
+}
+func (m *TickerParams) XXX_Size() int {
+	return xxx_messageInfo_TickerParams.Size(m)
+}
+func (m *TickerParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickerParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickerParams proto.InternalMessageInfo
+
+func (m *TickerParams) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
 type TickerItem struct {
 	Last                 float32  `protobuf:"fixed32,1,opt,name=Last,json=last,proto3" json:"Last,omitempty"`
 	Bid                  float32  `protobuf:"fixed32,2,opt,name=Bid,json=bid,proto3" json:"Bid,omitempty"`
@@ -72,7 +111,7 @@ func (m *TickerItem) Reset()         { *m = TickerItem{} }
 func (m *TickerItem) String() string { return proto.CompactTextString(m) }
 func (*TickerItem) ProtoMessage()    {}
 func (*TickerItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{1}
+	return fileDescriptor_d34031f942b0a586, []int{2}
 }
 
 func (m *TickerItem) XXX_Unmarshal(b []byte) error {
@@ -153,7 +192,7 @@ func (m *TradesParams) Reset()         { *m = TradesParams{} }
 func (m *TradesParams) String() string { return proto.CompactTextString(m) }
 func (*TradesParams) ProtoMessage()    {}
 func (*TradesParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{2}
+	return fileDescriptor_d34031f942b0a586, []int{3}
 }
 
 func (m *TradesParams) XXX_Unmarshal(b []byte) error {
@@ -195,7 +234,7 @@ func (m *Pagenation) Reset()         { *m = Pagenation{} }
 func (m *Pagenation) String() string { return proto.CompactTextString(m) }
 func (*Pagenation) ProtoMessage()    {}
 func (*Pagenation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{3}
+	return fileDescriptor_d34031f942b0a586, []int{4}
 }
 
 func (m *Pagenation) XXX_Unmarshal(b []byte) error {
@@ -245,12 +284,14 @@ func (m *Pagenation) GetEndingBefore() string {
 }
 
 type TradeData struct {
-	ID                   uint32   `protobuf:"varint,1,opt,name=ID,json=iD,proto3" json:"ID,omitempty"`
-	Amount               string   `protobuf:"bytes,2,opt,name=Amount,json=amount,proto3" json:"Amount,omitempty"`
-	Rate                 float32  `protobuf:"fixed32,3,opt,name=Rate,json=rate,proto3" json:"Rate,omitempty"`
+	ID     uint32 `protobuf:"varint,1,opt,name=ID,json=iD,proto3" json:"ID,omitempty"`
+	Amount string `protobuf:"bytes,2,opt,name=Amount,json=amount,proto3" json:"Amount,omitempty"`
+	// Deprecated: use RateV2, a float is too coarse for the BTC/JPY rates.
+	Rate                 float32  `protobuf:"fixed32,3,opt,name=Rate,json=rate,proto3" json:"Rate,omitempty"` // Deprecated: Do not use.
 	Pair                 string   `protobuf:"bytes,4,opt,name=Pair,json=pair,proto3" json:"Pair,omitempty"`
 	OrderType            string   `protobuf:"bytes,5,opt,name=OrderType,json=orderType,proto3" json:"OrderType,omitempty"`
 	CreatedAt            string   `protobuf:"bytes,6,opt,name=CreatedAt,json=createdAt,proto3" json:"CreatedAt,omitempty"`
+	RateV2               float64  `protobuf:"fixed64,7,opt,name=RateV2,json=rateV2,proto3" json:"RateV2,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -260,7 +301,7 @@ func (m *TradeData) Reset()         { *m = TradeData{} }
 func (m *TradeData) String() string { return proto.CompactTextString(m) }
 func (*TradeData) ProtoMessage()    {}
 func (*TradeData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{4}
+	return fileDescriptor_d34031f942b0a586, []int{5}
 }
 
 func (m *TradeData) XXX_Unmarshal(b []byte) error {
@@ -295,6 +336,7 @@ func (m *TradeData) GetAmount() string {
 	return ""
 }
 
+// Deprecated: Do not use.
 func (m *TradeData) GetRate() float32 {
 	if m != nil {
 		return m.Rate
@@ -323,6 +365,13 @@ func (m *TradeData) GetCreatedAt() string {
 	return ""
 }
 
+func (m *TradeData) GetRateV2() float64 {
+	if m != nil {
+		return m.RateV2
+	}
+	return 0
+}
+
 type TradesItem struct {
 	Success              bool         `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Pagination           *Pagenation  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
@@ -336,7 +385,7 @@ func (m *TradesItem) Reset()         { *m = TradesItem{} }
 func (m *TradesItem) String() string { return proto.CompactTextString(m) }
 func (*TradesItem) ProtoMessage()    {}
 func (*TradesItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{5}
+	return fileDescriptor_d34031f942b0a586, []int{6}
 }
 
 func (m *TradesItem) XXX_Unmarshal(b []byte) error {
@@ -378,6 +427,258 @@ func (m *TradesItem) GetData() []*TradeData {
 	return nil
 }
 
+type CandlesParam struct {
+	Pair                 string   `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Interval             string   `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	From                 uint64   `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CandlesParam) Reset()         { *m = CandlesParam{} }
+func (m *CandlesParam) String() string { return proto.CompactTextString(m) }
+func (*CandlesParam) ProtoMessage()    {}
+func (*CandlesParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{7}
+}
+
+func (m *CandlesParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CandlesParam.Unmarshal(m, b)
+}
+func (m *CandlesParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CandlesParam.Marshal(b, m, deterministic)
+}
+func (m *CandlesParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CandlesParam.Merge(m, src)
+}
+func (m *CandlesParam) XXX_Size() int {
+	return xxx_messageInfo_CandlesParam.Size(m)
+}
+func (m *CandlesParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_CandlesParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CandlesParam proto.InternalMessageInfo
+
+func (m *CandlesParam) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *CandlesParam) GetInterval() string {
+	if m != nil {
+		return m.Interval
+	}
+	return ""
+}
+
+func (m *CandlesParam) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *CandlesParam) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type Candle struct {
+	Timestamp            uint64   `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Open                 float64  `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
+	High                 float64  `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low                  float64  `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close                float64  `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
+	Volume               float64  `protobuf:"fixed64,6,opt,name=volume,proto3" json:"volume,omitempty"`
+	Trades               uint32   `protobuf:"varint,7,opt,name=trades,proto3" json:"trades,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Candle) Reset()         { *m = Candle{} }
+func (m *Candle) String() string { return proto.CompactTextString(m) }
+func (*Candle) ProtoMessage()    {}
+func (*Candle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{8}
+}
+
+func (m *Candle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Candle.Unmarshal(m, b)
+}
+func (m *Candle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Candle.Marshal(b, m, deterministic)
+}
+func (m *Candle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Candle.Merge(m, src)
+}
+func (m *Candle) XXX_Size() int {
+	return xxx_messageInfo_Candle.Size(m)
+}
+func (m *Candle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Candle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Candle proto.InternalMessageInfo
+
+func (m *Candle) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Candle) GetOpen() float64 {
+	if m != nil {
+		return m.Open
+	}
+	return 0
+}
+
+func (m *Candle) GetHigh() float64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *Candle) GetLow() float64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *Candle) GetClose() float64 {
+	if m != nil {
+		return m.Close
+	}
+	return 0
+}
+
+func (m *Candle) GetVolume() float64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+func (m *Candle) GetTrades() uint32 {
+	if m != nil {
+		return m.Trades
+	}
+	return 0
+}
+
+type CandlesItem struct {
+	Pair                 string    `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Interval             string    `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Candles              []*Candle `protobuf:"bytes,3,rep,name=candles,proto3" json:"candles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CandlesItem) Reset()         { *m = CandlesItem{} }
+func (m *CandlesItem) String() string { return proto.CompactTextString(m) }
+func (*CandlesItem) ProtoMessage()    {}
+func (*CandlesItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{9}
+}
+
+func (m *CandlesItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CandlesItem.Unmarshal(m, b)
+}
+func (m *CandlesItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CandlesItem.Marshal(b, m, deterministic)
+}
+func (m *CandlesItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CandlesItem.Merge(m, src)
+}
+func (m *CandlesItem) XXX_Size() int {
+	return xxx_messageInfo_CandlesItem.Size(m)
+}
+func (m *CandlesItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_CandlesItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CandlesItem proto.InternalMessageInfo
+
+func (m *CandlesItem) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *CandlesItem) GetInterval() string {
+	if m != nil {
+		return m.Interval
+	}
+	return ""
+}
+
+func (m *CandlesItem) GetCandles() []*Candle {
+	if m != nil {
+		return m.Candles
+	}
+	return nil
+}
+
+type OrderBooksParams struct {
+	Pair                 string   `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Depth                uint32   `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderBooksParams) Reset()         { *m = OrderBooksParams{} }
+func (m *OrderBooksParams) String() string { return proto.CompactTextString(m) }
+func (*OrderBooksParams) ProtoMessage()    {}
+func (*OrderBooksParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{10}
+}
+
+func (m *OrderBooksParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderBooksParams.Unmarshal(m, b)
+}
+func (m *OrderBooksParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderBooksParams.Marshal(b, m, deterministic)
+}
+func (m *OrderBooksParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderBooksParams.Merge(m, src)
+}
+func (m *OrderBooksParams) XXX_Size() int {
+	return xxx_messageInfo_OrderBooksParams.Size(m)
+}
+func (m *OrderBooksParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderBooksParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderBooksParams proto.InternalMessageInfo
+
+func (m *OrderBooksParams) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *OrderBooksParams) GetDepth() uint32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
 type OrderArray struct {
 	Items                []string `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -389,7 +690,7 @@ func (m *OrderArray) Reset()         { *m = OrderArray{} }
 func (m *OrderArray) String() string { return proto.CompactTextString(m) }
 func (*OrderArray) ProtoMessage()    {}
 func (*OrderArray) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{6}
+	return fileDescriptor_d34031f942b0a586, []int{11}
 }
 
 func (m *OrderArray) XXX_Unmarshal(b []byte) error {
@@ -429,7 +730,7 @@ func (m *OrderBooksItem) Reset()         { *m = OrderBooksItem{} }
 func (m *OrderBooksItem) String() string { return proto.CompactTextString(m) }
 func (*OrderBooksItem) ProtoMessage()    {}
 func (*OrderBooksItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{7}
+	return fileDescriptor_d34031f942b0a586, []int{12}
 }
 
 func (m *OrderBooksItem) XXX_Unmarshal(b []byte) error {
@@ -464,6 +765,116 @@ func (m *OrderBooksItem) GetBids() []*OrderArray {
 	return nil
 }
 
+type PriceLevel struct {
+	Rate                 string   `protobuf:"bytes,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	Amount               string   `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PriceLevel) Reset()         { *m = PriceLevel{} }
+func (m *PriceLevel) String() string { return proto.CompactTextString(m) }
+func (*PriceLevel) ProtoMessage()    {}
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{13}
+}
+
+func (m *PriceLevel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PriceLevel.Unmarshal(m, b)
+}
+func (m *PriceLevel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PriceLevel.Marshal(b, m, deterministic)
+}
+func (m *PriceLevel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PriceLevel.Merge(m, src)
+}
+func (m *PriceLevel) XXX_Size() int {
+	return xxx_messageInfo_PriceLevel.Size(m)
+}
+func (m *PriceLevel) XXX_DiscardUnknown() {
+	xxx_messageInfo_PriceLevel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PriceLevel proto.InternalMessageInfo
+
+func (m *PriceLevel) GetRate() string {
+	if m != nil {
+		return m.Rate
+	}
+	return ""
+}
+
+func (m *PriceLevel) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+type OrderBooksV2Item struct {
+	Pair                 string        `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Timestamp            uint64        `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Asks                 []*PriceLevel `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"`
+	Bids                 []*PriceLevel `protobuf:"bytes,4,rep,name=bids,proto3" json:"bids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *OrderBooksV2Item) Reset()         { *m = OrderBooksV2Item{} }
+func (m *OrderBooksV2Item) String() string { return proto.CompactTextString(m) }
+func (*OrderBooksV2Item) ProtoMessage()    {}
+func (*OrderBooksV2Item) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{14}
+}
+
+func (m *OrderBooksV2Item) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderBooksV2Item.Unmarshal(m, b)
+}
+func (m *OrderBooksV2Item) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderBooksV2Item.Marshal(b, m, deterministic)
+}
+func (m *OrderBooksV2Item) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderBooksV2Item.Merge(m, src)
+}
+func (m *OrderBooksV2Item) XXX_Size() int {
+	return xxx_messageInfo_OrderBooksV2Item.Size(m)
+}
+func (m *OrderBooksV2Item) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderBooksV2Item.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderBooksV2Item proto.InternalMessageInfo
+
+func (m *OrderBooksV2Item) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *OrderBooksV2Item) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *OrderBooksV2Item) GetAsks() []*PriceLevel {
+	if m != nil {
+		return m.Asks
+	}
+	return nil
+}
+
+func (m *OrderBooksV2Item) GetBids() []*PriceLevel {
+	if m != nil {
+		return m.Bids
+	}
+	return nil
+}
+
 type ExchangeOrdersRateParam struct {
 	OrderType            string   `protobuf:"bytes,1,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
 	Pair                 string   `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
@@ -478,7 +889,7 @@ func (m *ExchangeOrdersRateParam) Reset()         { *m = ExchangeOrdersRateParam
 func (m *ExchangeOrdersRateParam) String() string { return proto.CompactTextString(m) }
 func (*ExchangeOrdersRateParam) ProtoMessage()    {}
 func (*ExchangeOrdersRateParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{8}
+	return fileDescriptor_d34031f942b0a586, []int{15}
 }
 
 func (m *ExchangeOrdersRateParam) XXX_Unmarshal(b []byte) error {
@@ -541,7 +952,7 @@ func (m *ExchangeOrdersRateItem) Reset()         { *m = ExchangeOrdersRateItem{}
 func (m *ExchangeOrdersRateItem) String() string { return proto.CompactTextString(m) }
 func (*ExchangeOrdersRateItem) ProtoMessage()    {}
 func (*ExchangeOrdersRateItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{9}
+	return fileDescriptor_d34031f942b0a586, []int{16}
 }
 
 func (m *ExchangeOrdersRateItem) XXX_Unmarshal(b []byte) error {
@@ -601,7 +1012,7 @@ func (m *RatePairParams) Reset()         { *m = RatePairParams{} }
 func (m *RatePairParams) String() string { return proto.CompactTextString(m) }
 func (*RatePairParams) ProtoMessage()    {}
 func (*RatePairParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{10}
+	return fileDescriptor_d34031f942b0a586, []int{17}
 }
 
 func (m *RatePairParams) XXX_Unmarshal(b []byte) error {
@@ -640,7 +1051,7 @@ func (m *RatePairItem) Reset()         { *m = RatePairItem{} }
 func (m *RatePairItem) String() string { return proto.CompactTextString(m) }
 func (*RatePairItem) ProtoMessage()    {}
 func (*RatePairItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{11}
+	return fileDescriptor_d34031f942b0a586, []int{18}
 }
 
 func (m *RatePairItem) XXX_Unmarshal(b []byte) error {
@@ -671,6 +1082,7 @@ func (m *RatePairItem) GetRate() string {
 type MarketBuyParams struct {
 	Pair                 string   `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
 	MarketBuyAmount      uint32   `protobuf:"varint,2,opt,name=market_buy_amount,json=marketBuyAmount,proto3" json:"market_buy_amount,omitempty"`
+	ClientOrderId        string   `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -680,7 +1092,7 @@ func (m *MarketBuyParams) Reset()         { *m = MarketBuyParams{} }
 func (m *MarketBuyParams) String() string { return proto.CompactTextString(m) }
 func (*MarketBuyParams) ProtoMessage()    {}
 func (*MarketBuyParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{12}
+	return fileDescriptor_d34031f942b0a586, []int{19}
 }
 
 func (m *MarketBuyParams) XXX_Unmarshal(b []byte) error {
@@ -715,9 +1127,17 @@ func (m *MarketBuyParams) GetMarketBuyAmount() uint32 {
 	return 0
 }
 
+func (m *MarketBuyParams) GetClientOrderId() string {
+	if m != nil {
+		return m.ClientOrderId
+	}
+	return ""
+}
+
 type MarketSellParam struct {
 	Pair                 string   `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
 	Amount               uint32   `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	ClientOrderId        string   `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -727,7 +1147,7 @@ func (m *MarketSellParam) Reset()         { *m = MarketSellParam{} }
 func (m *MarketSellParam) String() string { return proto.CompactTextString(m) }
 func (*MarketSellParam) ProtoMessage()    {}
 func (*MarketSellParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{13}
+	return fileDescriptor_d34031f942b0a586, []int{20}
 }
 
 func (m *MarketSellParam) XXX_Unmarshal(b []byte) error {
@@ -762,12 +1182,20 @@ func (m *MarketSellParam) GetAmount() uint32 {
 	return 0
 }
 
+func (m *MarketSellParam) GetClientOrderId() string {
+	if m != nil {
+		return m.ClientOrderId
+	}
+	return ""
+}
+
 type LimitOrderParams struct {
 	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Pair                 string   `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
 	Rate                 string   `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
 	Amount               string   `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
 	StopLossRate         string   `protobuf:"bytes,5,opt,name=stop_loss_rate,json=stopLossRate,proto3" json:"stop_loss_rate,omitempty"`
+	ClientOrderId        string   `protobuf:"bytes,6,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -777,7 +1205,7 @@ func (m *LimitOrderParams) Reset()         { *m = LimitOrderParams{} }
 func (m *LimitOrderParams) String() string { return proto.CompactTextString(m) }
 func (*LimitOrderParams) ProtoMessage()    {}
 func (*LimitOrderParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{14}
+	return fileDescriptor_d34031f942b0a586, []int{21}
 }
 
 func (m *LimitOrderParams) XXX_Unmarshal(b []byte) error {
@@ -833,6 +1261,13 @@ func (m *LimitOrderParams) GetStopLossRate() string {
 	return ""
 }
 
+func (m *LimitOrderParams) GetClientOrderId() string {
+	if m != nil {
+		return m.ClientOrderId
+	}
+	return ""
+}
+
 type MarketItem struct {
 	Success              string   `protobuf:"bytes,1,opt,name=success,proto3" json:"success,omitempty"`
 	Id                   uint64   `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
@@ -851,7 +1286,7 @@ func (m *MarketItem) Reset()         { *m = MarketItem{} }
 func (m *MarketItem) String() string { return proto.CompactTextString(m) }
 func (*MarketItem) ProtoMessage()    {}
 func (*MarketItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{15}
+	return fileDescriptor_d34031f942b0a586, []int{22}
 }
 
 func (m *MarketItem) XXX_Unmarshal(b []byte) error {
@@ -936,6 +1371,7 @@ type OpenItem struct {
 	PendingMarketBuyAmount string   `protobuf:"bytes,5,opt,name=pending_market_buy_amount,json=pendingMarketBuyAmount,proto3" json:"pending_market_buy_amount,omitempty"`
 	StopLossRate           string   `protobuf:"bytes,6,opt,name=stop_loss_rate,json=stopLossRate,proto3" json:"stop_loss_rate,omitempty"`
 	CreatedAt              string   `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Pair                   string   `protobuf:"bytes,8,opt,name=pair,proto3" json:"pair,omitempty"`
 	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
 	XXX_unrecognized       []byte   `json:"-"`
 	XXX_sizecache          int32    `json:"-"`
@@ -945,7 +1381,7 @@ func (m *OpenItem) Reset()         { *m = OpenItem{} }
 func (m *OpenItem) String() string { return proto.CompactTextString(m) }
 func (*OpenItem) ProtoMessage()    {}
 func (*OpenItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{16}
+	return fileDescriptor_d34031f942b0a586, []int{23}
 }
 
 func (m *OpenItem) XXX_Unmarshal(b []byte) error {
@@ -1015,6 +1451,13 @@ func (m *OpenItem) GetCreatedAt() string {
 	return ""
 }
 
+func (m *OpenItem) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
 type OrdersOpensItem struct {
 	Success              bool        `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Orders               []*OpenItem `protobuf:"bytes,2,rep,name=orders,proto3" json:"orders,omitempty"`
@@ -1027,7 +1470,7 @@ func (m *OrdersOpensItem) Reset()         { *m = OrdersOpensItem{} }
 func (m *OrdersOpensItem) String() string { return proto.CompactTextString(m) }
 func (*OrdersOpensItem) ProtoMessage()    {}
 func (*OrdersOpensItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{17}
+	return fileDescriptor_d34031f942b0a586, []int{24}
 }
 
 func (m *OrdersOpensItem) XXX_Unmarshal(b []byte) error {
@@ -1073,7 +1516,7 @@ func (m *DeleteOrderParam) Reset()         { *m = DeleteOrderParam{} }
 func (m *DeleteOrderParam) String() string { return proto.CompactTextString(m) }
 func (*DeleteOrderParam) ProtoMessage()    {}
 func (*DeleteOrderParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{18}
+	return fileDescriptor_d34031f942b0a586, []int{25}
 }
 
 func (m *DeleteOrderParam) XXX_Unmarshal(b []byte) error {
@@ -1113,7 +1556,7 @@ func (m *DeleteOrderItem) Reset()         { *m = DeleteOrderItem{} }
 func (m *DeleteOrderItem) String() string { return proto.CompactTextString(m) }
 func (*DeleteOrderItem) ProtoMessage()    {}
 func (*DeleteOrderItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{19}
+	return fileDescriptor_d34031f942b0a586, []int{26}
 }
 
 func (m *DeleteOrderItem) XXX_Unmarshal(b []byte) error {
@@ -1151,6 +1594,7 @@ func (m *DeleteOrderItem) GetId() uint32 {
 type Funds struct {
 	Btc                  string   `protobuf:"bytes,1,opt,name=btc,proto3" json:"btc,omitempty"`
 	Jpy                  string   `protobuf:"bytes,2,opt,name=jpy,proto3" json:"jpy,omitempty"`
+	Base                 string   `protobuf:"bytes,3,opt,name=base,proto3" json:"base,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1160,7 +1604,7 @@ func (m *Funds) Reset()         { *m = Funds{} }
 func (m *Funds) String() string { return proto.CompactTextString(m) }
 func (*Funds) ProtoMessage()    {}
 func (*Funds) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{20}
+	return fileDescriptor_d34031f942b0a586, []int{27}
 }
 
 func (m *Funds) XXX_Unmarshal(b []byte) error {
@@ -1195,6 +1639,13 @@ func (m *Funds) GetJpy() string {
 	return ""
 }
 
+func (m *Funds) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
 type TransactionsItem struct {
 	Id                   uint32   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	OrderId              uint32   `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -1215,7 +1666,7 @@ func (m *TransactionsItem) Reset()         { *m = TransactionsItem{} }
 func (m *TransactionsItem) String() string { return proto.CompactTextString(m) }
 func (*TransactionsItem) ProtoMessage()    {}
 func (*TransactionsItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{21}
+	return fileDescriptor_d34031f942b0a586, []int{28}
 }
 
 func (m *TransactionsItem) XXX_Unmarshal(b []byte) error {
@@ -1318,7 +1769,7 @@ func (m *OrdersTransactionsItem) Reset()         { *m = OrdersTransactionsItem{}
 func (m *OrdersTransactionsItem) String() string { return proto.CompactTextString(m) }
 func (*OrdersTransactionsItem) ProtoMessage()    {}
 func (*OrdersTransactionsItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{22}
+	return fileDescriptor_d34031f942b0a586, []int{29}
 }
 
 func (m *OrdersTransactionsItem) XXX_Unmarshal(b []byte) error {
@@ -1374,7 +1825,7 @@ func (m *AccountsBalanceItem) Reset()         { *m = AccountsBalanceItem{} }
 func (m *AccountsBalanceItem) String() string { return proto.CompactTextString(m) }
 func (*AccountsBalanceItem) ProtoMessage()    {}
 func (*AccountsBalanceItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{23}
+	return fileDescriptor_d34031f942b0a586, []int{30}
 }
 
 func (m *AccountsBalanceItem) XXX_Unmarshal(b []byte) error {
@@ -1484,7 +1935,7 @@ func (m *Fees) Reset()         { *m = Fees{} }
 func (m *Fees) String() string { return proto.CompactTextString(m) }
 func (*Fees) ProtoMessage()    {}
 func (*Fees) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{24}
+	return fileDescriptor_d34031f942b0a586, []int{31}
 }
 
 func (m *Fees) XXX_Unmarshal(b []byte) error {
@@ -1531,7 +1982,7 @@ func (m *ExchangeFees) Reset()         { *m = ExchangeFees{} }
 func (m *ExchangeFees) String() string { return proto.CompactTextString(m) }
 func (*ExchangeFees) ProtoMessage()    {}
 func (*ExchangeFees) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{25}
+	return fileDescriptor_d34031f942b0a586, []int{32}
 }
 
 func (m *ExchangeFees) XXX_Unmarshal(b []byte) error {
@@ -1585,7 +2036,7 @@ func (m *AccountsItem) Reset()         { *m = AccountsItem{} }
 func (m *AccountsItem) String() string { return proto.CompactTextString(m) }
 func (*AccountsItem) ProtoMessage()    {}
 func (*AccountsItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{26}
+	return fileDescriptor_d34031f942b0a586, []int{33}
 }
 
 func (m *AccountsItem) XXX_Unmarshal(b []byte) error {
@@ -1671,6 +2122,12 @@ func (m *AccountsItem) GetExchangeFees() *ExchangeFees {
 
 type TickerHistParam struct {
 	Limit                uint32   `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	From                 uint64   `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Order                string   `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	Pair                 string   `protobuf:"bytes,5,opt,name=pair,proto3" json:"pair,omitempty"`
+	Step                 uint32   `protobuf:"varint,6,opt,name=step,proto3" json:"step,omitempty"`
+	Cursor               string   `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1680,7 +2137,7 @@ func (m *TickerHistParam) Reset()         { *m = TickerHistParam{} }
 func (m *TickerHistParam) String() string { return proto.CompactTextString(m) }
 func (*TickerHistParam) ProtoMessage()    {}
 func (*TickerHistParam) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{27}
+	return fileDescriptor_d34031f942b0a586, []int{34}
 }
 
 func (m *TickerHistParam) XXX_Unmarshal(b []byte) error {
@@ -1708,8 +2165,51 @@ func (m *TickerHistParam) GetLimit() uint32 {
 	return 0
 }
 
+func (m *TickerHistParam) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *TickerHistParam) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *TickerHistParam) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
+func (m *TickerHistParam) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *TickerHistParam) GetStep() uint32 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *TickerHistParam) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
 type TickerHistItem struct {
 	Tickeritem           []*TickerItem `protobuf:"bytes,1,rep,name=tickeritem,proto3" json:"tickeritem,omitempty"`
+	NextCursor           string        `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -1719,7 +2219,7 @@ func (m *TickerHistItem) Reset()         { *m = TickerHistItem{} }
 func (m *TickerHistItem) String() string { return proto.CompactTextString(m) }
 func (*TickerHistItem) ProtoMessage()    {}
 func (*TickerHistItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d34031f942b0a586, []int{28}
+	return fileDescriptor_d34031f942b0a586, []int{35}
 }
 
 func (m *TickerHistItem) XXX_Unmarshal(b []byte) error {
@@ -1747,289 +2247,2312 @@ func (m *TickerHistItem) GetTickeritem() []*TickerItem {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Empty)(nil), "bitcocheck.Empty")
-	proto.RegisterType((*TickerItem)(nil), "bitcocheck.TickerItem")
-	proto.RegisterType((*TradesParams)(nil), "bitcocheck.TradesParams")
-	proto.RegisterType((*Pagenation)(nil), "bitcocheck.Pagenation")
-	proto.RegisterType((*TradeData)(nil), "bitcocheck.TradeData")
-	proto.RegisterType((*TradesItem)(nil), "bitcocheck.TradesItem")
-	proto.RegisterType((*OrderArray)(nil), "bitcocheck.OrderArray")
-	proto.RegisterType((*OrderBooksItem)(nil), "bitcocheck.OrderBooksItem")
-	proto.RegisterType((*ExchangeOrdersRateParam)(nil), "bitcocheck.ExchangeOrdersRateParam")
-	proto.RegisterType((*ExchangeOrdersRateItem)(nil), "bitcocheck.ExchangeOrdersRateItem")
-	proto.RegisterType((*RatePairParams)(nil), "bitcocheck.RatePairParams")
-	proto.RegisterType((*RatePairItem)(nil), "bitcocheck.RatePairItem")
-	proto.RegisterType((*MarketBuyParams)(nil), "bitcocheck.MarketBuyParams")
-	proto.RegisterType((*MarketSellParam)(nil), "bitcocheck.MarketSellParam")
-	proto.RegisterType((*LimitOrderParams)(nil), "bitcocheck.LimitOrderParams")
-	proto.RegisterType((*MarketItem)(nil), "bitcocheck.MarketItem")
-	proto.RegisterType((*OpenItem)(nil), "bitcocheck.OpenItem")
-	proto.RegisterType((*OrdersOpensItem)(nil), "bitcocheck.OrdersOpensItem")
-	proto.RegisterType((*DeleteOrderParam)(nil), "bitcocheck.DeleteOrderParam")
-	proto.RegisterType((*DeleteOrderItem)(nil), "bitcocheck.DeleteOrderItem")
-	proto.RegisterType((*Funds)(nil), "bitcocheck.Funds")
-	proto.RegisterType((*TransactionsItem)(nil), "bitcocheck.TransactionsItem")
-	proto.RegisterType((*OrdersTransactionsItem)(nil), "bitcocheck.OrdersTransactionsItem")
-	proto.RegisterType((*AccountsBalanceItem)(nil), "bitcocheck.AccountsBalanceItem")
-	proto.RegisterType((*Fees)(nil), "bitcocheck.Fees")
-	proto.RegisterType((*ExchangeFees)(nil), "bitcocheck.ExchangeFees")
-	proto.RegisterType((*AccountsItem)(nil), "bitcocheck.AccountsItem")
-	proto.RegisterType((*TickerHistParam)(nil), "bitcocheck.TickerHistParam")
-	proto.RegisterType((*TickerHistItem)(nil), "bitcocheck.TickerHistItem")
+func (m *TickerHistItem) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
 }
 
-func init() {
-	proto.RegisterFile("bitcocheck.proto", fileDescriptor_d34031f942b0a586)
+type ExportParam struct {
+	Dataset              string   `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Format               string   `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Pair                 string   `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`
+	From                 uint64   `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`
+	Interval             string   `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-var fileDescriptor_d34031f942b0a586 = []byte{
-	// 1668 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdd, 0x6e, 0x23, 0x49,
-	0x15, 0x8e, 0xff, 0xdd, 0xc7, 0x7f, 0xd9, 0x9a, 0x10, 0x7a, 0x32, 0xbb, 0x22, 0x5b, 0xcc, 0x32,
-	0x33, 0x0b, 0xca, 0x45, 0x10, 0x23, 0xad, 0x60, 0xa4, 0x8d, 0x93, 0x99, 0x9d, 0xa0, 0xac, 0x36,
-	0xf4, 0x64, 0x91, 0xb8, 0x6a, 0x95, 0xbb, 0xcb, 0x76, 0xc5, 0xed, 0xee, 0xa6, 0xab, 0x9c, 0xc5,
-	0xb7, 0x88, 0x0b, 0xb8, 0x47, 0xe2, 0x96, 0xb7, 0xe0, 0x05, 0x78, 0x03, 0xee, 0x78, 0x0e, 0x1e,
-	0x00, 0xd5, 0x4f, 0xff, 0xba, 0x93, 0x2c, 0xdc, 0xb9, 0x4e, 0x7d, 0x75, 0xea, 0x9c, 0xef, 0x3b,
-	0x55, 0x7d, 0xca, 0xb0, 0x3f, 0x63, 0xc2, 0x8b, 0xbc, 0x25, 0xf5, 0x56, 0x27, 0x71, 0x12, 0x89,
-	0x08, 0x41, 0x6e, 0xc1, 0x3d, 0xe8, 0xbc, 0x5d, 0xc7, 0x62, 0x8b, 0xff, 0xde, 0x00, 0xb8, 0x61,
-	0xde, 0x8a, 0x26, 0x97, 0x82, 0xae, 0x11, 0x82, 0xf6, 0x15, 0xe1, 0xc2, 0x6e, 0x1c, 0x37, 0x5e,
-	0x36, 0x9d, 0x76, 0x40, 0xb8, 0x40, 0xfb, 0xd0, 0x9a, 0x32, 0xdf, 0x6e, 0x2a, 0x53, 0x6b, 0xc6,
-	0x7c, 0x69, 0x39, 0xe3, 0x2b, 0xbb, 0xa5, 0x2d, 0x84, 0xaf, 0xe4, 0xba, 0xf7, 0x6c, 0xb1, 0xb4,
-	0xdb, 0x7a, 0xdd, 0x92, 0x2d, 0x96, 0x12, 0x75, 0x15, 0x7d, 0x67, 0x77, 0x34, 0x2a, 0x88, 0xbe,
-	0x43, 0x87, 0xd0, 0xfd, 0x6d, 0x14, 0x6c, 0xd6, 0xd4, 0xee, 0x2a, 0x63, 0xf7, 0x4e, 0x8d, 0xd0,
-	0xc7, 0x60, 0xdd, 0xb0, 0x35, 0xe5, 0x82, 0xac, 0x63, 0xbb, 0x77, 0xdc, 0x78, 0xd9, 0x76, 0x2c,
-	0x91, 0x1a, 0x30, 0x86, 0xe1, 0x4d, 0x42, 0x7c, 0xca, 0xaf, 0x49, 0x42, 0xd6, 0x5c, 0xee, 0x75,
-	0x4d, 0x58, 0xa2, 0x62, 0xb4, 0x9c, 0x76, 0x4c, 0x58, 0x82, 0xff, 0xd8, 0x00, 0xb8, 0x26, 0x0b,
-	0x1a, 0x12, 0xc1, 0xa2, 0x10, 0x1d, 0x40, 0xe7, 0x8a, 0xad, 0x99, 0xce, 0x63, 0xe4, 0x74, 0x02,
-	0x39, 0x90, 0xd6, 0x6f, 0x12, 0x9f, 0x26, 0x2a, 0x15, 0xcb, 0xe9, 0x44, 0x72, 0x80, 0x9e, 0xc3,
-	0xe8, 0x83, 0x20, 0x89, 0x60, 0xe1, 0xe2, 0x6c, 0x2e, 0x68, 0xa2, 0xd2, 0xb2, 0x9c, 0x11, 0x2f,
-	0x1a, 0x11, 0x86, 0xe1, 0xdb, 0xd0, 0x67, 0xe1, 0x62, 0x4a, 0xe7, 0x51, 0x42, 0x55, 0xa2, 0x96,
-	0x33, 0xa4, 0x05, 0x1b, 0xfe, 0x5b, 0x03, 0x2c, 0x15, 0xe9, 0x05, 0x11, 0x04, 0x8d, 0xa1, 0x79,
-	0x79, 0x61, 0x02, 0x68, 0xb2, 0x0b, 0x99, 0xfc, 0xd9, 0x3a, 0xda, 0x84, 0xc2, 0x6c, 0xdf, 0x25,
-	0x6a, 0x24, 0xd3, 0x71, 0x88, 0xa0, 0x86, 0xcd, 0x76, 0x42, 0x04, 0xcd, 0x52, 0x6c, 0xe7, 0x29,
-	0x4a, 0x92, 0x54, 0xf4, 0x37, 0xdb, 0x98, 0x2a, 0x52, 0x2d, 0xc7, 0x8a, 0x52, 0x83, 0x9c, 0x3d,
-	0x4f, 0x28, 0x11, 0xd4, 0x3f, 0x13, 0x8a, 0x5d, 0xcb, 0xb1, 0xbc, 0xd4, 0x80, 0xff, 0x22, 0x55,
-	0x56, 0x1c, 0x2a, 0x95, 0x6d, 0xe8, 0xf1, 0x8d, 0xe7, 0x51, 0xce, 0x55, 0x7c, 0x7d, 0x27, 0x1d,
-	0xa2, 0xd7, 0x00, 0x31, 0x59, 0x30, 0x4d, 0xa3, 0x0a, 0x74, 0x70, 0x7a, 0x78, 0x52, 0x28, 0xa5,
-	0x9c, 0x64, 0xa7, 0x80, 0x44, 0xaf, 0xa0, 0xed, 0x13, 0x41, 0xec, 0xd6, 0x71, 0xeb, 0xe5, 0xe0,
-	0xf4, 0x07, 0xc5, 0x15, 0x19, 0x23, 0x8e, 0x82, 0x60, 0x0c, 0xa0, 0xf2, 0x38, 0x4b, 0x12, 0xb2,
-	0x95, 0x9a, 0x30, 0x41, 0xd7, 0x32, 0x90, 0x96, 0xd4, 0x44, 0x0d, 0xf0, 0x12, 0xc6, 0x0a, 0x33,
-	0x8d, 0xa2, 0x95, 0x0e, 0xf9, 0x73, 0x68, 0x13, 0xbe, 0xd2, 0xb0, 0x4a, 0x48, 0xb9, 0x37, 0x47,
-	0x61, 0x24, 0x76, 0xc6, 0x7c, 0x6e, 0x37, 0x1f, 0xc6, 0x4a, 0x0c, 0xfe, 0x53, 0x03, 0x7e, 0xf8,
-	0xf6, 0x0f, 0xde, 0x92, 0x84, 0x0b, 0xaa, 0x26, 0xb9, 0x14, 0x43, 0x55, 0x1a, 0xfa, 0x04, 0x40,
-	0x11, 0xec, 0x0a, 0x49, 0x79, 0xa3, 0x4a, 0x39, 0x02, 0x25, 0x8c, 0x91, 0x53, 0x8b, 0x74, 0x0c,
-	0x03, 0x2d, 0x6b, 0x9c, 0x30, 0x8f, 0x9a, 0x52, 0x2a, 0x9a, 0x64, 0xc2, 0x77, 0x24, 0xd8, 0xa4,
-	0x15, 0xa4, 0x07, 0x58, 0xc0, 0xe1, 0x6e, 0x14, 0x8f, 0x68, 0x85, 0x40, 0x15, 0x4b, 0xba, 0xbf,
-	0x2a, 0x9c, 0x03, 0xe8, 0x14, 0x77, 0xd6, 0x03, 0x59, 0x7a, 0x3a, 0x04, 0xb3, 0xa9, 0x19, 0xe1,
-	0xe7, 0x30, 0xd6, 0xd9, 0xb2, 0x24, 0x3f, 0x5b, 0x71, 0xf5, 0x6c, 0x61, 0x18, 0xa6, 0xa8, 0xf4,
-	0x8e, 0x50, 0xfb, 0x36, 0xf2, 0x7d, 0xf1, 0x6f, 0x60, 0xf2, 0x35, 0x49, 0x56, 0x54, 0x4c, 0x37,
-	0xdb, 0xfb, 0x5d, 0xa1, 0xcf, 0xe1, 0xa3, 0xb5, 0x82, 0xb9, 0xb3, 0xcd, 0xd6, 0x25, 0xf9, 0x71,
-	0x18, 0x39, 0x93, 0x75, 0xba, 0x5e, 0x9f, 0x12, 0xfc, 0x26, 0x75, 0xf9, 0x81, 0x06, 0x81, 0x16,
-	0xa4, 0xce, 0x65, 0x9e, 0x9b, 0xf6, 0x93, 0xe6, 0xf6, 0xe7, 0x06, 0xec, 0xab, 0x3b, 0x40, 0xf1,
-	0x69, 0x62, 0x1a, 0x43, 0x93, 0xf9, 0x6a, 0x79, 0xcb, 0x69, 0x32, 0xbf, 0x56, 0xc2, 0x34, 0xbd,
-	0x56, 0x81, 0xd6, 0x7b, 0x08, 0x44, 0xcf, 0x61, 0xcc, 0x45, 0x14, 0xbb, 0x41, 0xc4, 0xb9, 0xab,
-	0x56, 0xe9, 0x83, 0x39, 0x94, 0xd6, 0xab, 0x88, 0x2b, 0x19, 0xf1, 0xbf, 0x1b, 0x00, 0x3a, 0x95,
-	0x3a, 0x45, 0xad, 0x5c, 0x51, 0x1d, 0x5e, 0x53, 0x5d, 0x80, 0x26, 0xbc, 0xef, 0x1d, 0x4a, 0xb9,
-	0x58, 0x77, 0xee, 0x87, 0xdd, 0x48, 0xbb, 0xbb, 0x91, 0x66, 0x7c, 0xf4, 0x0a, 0x7c, 0x7c, 0x02,
-	0x60, 0x2e, 0x12, 0x97, 0x08, 0xbb, 0x5f, 0xbd, 0x5a, 0xfe, 0xd3, 0x80, 0xfe, 0x37, 0x31, 0x0d,
-	0x55, 0x6a, 0x39, 0xbf, 0x23, 0x95, 0x40, 0x39, 0xa8, 0x66, 0xcd, 0x09, 0xca, 0xf2, 0x1b, 0x99,
-	0xfc, 0x3e, 0x83, 0x71, 0xac, 0x6f, 0x55, 0xb7, 0x94, 0xe7, 0xc8, 0x58, 0x75, 0x75, 0xa0, 0x2f,
-	0xe0, 0x69, 0x0a, 0xdb, 0xad, 0x28, 0x9d, 0xfd, 0xa1, 0x01, 0x7c, 0x5d, 0x2e, 0xac, 0xef, 0x49,
-	0x45, 0x39, 0xed, 0x5e, 0x35, 0xed, 0xdf, 0xc1, 0x44, 0x1f, 0x54, 0x99, 0xfb, 0x63, 0xb7, 0xea,
-	0xcf, 0xa0, 0xab, 0x92, 0x4e, 0xaf, 0xa4, 0x83, 0xd2, 0x95, 0x64, 0xc8, 0x73, 0x0c, 0x06, 0x63,
-	0xd8, 0xbf, 0xa0, 0x01, 0x15, 0x34, 0xaf, 0xdc, 0x2a, 0xb1, 0xf8, 0x97, 0x30, 0x29, 0x60, 0x1e,
-	0xd9, 0x3e, 0x2f, 0x2b, 0xbd, 0xf8, 0xa7, 0xd0, 0x79, 0xb7, 0x09, 0x7d, 0x2e, 0xbf, 0xd0, 0x33,
-	0xe1, 0x99, 0x2a, 0x94, 0x3f, 0xa5, 0xe5, 0x36, 0xde, 0x1a, 0xa5, 0xe4, 0x4f, 0xfc, 0xd7, 0x26,
-	0xec, 0xdf, 0x24, 0x24, 0xe4, 0xc4, 0x93, 0x37, 0x3d, 0xaf, 0xd5, 0xf9, 0x29, 0xf4, 0xb5, 0xce,
-	0xd9, 0x3e, 0x3d, 0x35, 0xbe, 0xf4, 0x2b, 0x3c, 0xb6, 0x2a, 0x3c, 0xa2, 0x17, 0xd0, 0x99, 0xcb,
-	0x58, 0x94, 0xca, 0x83, 0xd3, 0x8f, 0x8a, 0xcc, 0xa8, 0x20, 0x1d, 0x3d, 0x9f, 0x95, 0x66, 0xa7,
-	0xe6, 0xa8, 0x76, 0x0b, 0xe7, 0xe3, 0x53, 0x18, 0xce, 0x29, 0x75, 0xbd, 0x4d, 0x92, 0xd0, 0xd0,
-	0xdb, 0x1a, 0xe5, 0x06, 0x73, 0x4a, 0xcf, 0x8d, 0x49, 0x26, 0x39, 0xa7, 0xd4, 0x94, 0xb2, 0xfc,
-	0x29, 0xbf, 0x9e, 0x01, 0xfb, 0xfd, 0x86, 0xf9, 0x4c, 0x6c, 0x6d, 0x4b, 0xc7, 0x98, 0x19, 0xe4,
-	0x36, 0x9c, 0xf9, 0xd4, 0x06, 0xbd, 0x8d, 0xfc, 0x2d, 0x2f, 0x6c, 0xad, 0xff, 0x0e, 0x37, 0xf7,
-	0xeb, 0xf0, 0x25, 0x0c, 0x45, 0x01, 0x6d, 0x8a, 0xe1, 0xe3, 0xca, 0xc7, 0xb2, 0xe4, 0xcd, 0x29,
-	0xad, 0xc0, 0xff, 0x6a, 0xc2, 0x93, 0x33, 0xcf, 0x93, 0x65, 0xcc, 0xa7, 0x24, 0x20, 0xa1, 0xf7,
-	0xd8, 0x47, 0x62, 0x47, 0xd0, 0x54, 0xf4, 0x56, 0x2e, 0xfa, 0xa7, 0x30, 0xbc, 0x8d, 0xb7, 0x6e,
-	0x42, 0x39, 0x4d, 0xee, 0xa8, 0x6f, 0x0e, 0xdc, 0xe0, 0x36, 0xde, 0x3a, 0xc6, 0x24, 0x21, 0x33,
-	0xe1, 0xe5, 0x10, 0xad, 0xc2, 0x60, 0x26, 0xbc, 0x0c, 0xf2, 0x19, 0x4c, 0xa4, 0x97, 0x80, 0x86,
-	0xbe, 0xcb, 0x42, 0x77, 0xc3, 0xb3, 0x73, 0x75, 0x1b, 0x6f, 0xaf, 0x68, 0xe8, 0x5f, 0x86, 0xdf,
-	0x72, 0x79, 0xbe, 0x27, 0xd2, 0x53, 0x11, 0xa6, 0x25, 0x92, 0x1b, 0xe4, 0xb0, 0xa7, 0xd0, 0x37,
-	0xde, 0xd2, 0x3b, 0xa7, 0xa7, 0xdd, 0x08, 0x39, 0x65, 0x3c, 0x08, 0xa3, 0x55, 0x4f, 0x2f, 0x15,
-	0xe9, 0x2a, 0x9f, 0xce, 0x84, 0x51, 0x4b, 0xae, 0xba, 0xa0, 0xb3, 0x6c, 0x95, 0x9a, 0x1a, 0x64,
-	0xab, 0xe4, 0x14, 0xfe, 0x12, 0xda, 0xef, 0x28, 0xe5, 0xe8, 0x19, 0x58, 0x82, 0xac, 0x68, 0xe2,
-	0xca, 0xea, 0xd0, 0x87, 0xa2, 0xaf, 0x0c, 0xef, 0x28, 0x95, 0x93, 0xeb, 0x6c, 0x52, 0xd3, 0xd9,
-	0x5f, 0x9b, 0x49, 0xec, 0xc3, 0x30, 0xfd, 0x7c, 0x2b, 0x4f, 0xaf, 0x40, 0x3a, 0x77, 0x25, 0xf3,
-	0x0d, 0x55, 0xd7, 0xfb, 0xa5, 0xba, 0xa6, 0x94, 0x3b, 0xdd, 0x99, 0xf0, 0x7e, 0x1d, 0x6f, 0x25,
-	0x74, 0x6e, 0xa0, 0xcd, 0xfb, 0xa0, 0x73, 0x05, 0xc5, 0xff, 0x6c, 0xc2, 0x30, 0x55, 0xff, 0x7f,
-	0x3b, 0xf2, 0xb2, 0x2f, 0xa0, 0x6b, 0xc2, 0x82, 0xb4, 0x2f, 0x50, 0x03, 0xf4, 0x02, 0x26, 0xcc,
-	0xa7, 0xa1, 0x60, 0x62, 0xeb, 0x72, 0x41, 0xc4, 0x86, 0x1b, 0xed, 0xc7, 0xa9, 0xf9, 0x83, 0xb2,
-	0x4a, 0xa0, 0x0a, 0x8a, 0x85, 0x2e, 0xf1, 0xfd, 0x44, 0x6e, 0xa8, 0x2b, 0x60, 0x6c, 0xcc, 0x67,
-	0xda, 0x8a, 0x5e, 0xc1, 0x7e, 0x60, 0xae, 0xe5, 0x80, 0xde, 0xd1, 0x84, 0x2c, 0x74, 0x15, 0x8c,
-	0x9c, 0x89, 0xb1, 0x5f, 0x19, 0x73, 0x99, 0xed, 0xde, 0x43, 0x6c, 0xf7, 0xcb, 0x6c, 0xa3, 0x37,
-	0x30, 0xa2, 0x86, 0x6d, 0x39, 0xcf, 0x55, 0x15, 0x0c, 0x4e, 0xed, 0x22, 0x71, 0x45, 0x39, 0x9c,
-	0x21, 0x2d, 0x8c, 0xf0, 0x0b, 0x98, 0xe8, 0x17, 0xcf, 0x7b, 0xc6, 0x85, 0xbe, 0x5e, 0x0f, 0x40,
-	0x3f, 0x11, 0x4a, 0xef, 0x05, 0xfc, 0x1e, 0xc6, 0x39, 0x50, 0x11, 0xfe, 0x1a, 0x40, 0x28, 0x8b,
-	0x6c, 0x53, 0xeb, 0x7a, 0xd1, 0xfc, 0x29, 0xe5, 0x14, 0x90, 0xa7, 0xff, 0xe8, 0x83, 0x75, 0x1e,
-	0xb1, 0x50, 0x81, 0xd0, 0x2f, 0xa0, 0xab, 0x71, 0xa8, 0x74, 0xdd, 0xa9, 0x07, 0xd9, 0xd1, 0x3d,
-	0xee, 0xf0, 0x1e, 0xfa, 0x2a, 0x7d, 0xa9, 0xc9, 0x70, 0xd0, 0xb3, 0x5d, 0x5c, 0x96, 0xcf, 0xd1,
-	0x51, 0xfd, 0xa4, 0x71, 0xf4, 0x2b, 0xe8, 0xea, 0xc7, 0x00, 0xb2, 0x77, 0x1a, 0x75, 0xf3, 0xc8,
-	0xaa, 0x84, 0x91, 0x3d, 0x1d, 0xf0, 0x1e, 0x7a, 0x63, 0xfa, 0x77, 0xd5, 0x9b, 0xd7, 0x65, 0x70,
-	0xb4, 0xd3, 0x70, 0x67, 0x6d, 0x3c, 0xde, 0x43, 0x2e, 0xa0, 0xdd, 0x4e, 0x17, 0xfd, 0xb8, 0x4e,
-	0xbb, 0x4a, 0x3f, 0x7e, 0x84, 0x1f, 0x06, 0x99, 0x0d, 0xa6, 0xd0, 0x4f, 0xdb, 0x55, 0x54, 0x0a,
-	0xa5, 0xdc, 0xea, 0x1e, 0xd9, 0x75, 0x73, 0x99, 0x0f, 0x2b, 0xeb, 0x1a, 0xca, 0x4c, 0x57, 0xba,
-	0xdc, 0x32, 0x4f, 0x79, 0x93, 0x87, 0xf7, 0xd0, 0x79, 0xda, 0xf4, 0xc9, 0xfe, 0xb5, 0xce, 0x49,
-	0xd6, 0xd7, 0x3e, 0xe0, 0x64, 0x0a, 0x7d, 0xd5, 0xc4, 0xca, 0x38, 0x4a, 0x1f, 0x8a, 0x6a, 0x6b,
-	0xfb, 0x60, 0x20, 0x96, 0x42, 0xab, 0x38, 0xfe, 0x5f, 0x27, 0x5f, 0xc1, 0x93, 0x32, 0xe3, 0xaa,
-	0xef, 0xa9, 0x93, 0xff, 0xd9, 0x8e, 0xfc, 0x79, 0x8f, 0x84, 0xf7, 0x90, 0x03, 0x4f, 0x74, 0xe7,
-	0x52, 0x72, 0x57, 0x8e, 0xab, 0xda, 0xfe, 0x94, 0x7d, 0x56, 0x1a, 0x1f, 0xbc, 0x87, 0xbe, 0x85,
-	0xa3, 0x72, 0x70, 0xc5, 0xcf, 0x68, 0x5d, 0x8c, 0x78, 0x37, 0xc6, 0xea, 0x97, 0x57, 0xe5, 0x3c,
-	0xa9, 0x7c, 0x6c, 0xeb, 0x7c, 0xfd, 0xa8, 0x68, 0xaa, 0xf9, 0x38, 0xe3, 0x3d, 0xf4, 0x05, 0xf4,
-	0xd3, 0x89, 0x3a, 0x0f, 0x76, 0x9d, 0x07, 0xbd, 0x74, 0xfa, 0x1a, 0x7e, 0xe2, 0x45, 0xeb, 0x93,
-	0x05, 0x13, 0xcb, 0xcd, 0xec, 0x64, 0xb9, 0x8d, 0x23, 0xf9, 0x88, 0x9e, 0x91, 0x70, 0x75, 0x12,
-	0x44, 0x1e, 0x09, 0x3c, 0xe2, 0x2d, 0xe9, 0x22, 0x89, 0xbd, 0x69, 0xe1, 0xef, 0x9d, 0xeb, 0xc6,
-	0xac, 0xab, 0xfe, 0xf3, 0xf9, 0xf9, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xfa, 0x35, 0x95, 0x0e,
-	0x07, 0x12, 0x00, 0x00,
+func (m *ExportParam) Reset()         { *m = ExportParam{} }
+func (m *ExportParam) String() string { return proto.CompactTextString(m) }
+func (*ExportParam) ProtoMessage()    {}
+func (*ExportParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{36}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
-
-// CoincheckClient is the client API for Coincheck service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CoincheckClient interface {
-	// You can get the latest information easily.
-	Ticker(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TickerItem, error)
-	TickerHist(ctx context.Context, in *TickerHistParam, opts ...grpc.CallOption) (*TickerHistItem, error)
-	// You can get the latest transaction history.
-	Trades(ctx context.Context, in *TradesParams, opts ...grpc.CallOption) (*TradesItem, error)
-	// Board information can be obtained.
-	OrderBooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderBooksItem, error)
-	// The rate is calculated based on the exchange's order.
-	ExchangeOrdersRate(ctx context.Context, in *ExchangeOrdersRateParam, opts ...grpc.CallOption) (*ExchangeOrdersRateItem, error)
-	// Get a dealership rate
-	RatePair(ctx context.Context, in *RatePairParams, opts ...grpc.CallOption) (*RatePairItem, error)
-	// Market order Cash transaction Buy
-	MarketBuy(ctx context.Context, in *MarketBuyParams, opts ...grpc.CallOption) (*MarketItem, error)
-	// Market orders, spot trading, selling
-	MarketSell(ctx context.Context, in *MarketSellParam, opts ...grpc.CallOption) (*MarketItem, error)
-	// Limit order, spot trading, buy.
-	LimitBuy(ctx context.Context, in *LimitOrderParams, opts ...grpc.CallOption) (*MarketItem, error)
-	// Limit order, spot trading, sell.
-	LimitSell(ctx context.Context, in *LimitOrderParams, opts ...grpc.CallOption) (*MarketItem, error)
-	// View a list of pending orders in your account.
-	ExchangeOrdersOpens(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrdersOpensItem, error)
-	DeleteExchangeOrder(ctx context.Context, in *DeleteOrderParam, opts ...grpc.CallOption) (*DeleteOrderItem, error)
-	// You can see your recent transaction history.
-	ExchangeOrdersTransactions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrdersTransactionsItem, error)
-	// You can check the balance of your account.
-	AccountsBalance(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountsBalanceItem, error)
-	// View your account information.
-	Accounts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountsItem, error)
+func (m *ExportParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportParam.Unmarshal(m, b)
 }
-
-type coincheckClient struct {
-	cc grpc.ClientConnInterface
+func (m *ExportParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportParam.Marshal(b, m, deterministic)
 }
-
-func NewCoincheckClient(cc grpc.ClientConnInterface) CoincheckClient {
-	return &coincheckClient{cc}
+func (m *ExportParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportParam.Merge(m, src)
 }
-
-func (c *coincheckClient) Ticker(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TickerItem, error) {
-	out := new(TickerItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/Ticker", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ExportParam) XXX_Size() int {
+	return xxx_messageInfo_ExportParam.Size(m)
 }
-
-func (c *coincheckClient) TickerHist(ctx context.Context, in *TickerHistParam, opts ...grpc.CallOption) (*TickerHistItem, error) {
-	out := new(TickerHistItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/TickerHist", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ExportParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportParam.DiscardUnknown(m)
 }
 
-func (c *coincheckClient) Trades(ctx context.Context, in *TradesParams, opts ...grpc.CallOption) (*TradesItem, error) {
-	out := new(TradesItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/Trades", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ExportParam proto.InternalMessageInfo
+
+func (m *ExportParam) GetDataset() string {
+	if m != nil {
+		return m.Dataset
 	}
-	return out, nil
+	return ""
 }
 
-func (c *coincheckClient) OrderBooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrderBooksItem, error) {
-	out := new(OrderBooksItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/OrderBooks", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportParam) GetFormat() string {
+	if m != nil {
+		return m.Format
 	}
-	return out, nil
+	return ""
 }
 
-func (c *coincheckClient) ExchangeOrdersRate(ctx context.Context, in *ExchangeOrdersRateParam, opts ...grpc.CallOption) (*ExchangeOrdersRateItem, error) {
-	out := new(ExchangeOrdersRateItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/ExchangeOrdersRate", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportParam) GetPair() string {
+	if m != nil {
+		return m.Pair
 	}
-	return out, nil
+	return ""
 }
 
-func (c *coincheckClient) RatePair(ctx context.Context, in *RatePairParams, opts ...grpc.CallOption) (*RatePairItem, error) {
-	out := new(RatePairItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/RatePair", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportParam) GetFrom() uint64 {
+	if m != nil {
+		return m.From
 	}
-	return out, nil
+	return 0
 }
 
-func (c *coincheckClient) MarketBuy(ctx context.Context, in *MarketBuyParams, opts ...grpc.CallOption) (*MarketItem, error) {
-	out := new(MarketItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/MarketBuy", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportParam) GetTo() uint64 {
+	if m != nil {
+		return m.To
 	}
-	return out, nil
+	return 0
 }
 
-func (c *coincheckClient) MarketSell(ctx context.Context, in *MarketSellParam, opts ...grpc.CallOption) (*MarketItem, error) {
-	out := new(MarketItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/MarketSell", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportParam) GetInterval() string {
+	if m != nil {
+		return m.Interval
 	}
-	return out, nil
+	return ""
 }
 
-func (c *coincheckClient) LimitBuy(ctx context.Context, in *LimitOrderParams, opts ...grpc.CallOption) (*MarketItem, error) {
-	out := new(MarketItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/LimitBuy", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ExportChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *coincheckClient) LimitSell(ctx context.Context, in *LimitOrderParams, opts ...grpc.CallOption) (*MarketItem, error) {
-	out := new(MarketItem)
-	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/LimitSell", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ExportChunk) Reset()         { *m = ExportChunk{} }
+func (m *ExportChunk) String() string { return proto.CompactTextString(m) }
+func (*ExportChunk) ProtoMessage()    {}
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{37}
+}
+
+func (m *ExportChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportChunk.Unmarshal(m, b)
+}
+func (m *ExportChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportChunk.Marshal(b, m, deterministic)
+}
+func (m *ExportChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportChunk.Merge(m, src)
+}
+func (m *ExportChunk) XXX_Size() int {
+	return xxx_messageInfo_ExportChunk.Size(m)
+}
+func (m *ExportChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportChunk proto.InternalMessageInfo
+
+func (m *ExportChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type TickerGapsParam struct {
+	Pair                 string   `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	From                 uint64   `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TickerGapsParam) Reset()         { *m = TickerGapsParam{} }
+func (m *TickerGapsParam) String() string { return proto.CompactTextString(m) }
+func (*TickerGapsParam) ProtoMessage()    {}
+func (*TickerGapsParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{38}
+}
+
+func (m *TickerGapsParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TickerGapsParam.Unmarshal(m, b)
+}
+func (m *TickerGapsParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TickerGapsParam.Marshal(b, m, deterministic)
+}
+func (m *TickerGapsParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickerGapsParam.Merge(m, src)
+}
+func (m *TickerGapsParam) XXX_Size() int {
+	return xxx_messageInfo_TickerGapsParam.Size(m)
+}
+func (m *TickerGapsParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickerGapsParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickerGapsParam proto.InternalMessageInfo
+
+func (m *TickerGapsParam) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *TickerGapsParam) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *TickerGapsParam) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type TickerGap struct {
+	From                 uint64   `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Trades               uint32   `protobuf:"varint,4,opt,name=trades,proto3" json:"trades,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TickerGap) Reset()         { *m = TickerGap{} }
+func (m *TickerGap) String() string { return proto.CompactTextString(m) }
+func (*TickerGap) ProtoMessage()    {}
+func (*TickerGap) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{39}
+}
+
+func (m *TickerGap) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TickerGap.Unmarshal(m, b)
+}
+func (m *TickerGap) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TickerGap.Marshal(b, m, deterministic)
+}
+func (m *TickerGap) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickerGap.Merge(m, src)
+}
+func (m *TickerGap) XXX_Size() int {
+	return xxx_messageInfo_TickerGap.Size(m)
+}
+func (m *TickerGap) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickerGap.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickerGap proto.InternalMessageInfo
+
+func (m *TickerGap) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *TickerGap) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *TickerGap) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *TickerGap) GetTrades() uint32 {
+	if m != nil {
+		return m.Trades
+	}
+	return 0
+}
+
+type TickerGapsItem struct {
+	Pair                 string       `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Gaps                 []*TickerGap `protobuf:"bytes,2,rep,name=gaps,proto3" json:"gaps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TickerGapsItem) Reset()         { *m = TickerGapsItem{} }
+func (m *TickerGapsItem) String() string { return proto.CompactTextString(m) }
+func (*TickerGapsItem) ProtoMessage()    {}
+func (*TickerGapsItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{40}
+}
+
+func (m *TickerGapsItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TickerGapsItem.Unmarshal(m, b)
+}
+func (m *TickerGapsItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TickerGapsItem.Marshal(b, m, deterministic)
+}
+func (m *TickerGapsItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickerGapsItem.Merge(m, src)
+}
+func (m *TickerGapsItem) XXX_Size() int {
+	return xxx_messageInfo_TickerGapsItem.Size(m)
+}
+func (m *TickerGapsItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickerGapsItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickerGapsItem proto.InternalMessageInfo
+
+func (m *TickerGapsItem) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *TickerGapsItem) GetGaps() []*TickerGap {
+	if m != nil {
+		return m.Gaps
+	}
+	return nil
+}
+
+type HaltParam struct {
+	Reason               string   `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	CancelOrders         bool     `protobuf:"varint,2,opt,name=cancel_orders,json=cancelOrders,proto3" json:"cancel_orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HaltParam) Reset()         { *m = HaltParam{} }
+func (m *HaltParam) String() string { return proto.CompactTextString(m) }
+func (*HaltParam) ProtoMessage()    {}
+func (*HaltParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{41}
+}
+
+func (m *HaltParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HaltParam.Unmarshal(m, b)
+}
+func (m *HaltParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HaltParam.Marshal(b, m, deterministic)
+}
+func (m *HaltParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HaltParam.Merge(m, src)
+}
+func (m *HaltParam) XXX_Size() int {
+	return xxx_messageInfo_HaltParam.Size(m)
+}
+func (m *HaltParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_HaltParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HaltParam proto.InternalMessageInfo
+
+func (m *HaltParam) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *HaltParam) GetCancelOrders() bool {
+	if m != nil {
+		return m.CancelOrders
+	}
+	return false
+}
+
+type HaltItem struct {
+	Halted               bool     `protobuf:"varint,1,opt,name=halted,proto3" json:"halted,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	By                   string   `protobuf:"bytes,3,opt,name=by,proto3" json:"by,omitempty"`
+	Since                uint64   `protobuf:"varint,4,opt,name=since,proto3" json:"since,omitempty"`
+	Cancelled            []uint32 `protobuf:"varint,5,rep,packed,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Failed               []uint32 `protobuf:"varint,6,rep,packed,name=failed,proto3" json:"failed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HaltItem) Reset()         { *m = HaltItem{} }
+func (m *HaltItem) String() string { return proto.CompactTextString(m) }
+func (*HaltItem) ProtoMessage()    {}
+func (*HaltItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{42}
+}
+
+func (m *HaltItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HaltItem.Unmarshal(m, b)
+}
+func (m *HaltItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HaltItem.Marshal(b, m, deterministic)
+}
+func (m *HaltItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HaltItem.Merge(m, src)
+}
+func (m *HaltItem) XXX_Size() int {
+	return xxx_messageInfo_HaltItem.Size(m)
+}
+func (m *HaltItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_HaltItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HaltItem proto.InternalMessageInfo
+
+func (m *HaltItem) GetHalted() bool {
+	if m != nil {
+		return m.Halted
+	}
+	return false
+}
+
+func (m *HaltItem) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *HaltItem) GetBy() string {
+	if m != nil {
+		return m.By
+	}
+	return ""
+}
+
+func (m *HaltItem) GetSince() uint64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *HaltItem) GetCancelled() []uint32 {
+	if m != nil {
+		return m.Cancelled
+	}
+	return nil
+}
+
+func (m *HaltItem) GetFailed() []uint32 {
+	if m != nil {
+		return m.Failed
+	}
+	return nil
+}
+
+type GetOrderParam struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientOrderId        string   `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrderParam) Reset()         { *m = GetOrderParam{} }
+func (m *GetOrderParam) String() string { return proto.CompactTextString(m) }
+func (*GetOrderParam) ProtoMessage()    {}
+func (*GetOrderParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{43}
+}
+
+func (m *GetOrderParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderParam.Unmarshal(m, b)
+}
+func (m *GetOrderParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderParam.Marshal(b, m, deterministic)
+}
+func (m *GetOrderParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderParam.Merge(m, src)
+}
+func (m *GetOrderParam) XXX_Size() int {
+	return xxx_messageInfo_GetOrderParam.Size(m)
+}
+func (m *GetOrderParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderParam proto.InternalMessageInfo
+
+func (m *GetOrderParam) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *GetOrderParam) GetClientOrderId() string {
+	if m != nil {
+		return m.ClientOrderId
+	}
+	return ""
+}
+
+type ListOrdersParam struct {
+	Status               []string `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty"`
+	Pair                 string   `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Limit                uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOrdersParam) Reset()         { *m = ListOrdersParam{} }
+func (m *ListOrdersParam) String() string { return proto.CompactTextString(m) }
+func (*ListOrdersParam) ProtoMessage()    {}
+func (*ListOrdersParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{44}
+}
+
+func (m *ListOrdersParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOrdersParam.Unmarshal(m, b)
+}
+func (m *ListOrdersParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOrdersParam.Marshal(b, m, deterministic)
+}
+func (m *ListOrdersParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOrdersParam.Merge(m, src)
+}
+func (m *ListOrdersParam) XXX_Size() int {
+	return xxx_messageInfo_ListOrdersParam.Size(m)
+}
+func (m *ListOrdersParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOrdersParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOrdersParam proto.InternalMessageInfo
+
+func (m *ListOrdersParam) GetStatus() []string {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ListOrdersParam) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *ListOrdersParam) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type OrderTransitionItem struct {
+	From                 string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string   `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Filled               string   `protobuf:"bytes,3,opt,name=filled,proto3" json:"filled,omitempty"`
+	Time                 uint64   `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderTransitionItem) Reset()         { *m = OrderTransitionItem{} }
+func (m *OrderTransitionItem) String() string { return proto.CompactTextString(m) }
+func (*OrderTransitionItem) ProtoMessage()    {}
+func (*OrderTransitionItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{45}
+}
+
+func (m *OrderTransitionItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderTransitionItem.Unmarshal(m, b)
+}
+func (m *OrderTransitionItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderTransitionItem.Marshal(b, m, deterministic)
+}
+func (m *OrderTransitionItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderTransitionItem.Merge(m, src)
+}
+func (m *OrderTransitionItem) XXX_Size() int {
+	return xxx_messageInfo_OrderTransitionItem.Size(m)
+}
+func (m *OrderTransitionItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderTransitionItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderTransitionItem proto.InternalMessageInfo
+
+func (m *OrderTransitionItem) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *OrderTransitionItem) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *OrderTransitionItem) GetFilled() string {
+	if m != nil {
+		return m.Filled
+	}
+	return ""
+}
+
+func (m *OrderTransitionItem) GetTime() uint64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type OrderItem struct {
+	Id                   uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientOrderId        string                 `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Pair                 string                 `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`
+	OrderType            string                 `protobuf:"bytes,4,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	Rate                 string                 `protobuf:"bytes,5,opt,name=rate,proto3" json:"rate,omitempty"`
+	Amount               string                 `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status               string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Filled               string                 `protobuf:"bytes,8,opt,name=filled,proto3" json:"filled,omitempty"`
+	Created              uint64                 `protobuf:"varint,9,opt,name=created,proto3" json:"created,omitempty"`
+	Updated              uint64                 `protobuf:"varint,10,opt,name=updated,proto3" json:"updated,omitempty"`
+	History              []*OrderTransitionItem `protobuf:"bytes,11,rep,name=history,proto3" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *OrderItem) Reset()         { *m = OrderItem{} }
+func (m *OrderItem) String() string { return proto.CompactTextString(m) }
+func (*OrderItem) ProtoMessage()    {}
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{46}
+}
+
+func (m *OrderItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderItem.Unmarshal(m, b)
+}
+func (m *OrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderItem.Marshal(b, m, deterministic)
+}
+func (m *OrderItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderItem.Merge(m, src)
+}
+func (m *OrderItem) XXX_Size() int {
+	return xxx_messageInfo_OrderItem.Size(m)
+}
+func (m *OrderItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderItem proto.InternalMessageInfo
+
+func (m *OrderItem) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *OrderItem) GetClientOrderId() string {
+	if m != nil {
+		return m.ClientOrderId
+	}
+	return ""
+}
+
+func (m *OrderItem) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *OrderItem) GetOrderType() string {
+	if m != nil {
+		return m.OrderType
+	}
+	return ""
+}
+
+func (m *OrderItem) GetRate() string {
+	if m != nil {
+		return m.Rate
+	}
+	return ""
+}
+
+func (m *OrderItem) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *OrderItem) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *OrderItem) GetFilled() string {
+	if m != nil {
+		return m.Filled
+	}
+	return ""
+}
+
+func (m *OrderItem) GetCreated() uint64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *OrderItem) GetUpdated() uint64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *OrderItem) GetHistory() []*OrderTransitionItem {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+type OrderList struct {
+	Orders               []*OrderItem `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *OrderList) Reset()         { *m = OrderList{} }
+func (m *OrderList) String() string { return proto.CompactTextString(m) }
+func (*OrderList) ProtoMessage()    {}
+func (*OrderList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{47}
+}
+
+func (m *OrderList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderList.Unmarshal(m, b)
+}
+func (m *OrderList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderList.Marshal(b, m, deterministic)
+}
+func (m *OrderList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderList.Merge(m, src)
+}
+func (m *OrderList) XXX_Size() int {
+	return xxx_messageInfo_OrderList.Size(m)
+}
+func (m *OrderList) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderList proto.InternalMessageInfo
+
+func (m *OrderList) GetOrders() []*OrderItem {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type OrderEventsParam struct {
+	After                uint64   `protobuf:"varint,1,opt,name=after,proto3" json:"after,omitempty"`
+	Pair                 string   `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderEventsParam) Reset()         { *m = OrderEventsParam{} }
+func (m *OrderEventsParam) String() string { return proto.CompactTextString(m) }
+func (*OrderEventsParam) ProtoMessage()    {}
+func (*OrderEventsParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{48}
+}
+
+func (m *OrderEventsParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderEventsParam.Unmarshal(m, b)
+}
+func (m *OrderEventsParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderEventsParam.Marshal(b, m, deterministic)
+}
+func (m *OrderEventsParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderEventsParam.Merge(m, src)
+}
+func (m *OrderEventsParam) XXX_Size() int {
+	return xxx_messageInfo_OrderEventsParam.Size(m)
+}
+func (m *OrderEventsParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderEventsParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderEventsParam proto.InternalMessageInfo
+
+func (m *OrderEventsParam) GetAfter() uint64 {
+	if m != nil {
+		return m.After
+	}
+	return 0
+}
+
+func (m *OrderEventsParam) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+type OrderEvent struct {
+	Seq                  uint64   `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	OrderId              uint64   `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId        string   `protobuf:"bytes,4,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Pair                 string   `protobuf:"bytes,5,opt,name=pair,proto3" json:"pair,omitempty"`
+	OrderType            string   `protobuf:"bytes,6,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	Amount               string   `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Filled               string   `protobuf:"bytes,8,opt,name=filled,proto3" json:"filled,omitempty"`
+	Reason               string   `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	Time                 uint64   `protobuf:"varint,10,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderEvent) Reset()         { *m = OrderEvent{} }
+func (m *OrderEvent) String() string { return proto.CompactTextString(m) }
+func (*OrderEvent) ProtoMessage()    {}
+func (*OrderEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{49}
+}
+
+func (m *OrderEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderEvent.Unmarshal(m, b)
+}
+func (m *OrderEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderEvent.Marshal(b, m, deterministic)
+}
+func (m *OrderEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderEvent.Merge(m, src)
+}
+func (m *OrderEvent) XXX_Size() int {
+	return xxx_messageInfo_OrderEvent.Size(m)
+}
+func (m *OrderEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderEvent proto.InternalMessageInfo
+
+func (m *OrderEvent) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *OrderEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+func (m *OrderEvent) GetClientOrderId() string {
+	if m != nil {
+		return m.ClientOrderId
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetOrderType() string {
+	if m != nil {
+		return m.OrderType
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetFilled() string {
+	if m != nil {
+		return m.Filled
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *OrderEvent) GetTime() uint64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type ConditionalOrderParams struct {
+	Kind                 string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Pair                 string   `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Side                 string   `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`
+	TriggerRate          string   `protobuf:"bytes,4,opt,name=trigger_rate,json=triggerRate,proto3" json:"trigger_rate,omitempty"`
+	TrailDistance        string   `protobuf:"bytes,5,opt,name=trail_distance,json=trailDistance,proto3" json:"trail_distance,omitempty"`
+	Amount               string   `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Rate                 string   `protobuf:"bytes,7,opt,name=rate,proto3" json:"rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConditionalOrderParams) Reset()         { *m = ConditionalOrderParams{} }
+func (m *ConditionalOrderParams) String() string { return proto.CompactTextString(m) }
+func (*ConditionalOrderParams) ProtoMessage()    {}
+func (*ConditionalOrderParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{50}
+}
+
+func (m *ConditionalOrderParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConditionalOrderParams.Unmarshal(m, b)
+}
+func (m *ConditionalOrderParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConditionalOrderParams.Marshal(b, m, deterministic)
+}
+func (m *ConditionalOrderParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConditionalOrderParams.Merge(m, src)
+}
+func (m *ConditionalOrderParams) XXX_Size() int {
+	return xxx_messageInfo_ConditionalOrderParams.Size(m)
+}
+func (m *ConditionalOrderParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConditionalOrderParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConditionalOrderParams proto.InternalMessageInfo
+
+func (m *ConditionalOrderParams) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ConditionalOrderParams) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *ConditionalOrderParams) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *ConditionalOrderParams) GetTriggerRate() string {
+	if m != nil {
+		return m.TriggerRate
+	}
+	return ""
+}
+
+func (m *ConditionalOrderParams) GetTrailDistance() string {
+	if m != nil {
+		return m.TrailDistance
+	}
+	return ""
+}
+
+func (m *ConditionalOrderParams) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *ConditionalOrderParams) GetRate() string {
+	if m != nil {
+		return m.Rate
+	}
+	return ""
+}
+
+type OCOOrderParams struct {
+	First                *ConditionalOrderParams `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second               *ConditionalOrderParams `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *OCOOrderParams) Reset()         { *m = OCOOrderParams{} }
+func (m *OCOOrderParams) String() string { return proto.CompactTextString(m) }
+func (*OCOOrderParams) ProtoMessage()    {}
+func (*OCOOrderParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{51}
+}
+
+func (m *OCOOrderParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OCOOrderParams.Unmarshal(m, b)
+}
+func (m *OCOOrderParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OCOOrderParams.Marshal(b, m, deterministic)
+}
+func (m *OCOOrderParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OCOOrderParams.Merge(m, src)
+}
+func (m *OCOOrderParams) XXX_Size() int {
+	return xxx_messageInfo_OCOOrderParams.Size(m)
+}
+func (m *OCOOrderParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_OCOOrderParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OCOOrderParams proto.InternalMessageInfo
+
+func (m *OCOOrderParams) GetFirst() *ConditionalOrderParams {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *OCOOrderParams) GetSecond() *ConditionalOrderParams {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
+type ConditionalOrderItem struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OcoId                string   `protobuf:"bytes,2,opt,name=oco_id,json=ocoId,proto3" json:"oco_id,omitempty"`
+	Kind                 string   `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Pair                 string   `protobuf:"bytes,4,opt,name=pair,proto3" json:"pair,omitempty"`
+	Side                 string   `protobuf:"bytes,5,opt,name=side,proto3" json:"side,omitempty"`
+	TriggerRate          string   `protobuf:"bytes,6,opt,name=trigger_rate,json=triggerRate,proto3" json:"trigger_rate,omitempty"`
+	TrailDistance        string   `protobuf:"bytes,7,opt,name=trail_distance,json=trailDistance,proto3" json:"trail_distance,omitempty"`
+	BestRate             string   `protobuf:"bytes,8,opt,name=best_rate,json=bestRate,proto3" json:"best_rate,omitempty"`
+	Amount               string   `protobuf:"bytes,9,opt,name=amount,proto3" json:"amount,omitempty"`
+	Rate                 string   `protobuf:"bytes,10,opt,name=rate,proto3" json:"rate,omitempty"`
+	State                string   `protobuf:"bytes,11,opt,name=state,proto3" json:"state,omitempty"`
+	ClientOrderId        string   `protobuf:"bytes,12,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	OrderId              uint64   `protobuf:"varint,13,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Error                string   `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
+	Created              uint64   `protobuf:"varint,15,opt,name=created,proto3" json:"created,omitempty"`
+	Updated              uint64   `protobuf:"varint,16,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConditionalOrderItem) Reset()         { *m = ConditionalOrderItem{} }
+func (m *ConditionalOrderItem) String() string { return proto.CompactTextString(m) }
+func (*ConditionalOrderItem) ProtoMessage()    {}
+func (*ConditionalOrderItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{52}
+}
+
+func (m *ConditionalOrderItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConditionalOrderItem.Unmarshal(m, b)
+}
+func (m *ConditionalOrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConditionalOrderItem.Marshal(b, m, deterministic)
+}
+func (m *ConditionalOrderItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConditionalOrderItem.Merge(m, src)
+}
+func (m *ConditionalOrderItem) XXX_Size() int {
+	return xxx_messageInfo_ConditionalOrderItem.Size(m)
+}
+func (m *ConditionalOrderItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConditionalOrderItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConditionalOrderItem proto.InternalMessageInfo
+
+func (m *ConditionalOrderItem) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetOcoId() string {
+	if m != nil {
+		return m.OcoId
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetTriggerRate() string {
+	if m != nil {
+		return m.TriggerRate
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetTrailDistance() string {
+	if m != nil {
+		return m.TrailDistance
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetBestRate() string {
+	if m != nil {
+		return m.BestRate
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetRate() string {
+	if m != nil {
+		return m.Rate
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetClientOrderId() string {
+	if m != nil {
+		return m.ClientOrderId
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+func (m *ConditionalOrderItem) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ConditionalOrderItem) GetCreated() uint64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ConditionalOrderItem) GetUpdated() uint64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+type ConditionalOrderList struct {
+	Orders               []*ConditionalOrderItem `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ConditionalOrderList) Reset()         { *m = ConditionalOrderList{} }
+func (m *ConditionalOrderList) String() string { return proto.CompactTextString(m) }
+func (*ConditionalOrderList) ProtoMessage()    {}
+func (*ConditionalOrderList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{53}
+}
+
+func (m *ConditionalOrderList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConditionalOrderList.Unmarshal(m, b)
+}
+func (m *ConditionalOrderList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConditionalOrderList.Marshal(b, m, deterministic)
+}
+func (m *ConditionalOrderList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConditionalOrderList.Merge(m, src)
+}
+func (m *ConditionalOrderList) XXX_Size() int {
+	return xxx_messageInfo_ConditionalOrderList.Size(m)
+}
+func (m *ConditionalOrderList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConditionalOrderList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConditionalOrderList proto.InternalMessageInfo
+
+func (m *ConditionalOrderList) GetOrders() []*ConditionalOrderItem {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type ConditionalOrderParam struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConditionalOrderParam) Reset()         { *m = ConditionalOrderParam{} }
+func (m *ConditionalOrderParam) String() string { return proto.CompactTextString(m) }
+func (*ConditionalOrderParam) ProtoMessage()    {}
+func (*ConditionalOrderParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{54}
+}
+
+func (m *ConditionalOrderParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConditionalOrderParam.Unmarshal(m, b)
+}
+func (m *ConditionalOrderParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConditionalOrderParam.Marshal(b, m, deterministic)
+}
+func (m *ConditionalOrderParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConditionalOrderParam.Merge(m, src)
+}
+func (m *ConditionalOrderParam) XXX_Size() int {
+	return xxx_messageInfo_ConditionalOrderParam.Size(m)
+}
+func (m *ConditionalOrderParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConditionalOrderParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConditionalOrderParam proto.InternalMessageInfo
+
+func (m *ConditionalOrderParam) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListConditionalOrdersParam struct {
+	State                []string `protobuf:"bytes,1,rep,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListConditionalOrdersParam) Reset()         { *m = ListConditionalOrdersParam{} }
+func (m *ListConditionalOrdersParam) String() string { return proto.CompactTextString(m) }
+func (*ListConditionalOrdersParam) ProtoMessage()    {}
+func (*ListConditionalOrdersParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{55}
+}
+
+func (m *ListConditionalOrdersParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListConditionalOrdersParam.Unmarshal(m, b)
+}
+func (m *ListConditionalOrdersParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListConditionalOrdersParam.Marshal(b, m, deterministic)
+}
+func (m *ListConditionalOrdersParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConditionalOrdersParam.Merge(m, src)
+}
+func (m *ListConditionalOrdersParam) XXX_Size() int {
+	return xxx_messageInfo_ListConditionalOrdersParam.Size(m)
+}
+func (m *ListConditionalOrdersParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConditionalOrdersParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConditionalOrdersParam proto.InternalMessageInfo
+
+func (m *ListConditionalOrdersParam) GetState() []string {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+type RecurringOrderParams struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Pair                 string   `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Side                 string   `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`
+	Amount               string   `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Schedule             string   `protobuf:"bytes,5,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	LimitRate            string   `protobuf:"bytes,6,opt,name=limit_rate,json=limitRate,proto3" json:"limit_rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecurringOrderParams) Reset()         { *m = RecurringOrderParams{} }
+func (m *RecurringOrderParams) String() string { return proto.CompactTextString(m) }
+func (*RecurringOrderParams) ProtoMessage()    {}
+func (*RecurringOrderParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{56}
+}
+
+func (m *RecurringOrderParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecurringOrderParams.Unmarshal(m, b)
+}
+func (m *RecurringOrderParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecurringOrderParams.Marshal(b, m, deterministic)
+}
+func (m *RecurringOrderParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecurringOrderParams.Merge(m, src)
+}
+func (m *RecurringOrderParams) XXX_Size() int {
+	return xxx_messageInfo_RecurringOrderParams.Size(m)
+}
+func (m *RecurringOrderParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecurringOrderParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecurringOrderParams proto.InternalMessageInfo
+
+func (m *RecurringOrderParams) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RecurringOrderParams) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *RecurringOrderParams) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *RecurringOrderParams) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *RecurringOrderParams) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
+
+func (m *RecurringOrderParams) GetLimitRate() string {
+	if m != nil {
+		return m.LimitRate
+	}
+	return ""
+}
+
+type RecurringOrderItem struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Pair                 string   `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`
+	Side                 string   `protobuf:"bytes,4,opt,name=side,proto3" json:"side,omitempty"`
+	Amount               string   `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Schedule             string   `protobuf:"bytes,6,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	LimitRate            string   `protobuf:"bytes,7,opt,name=limit_rate,json=limitRate,proto3" json:"limit_rate,omitempty"`
+	State                string   `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`
+	Source               string   `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
+	NextRun              uint64   `protobuf:"varint,10,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	Created              uint64   `protobuf:"varint,11,opt,name=created,proto3" json:"created,omitempty"`
+	Updated              uint64   `protobuf:"varint,12,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecurringOrderItem) Reset()         { *m = RecurringOrderItem{} }
+func (m *RecurringOrderItem) String() string { return proto.CompactTextString(m) }
+func (*RecurringOrderItem) ProtoMessage()    {}
+func (*RecurringOrderItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{57}
+}
+
+func (m *RecurringOrderItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecurringOrderItem.Unmarshal(m, b)
+}
+func (m *RecurringOrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecurringOrderItem.Marshal(b, m, deterministic)
+}
+func (m *RecurringOrderItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecurringOrderItem.Merge(m, src)
+}
+func (m *RecurringOrderItem) XXX_Size() int {
+	return xxx_messageInfo_RecurringOrderItem.Size(m)
+}
+func (m *RecurringOrderItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecurringOrderItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecurringOrderItem proto.InternalMessageInfo
+
+func (m *RecurringOrderItem) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RecurringOrderItem) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RecurringOrderItem) GetPair() string {
+	if m != nil {
+		return m.Pair
+	}
+	return ""
+}
+
+func (m *RecurringOrderItem) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *RecurringOrderItem) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *RecurringOrderItem) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
+
+func (m *RecurringOrderItem) GetLimitRate() string {
+	if m != nil {
+		return m.LimitRate
+	}
+	return ""
+}
+
+func (m *RecurringOrderItem) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *RecurringOrderItem) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *RecurringOrderItem) GetNextRun() uint64 {
+	if m != nil {
+		return m.NextRun
+	}
+	return 0
+}
+
+func (m *RecurringOrderItem) GetCreated() uint64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *RecurringOrderItem) GetUpdated() uint64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+type RecurringOrderList struct {
+	Orders               []*RecurringOrderItem `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *RecurringOrderList) Reset()         { *m = RecurringOrderList{} }
+func (m *RecurringOrderList) String() string { return proto.CompactTextString(m) }
+func (*RecurringOrderList) ProtoMessage()    {}
+func (*RecurringOrderList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{58}
+}
+
+func (m *RecurringOrderList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecurringOrderList.Unmarshal(m, b)
+}
+func (m *RecurringOrderList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecurringOrderList.Marshal(b, m, deterministic)
+}
+func (m *RecurringOrderList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecurringOrderList.Merge(m, src)
+}
+func (m *RecurringOrderList) XXX_Size() int {
+	return xxx_messageInfo_RecurringOrderList.Size(m)
+}
+func (m *RecurringOrderList) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecurringOrderList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecurringOrderList proto.InternalMessageInfo
+
+func (m *RecurringOrderList) GetOrders() []*RecurringOrderItem {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type RecurringOrderParam struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecurringOrderParam) Reset()         { *m = RecurringOrderParam{} }
+func (m *RecurringOrderParam) String() string { return proto.CompactTextString(m) }
+func (*RecurringOrderParam) ProtoMessage()    {}
+func (*RecurringOrderParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{59}
+}
+
+func (m *RecurringOrderParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecurringOrderParam.Unmarshal(m, b)
+}
+func (m *RecurringOrderParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecurringOrderParam.Marshal(b, m, deterministic)
+}
+func (m *RecurringOrderParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecurringOrderParam.Merge(m, src)
+}
+func (m *RecurringOrderParam) XXX_Size() int {
+	return xxx_messageInfo_RecurringOrderParam.Size(m)
+}
+func (m *RecurringOrderParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecurringOrderParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecurringOrderParam proto.InternalMessageInfo
+
+func (m *RecurringOrderParam) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type RecurringRunsParam struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Limit                uint32   `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecurringRunsParam) Reset()         { *m = RecurringRunsParam{} }
+func (m *RecurringRunsParam) String() string { return proto.CompactTextString(m) }
+func (*RecurringRunsParam) ProtoMessage()    {}
+func (*RecurringRunsParam) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{60}
+}
+
+func (m *RecurringRunsParam) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecurringRunsParam.Unmarshal(m, b)
+}
+func (m *RecurringRunsParam) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecurringRunsParam.Marshal(b, m, deterministic)
+}
+func (m *RecurringRunsParam) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecurringRunsParam.Merge(m, src)
+}
+func (m *RecurringRunsParam) XXX_Size() int {
+	return xxx_messageInfo_RecurringRunsParam.Size(m)
+}
+func (m *RecurringRunsParam) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecurringRunsParam.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecurringRunsParam proto.InternalMessageInfo
+
+func (m *RecurringRunsParam) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RecurringRunsParam) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type RecurringRunItem struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RecurringId          string   `protobuf:"bytes,2,opt,name=recurring_id,json=recurringId,proto3" json:"recurring_id,omitempty"`
+	Outcome              string   `protobuf:"bytes,3,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	OrderType            string   `protobuf:"bytes,5,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	Rate                 string   `protobuf:"bytes,6,opt,name=rate,proto3" json:"rate,omitempty"`
+	Amount               string   `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	ClientOrderId        string   `protobuf:"bytes,8,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	OrderId              uint64   `protobuf:"varint,9,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Time                 uint64   `protobuf:"varint,10,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecurringRunItem) Reset()         { *m = RecurringRunItem{} }
+func (m *RecurringRunItem) String() string { return proto.CompactTextString(m) }
+func (*RecurringRunItem) ProtoMessage()    {}
+func (*RecurringRunItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{61}
+}
+
+func (m *RecurringRunItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecurringRunItem.Unmarshal(m, b)
+}
+func (m *RecurringRunItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecurringRunItem.Marshal(b, m, deterministic)
+}
+func (m *RecurringRunItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecurringRunItem.Merge(m, src)
+}
+func (m *RecurringRunItem) XXX_Size() int {
+	return xxx_messageInfo_RecurringRunItem.Size(m)
+}
+func (m *RecurringRunItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecurringRunItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecurringRunItem proto.InternalMessageInfo
+
+func (m *RecurringRunItem) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RecurringRunItem) GetRecurringId() string {
+	if m != nil {
+		return m.RecurringId
+	}
+	return ""
+}
+
+func (m *RecurringRunItem) GetOutcome() string {
+	if m != nil {
+		return m.Outcome
+	}
+	return ""
+}
+
+func (m *RecurringRunItem) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RecurringRunItem) GetOrderType() string {
+	if m != nil {
+		return m.OrderType
+	}
+	return ""
+}
+
+func (m *RecurringRunItem) GetRate() string {
+	if m != nil {
+		return m.Rate
+	}
+	return ""
+}
+
+func (m *RecurringRunItem) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *RecurringRunItem) GetClientOrderId() string {
+	if m != nil {
+		return m.ClientOrderId
+	}
+	return ""
+}
+
+func (m *RecurringRunItem) GetOrderId() uint64 {
+	if m != nil {
+		return m.OrderId
+	}
+	return 0
+}
+
+func (m *RecurringRunItem) GetTime() uint64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type RecurringRunList struct {
+	Runs                 []*RecurringRunItem `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RecurringRunList) Reset()         { *m = RecurringRunList{} }
+func (m *RecurringRunList) String() string { return proto.CompactTextString(m) }
+func (*RecurringRunList) ProtoMessage()    {}
+func (*RecurringRunList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d34031f942b0a586, []int{62}
+}
+
+func (m *RecurringRunList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecurringRunList.Unmarshal(m, b)
+}
+func (m *RecurringRunList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecurringRunList.Marshal(b, m, deterministic)
+}
+func (m *RecurringRunList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecurringRunList.Merge(m, src)
+}
+func (m *RecurringRunList) XXX_Size() int {
+	return xxx_messageInfo_RecurringRunList.Size(m)
+}
+func (m *RecurringRunList) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecurringRunList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecurringRunList proto.InternalMessageInfo
+
+func (m *RecurringRunList) GetRuns() []*RecurringRunItem {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Empty)(nil), "bitcocheck.Empty")
+	proto.RegisterType((*TickerParams)(nil), "bitcocheck.TickerParams")
+	proto.RegisterType((*TickerItem)(nil), "bitcocheck.TickerItem")
+	proto.RegisterType((*TradesParams)(nil), "bitcocheck.TradesParams")
+	proto.RegisterType((*Pagenation)(nil), "bitcocheck.Pagenation")
+	proto.RegisterType((*TradeData)(nil), "bitcocheck.TradeData")
+	proto.RegisterType((*TradesItem)(nil), "bitcocheck.TradesItem")
+	proto.RegisterType((*CandlesParam)(nil), "bitcocheck.CandlesParam")
+	proto.RegisterType((*Candle)(nil), "bitcocheck.Candle")
+	proto.RegisterType((*CandlesItem)(nil), "bitcocheck.CandlesItem")
+	proto.RegisterType((*OrderBooksParams)(nil), "bitcocheck.OrderBooksParams")
+	proto.RegisterType((*OrderArray)(nil), "bitcocheck.OrderArray")
+	proto.RegisterType((*OrderBooksItem)(nil), "bitcocheck.OrderBooksItem")
+	proto.RegisterType((*PriceLevel)(nil), "bitcocheck.PriceLevel")
+	proto.RegisterType((*OrderBooksV2Item)(nil), "bitcocheck.OrderBooksV2Item")
+	proto.RegisterType((*ExchangeOrdersRateParam)(nil), "bitcocheck.ExchangeOrdersRateParam")
+	proto.RegisterType((*ExchangeOrdersRateItem)(nil), "bitcocheck.ExchangeOrdersRateItem")
+	proto.RegisterType((*RatePairParams)(nil), "bitcocheck.RatePairParams")
+	proto.RegisterType((*RatePairItem)(nil), "bitcocheck.RatePairItem")
+	proto.RegisterType((*MarketBuyParams)(nil), "bitcocheck.MarketBuyParams")
+	proto.RegisterType((*MarketSellParam)(nil), "bitcocheck.MarketSellParam")
+	proto.RegisterType((*LimitOrderParams)(nil), "bitcocheck.LimitOrderParams")
+	proto.RegisterType((*MarketItem)(nil), "bitcocheck.MarketItem")
+	proto.RegisterType((*OpenItem)(nil), "bitcocheck.OpenItem")
+	proto.RegisterType((*OrdersOpensItem)(nil), "bitcocheck.OrdersOpensItem")
+	proto.RegisterType((*DeleteOrderParam)(nil), "bitcocheck.DeleteOrderParam")
+	proto.RegisterType((*DeleteOrderItem)(nil), "bitcocheck.DeleteOrderItem")
+	proto.RegisterType((*Funds)(nil), "bitcocheck.Funds")
+	proto.RegisterType((*TransactionsItem)(nil), "bitcocheck.TransactionsItem")
+	proto.RegisterType((*OrdersTransactionsItem)(nil), "bitcocheck.OrdersTransactionsItem")
+	proto.RegisterType((*AccountsBalanceItem)(nil), "bitcocheck.AccountsBalanceItem")
+	proto.RegisterType((*Fees)(nil), "bitcocheck.Fees")
+	proto.RegisterType((*ExchangeFees)(nil), "bitcocheck.ExchangeFees")
+	proto.RegisterType((*AccountsItem)(nil), "bitcocheck.AccountsItem")
+	proto.RegisterType((*TickerHistParam)(nil), "bitcocheck.TickerHistParam")
+	proto.RegisterType((*TickerHistItem)(nil), "bitcocheck.TickerHistItem")
+	proto.RegisterType((*ExportParam)(nil), "bitcocheck.ExportParam")
+	proto.RegisterType((*ExportChunk)(nil), "bitcocheck.ExportChunk")
+	proto.RegisterType((*TickerGapsParam)(nil), "bitcocheck.TickerGapsParam")
+	proto.RegisterType((*TickerGap)(nil), "bitcocheck.TickerGap")
+	proto.RegisterType((*TickerGapsItem)(nil), "bitcocheck.TickerGapsItem")
+	proto.RegisterType((*HaltParam)(nil), "bitcocheck.HaltParam")
+	proto.RegisterType((*HaltItem)(nil), "bitcocheck.HaltItem")
+	proto.RegisterType((*GetOrderParam)(nil), "bitcocheck.GetOrderParam")
+	proto.RegisterType((*ListOrdersParam)(nil), "bitcocheck.ListOrdersParam")
+	proto.RegisterType((*OrderTransitionItem)(nil), "bitcocheck.OrderTransitionItem")
+	proto.RegisterType((*OrderItem)(nil), "bitcocheck.OrderItem")
+	proto.RegisterType((*OrderList)(nil), "bitcocheck.OrderList")
+	proto.RegisterType((*OrderEventsParam)(nil), "bitcocheck.OrderEventsParam")
+	proto.RegisterType((*OrderEvent)(nil), "bitcocheck.OrderEvent")
+	proto.RegisterType((*ConditionalOrderParams)(nil), "bitcocheck.ConditionalOrderParams")
+	proto.RegisterType((*OCOOrderParams)(nil), "bitcocheck.OCOOrderParams")
+	proto.RegisterType((*ConditionalOrderItem)(nil), "bitcocheck.ConditionalOrderItem")
+	proto.RegisterType((*ConditionalOrderList)(nil), "bitcocheck.ConditionalOrderList")
+	proto.RegisterType((*ConditionalOrderParam)(nil), "bitcocheck.ConditionalOrderParam")
+	proto.RegisterType((*ListConditionalOrdersParam)(nil), "bitcocheck.ListConditionalOrdersParam")
+	proto.RegisterType((*RecurringOrderParams)(nil), "bitcocheck.RecurringOrderParams")
+	proto.RegisterType((*RecurringOrderItem)(nil), "bitcocheck.RecurringOrderItem")
+	proto.RegisterType((*RecurringOrderList)(nil), "bitcocheck.RecurringOrderList")
+	proto.RegisterType((*RecurringOrderParam)(nil), "bitcocheck.RecurringOrderParam")
+	proto.RegisterType((*RecurringRunsParam)(nil), "bitcocheck.RecurringRunsParam")
+	proto.RegisterType((*RecurringRunItem)(nil), "bitcocheck.RecurringRunItem")
+	proto.RegisterType((*RecurringRunList)(nil), "bitcocheck.RecurringRunList")
+}
+
+func init() {
+	proto.RegisterFile("bitcocheck.proto", fileDescriptor_d34031f942b0a586)
+}
+
+var fileDescriptor_d34031f942b0a586 = []byte{
+	// 3247 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x5a, 0xdb, 0x6f, 0x1c, 0xb7,
+	0xd5, 0xd7, 0xcc, 0xde, 0xcf, 0xee, 0x4a, 0xca, 0x58, 0x56, 0xd6, 0x6b, 0x27, 0xb1, 0x99, 0x9b,
+	0x1d, 0xe4, 0x33, 0x02, 0x05, 0x9f, 0xbf, 0x24, 0x5f, 0x9a, 0x46, 0x92, 0x1d, 0xdb, 0xa9, 0x02,
+	0x0b, 0x63, 0x27, 0x45, 0x9e, 0x16, 0xdc, 0x19, 0x4a, 0x3b, 0xd6, 0xec, 0xcc, 0x66, 0x86, 0xab,
+	0x64, 0x5f, 0x8b, 0xa0, 0x48, 0x9f, 0x5b, 0x14, 0x28, 0xd0, 0x87, 0xf6, 0xa1, 0x7d, 0x2a, 0xd0,
+	0x00, 0xfd, 0x0b, 0x0a, 0xf4, 0xa9, 0xaf, 0x7d, 0xeb, 0x5f, 0x53, 0xf0, 0x90, 0x9c, 0xdb, 0x72,
+	0x57, 0x72, 0xdf, 0x86, 0x87, 0x87, 0xe4, 0xb9, 0xfc, 0x78, 0x78, 0x0e, 0x39, 0xb0, 0x3d, 0x0e,
+	0xb8, 0x17, 0x7b, 0x13, 0xe6, 0x9d, 0xdd, 0x9d, 0x25, 0x31, 0x8f, 0x1d, 0xc8, 0x29, 0xa4, 0x05,
+	0x8d, 0x07, 0xd3, 0x19, 0x5f, 0x10, 0x02, 0xbd, 0x67, 0x81, 0x77, 0xc6, 0x92, 0x63, 0x9a, 0xd0,
+	0x69, 0xea, 0x38, 0x50, 0x9f, 0xd1, 0x20, 0x19, 0x58, 0x37, 0xad, 0xdb, 0x1d, 0x17, 0xbf, 0xc9,
+	0x1f, 0x2c, 0x00, 0xc9, 0xf4, 0x98, 0xb3, 0xa9, 0x60, 0x39, 0xa2, 0x29, 0x47, 0x16, 0xdb, 0xad,
+	0x87, 0x34, 0xe5, 0xce, 0x36, 0xd4, 0x0e, 0x02, 0x7f, 0x60, 0x23, 0xa9, 0x36, 0x0e, 0x7c, 0x41,
+	0xd9, 0x4f, 0xcf, 0x06, 0x35, 0x49, 0xa1, 0xe9, 0x99, 0x18, 0xf7, 0x28, 0x38, 0x9d, 0x0c, 0xea,
+	0x72, 0xdc, 0x24, 0x38, 0x9d, 0x08, 0xae, 0xa3, 0xf8, 0xdb, 0x41, 0x43, 0x72, 0x85, 0xf1, 0xb7,
+	0xce, 0x2e, 0x34, 0xbf, 0x8a, 0xc3, 0xf9, 0x94, 0x0d, 0x9a, 0x48, 0x6c, 0x9e, 0x63, 0xcb, 0xb9,
+	0x01, 0x9d, 0x67, 0xc1, 0x94, 0xa5, 0x9c, 0x4e, 0x67, 0x83, 0xd6, 0x4d, 0xeb, 0x76, 0xdd, 0xed,
+	0x70, 0x4d, 0x40, 0x35, 0x12, 0xea, 0xb3, 0x34, 0x57, 0xe3, 0xb8, 0xaa, 0xc6, 0x2f, 0x2c, 0x80,
+	0x63, 0x7a, 0xca, 0x22, 0xca, 0x83, 0x38, 0x72, 0x76, 0xa0, 0x71, 0x14, 0x4c, 0x03, 0xa9, 0x47,
+	0xdf, 0x6d, 0x84, 0xa2, 0x21, 0xa8, 0x4f, 0x12, 0x9f, 0x25, 0xa8, 0x4a, 0xc7, 0x6d, 0xc4, 0xa2,
+	0xe1, 0xbc, 0x01, 0xfd, 0xa7, 0x9c, 0x26, 0x3c, 0x88, 0x4e, 0xf7, 0x4f, 0x38, 0x4b, 0x50, 0xad,
+	0x8e, 0xdb, 0x4f, 0x8b, 0x44, 0x87, 0x40, 0xef, 0x41, 0xe4, 0x07, 0xd1, 0xe9, 0x01, 0x3b, 0x89,
+	0x13, 0x86, 0x8a, 0x76, 0xdc, 0x1e, 0x2b, 0xd0, 0xc8, 0xdf, 0x2c, 0xe8, 0xa0, 0xa4, 0xf7, 0x29,
+	0xa7, 0xce, 0x26, 0xd8, 0x8f, 0xef, 0x2b, 0x01, 0xec, 0xe0, 0xbe, 0x50, 0x7e, 0x7f, 0x1a, 0xcf,
+	0x23, 0xae, 0x96, 0x6f, 0x52, 0x6c, 0x39, 0xbb, 0x50, 0x77, 0x29, 0x67, 0xd2, 0x9a, 0x07, 0xf6,
+	0xc0, 0x72, 0xeb, 0x09, 0xe5, 0x2c, 0x53, 0xb3, 0x9e, 0xab, 0x29, 0x0c, 0x85, 0x1a, 0x3c, 0x5b,
+	0xcc, 0x18, 0x1a, 0xb6, 0xe3, 0x76, 0x62, 0x4d, 0x10, 0xbd, 0x87, 0x09, 0xa3, 0x9c, 0xf9, 0xfb,
+	0x1c, 0x2d, 0xdc, 0x71, 0x3b, 0x9e, 0x26, 0x88, 0xf5, 0xc5, 0x3a, 0x5f, 0xed, 0xa1, 0x85, 0x2d,
+	0xb7, 0x99, 0x60, 0x8b, 0xfc, 0x4a, 0x20, 0x00, 0xed, 0x8b, 0x08, 0x18, 0x40, 0x2b, 0x9d, 0x7b,
+	0x1e, 0x4b, 0x53, 0x94, 0xbd, 0xed, 0xea, 0xa6, 0x73, 0x0f, 0x60, 0x46, 0x4f, 0x03, 0x69, 0x62,
+	0x54, 0xa2, 0xbb, 0xb7, 0x7b, 0xb7, 0x00, 0xc5, 0xdc, 0x01, 0x6e, 0x81, 0xd3, 0xb9, 0x03, 0x75,
+	0x9f, 0x72, 0x3a, 0xa8, 0xdd, 0xac, 0xdd, 0xee, 0xee, 0x5d, 0x2d, 0x8e, 0xc8, 0xac, 0xe5, 0x22,
+	0x0b, 0x19, 0x43, 0xef, 0x90, 0x46, 0x7e, 0xa8, 0x7c, 0x6d, 0x42, 0xac, 0x33, 0x84, 0x76, 0x10,
+	0x71, 0x96, 0x9c, 0xd3, 0x50, 0x59, 0x32, 0x6b, 0x0b, 0xfe, 0x93, 0x24, 0x9e, 0xa2, 0x2d, 0xeb,
+	0x2e, 0x7e, 0x0b, 0x3f, 0xf0, 0x18, 0xad, 0x58, 0x77, 0x6d, 0x1e, 0x93, 0x3f, 0x5b, 0xd0, 0x94,
+	0x8b, 0x08, 0x83, 0x65, 0x30, 0x1b, 0x58, 0x15, 0xdc, 0x89, 0xc9, 0xe2, 0x19, 0x93, 0x9a, 0x5a,
+	0x2e, 0x7e, 0x0b, 0x9a, 0xc0, 0x36, 0x2e, 0x60, 0xe5, 0x38, 0x0f, 0xe3, 0x6f, 0x71, 0x05, 0x4b,
+	0xe2, 0x7c, 0x07, 0x1a, 0x5e, 0x18, 0xa7, 0xd2, 0x45, 0x96, 0x2b, 0x1b, 0xc2, 0x01, 0xe7, 0x39,
+	0xfa, 0xad, 0x0c, 0xfd, 0xbb, 0xd0, 0xe4, 0x68, 0x7f, 0x74, 0x4c, 0xdf, 0x55, 0x2d, 0x72, 0x06,
+	0x5d, 0x65, 0x0c, 0xbd, 0x35, 0x5f, 0xc8, 0x16, 0xef, 0x42, 0xcb, 0x93, 0xc3, 0x95, 0xe5, 0x9d,
+	0xa2, 0xe5, 0xe5, 0xcc, 0xae, 0x66, 0x21, 0x1f, 0xc3, 0x36, 0x22, 0xeb, 0x20, 0x8e, 0xcf, 0xd2,
+	0xd5, 0xf1, 0x42, 0xa8, 0xe6, 0xb3, 0x19, 0x9f, 0xe0, 0x72, 0x7d, 0x57, 0x36, 0x08, 0x01, 0xc0,
+	0xd1, 0xfb, 0x49, 0x42, 0x17, 0x82, 0x27, 0xe0, 0x6c, 0x2a, 0x00, 0x54, 0x13, 0xfb, 0x0c, 0x1b,
+	0x64, 0x02, 0x9b, 0xf9, 0x0a, 0xa8, 0xd1, 0x3b, 0x50, 0xa7, 0xe9, 0x99, 0x64, 0xab, 0x40, 0x29,
+	0x9f, 0xcd, 0x45, 0x1e, 0xc1, 0x3b, 0x0e, 0xfc, 0x74, 0x60, 0xaf, 0xe7, 0x15, 0x3c, 0xe4, 0x03,
+	0x80, 0xe3, 0x24, 0xf0, 0xd8, 0x11, 0x3b, 0x67, 0x88, 0x09, 0x81, 0x74, 0xad, 0x85, 0xf8, 0x16,
+	0x26, 0xa7, 0x86, 0xbd, 0x48, 0x7e, 0x6f, 0x15, 0xcd, 0xf0, 0xd5, 0xde, 0x4a, 0xc3, 0x97, 0x90,
+	0x63, 0x57, 0x91, 0xa3, 0x15, 0xab, 0x2d, 0x0b, 0x9b, 0x0b, 0x56, 0x51, 0xac, 0xbe, 0x9e, 0x17,
+	0x15, 0xfb, 0xde, 0x82, 0x97, 0x1f, 0x7c, 0xe7, 0x4d, 0x68, 0x74, 0xca, 0x50, 0xcc, 0x54, 0xec,
+	0x68, 0xb9, 0x55, 0x5e, 0x01, 0xc0, 0x48, 0x30, 0xe2, 0x22, 0x36, 0x58, 0xd5, 0xd8, 0xa0, 0x95,
+	0xb0, 0x0b, 0x4a, 0xdc, 0x84, 0xae, 0xd4, 0x7b, 0x26, 0x16, 0x52, 0x71, 0xaf, 0x48, 0x12, 0x9e,
+	0x3c, 0xa7, 0xe1, 0x5c, 0x87, 0x3b, 0xd9, 0x20, 0x1c, 0x76, 0x97, 0xa5, 0xb8, 0x20, 0x78, 0x68,
+	0x2f, 0xd8, 0x05, 0x2f, 0xec, 0x40, 0xa3, 0xb8, 0xb2, 0x6c, 0x14, 0x7c, 0x53, 0x2f, 0xf9, 0xe6,
+	0x0d, 0xd8, 0x94, 0xda, 0x06, 0xeb, 0xce, 0x33, 0x02, 0x3d, 0xcd, 0xa5, 0x9d, 0x57, 0xf5, 0x3e,
+	0x59, 0xc0, 0xd6, 0x17, 0x34, 0x39, 0x63, 0xfc, 0x60, 0xbe, 0x58, 0x03, 0xf5, 0x77, 0xe0, 0xa5,
+	0x29, 0xb2, 0x8d, 0xc6, 0xf3, 0xc5, 0xa8, 0x80, 0x97, 0xbe, 0xbb, 0x35, 0xd5, 0xe3, 0x65, 0x48,
+	0x77, 0xde, 0x82, 0x2d, 0x2f, 0x0c, 0x58, 0xc4, 0x47, 0xd2, 0x09, 0x81, 0xaf, 0x8f, 0x11, 0x49,
+	0x46, 0x3b, 0x3d, 0xf6, 0x09, 0xd3, 0x4b, 0x3f, 0x65, 0x61, 0xb8, 0x3a, 0xc6, 0x95, 0xf1, 0xd9,
+	0xcf, 0xce, 0x8a, 0xcb, 0x2e, 0xf3, 0xa3, 0x05, 0xdb, 0x78, 0x00, 0x22, 0x41, 0xe9, 0xb8, 0x09,
+	0x76, 0xe0, 0xe3, 0x32, 0x35, 0xd7, 0x0e, 0x7c, 0x23, 0x24, 0xb4, 0xb9, 0x6a, 0xc6, 0xcd, 0x52,
+	0x72, 0x88, 0xf3, 0x06, 0x6c, 0xa6, 0x3c, 0x9e, 0x8d, 0xc2, 0x38, 0x4d, 0x47, 0x38, 0x4a, 0x9e,
+	0x48, 0x3d, 0x41, 0x3d, 0x8a, 0x53, 0x84, 0x85, 0x49, 0xe4, 0xa6, 0x49, 0xe4, 0x7f, 0x5b, 0x00,
+	0xd2, 0x34, 0x26, 0x24, 0x75, 0x72, 0x24, 0x49, 0x35, 0xe4, 0x9e, 0x53, 0x6a, 0x5c, 0x5a, 0xe4,
+	0xf2, 0x26, 0x59, 0x3a, 0x40, 0x97, 0x35, 0x6a, 0x1a, 0x34, 0xd2, 0x76, 0x6b, 0x15, 0xec, 0xf6,
+	0x0a, 0x80, 0x3a, 0x69, 0x47, 0x94, 0x0f, 0xda, 0x95, 0xb3, 0x97, 0xfc, 0x60, 0x43, 0xfb, 0xc9,
+	0x8c, 0x45, 0xa8, 0x5a, 0xee, 0x87, 0x3e, 0x2a, 0x50, 0x16, 0xca, 0x36, 0xec, 0xdc, 0x4c, 0xbf,
+	0xbe, 0xd2, 0xef, 0x4d, 0xd8, 0x9c, 0xc9, 0xd4, 0x63, 0x54, 0xd2, 0xb3, 0xaf, 0xa8, 0x0a, 0x95,
+	0x1f, 0xc2, 0x35, 0xcd, 0xb6, 0x8c, 0x64, 0xa9, 0xfd, 0xae, 0x62, 0xf8, 0xa2, 0x02, 0xe8, 0xcb,
+	0x99, 0xa2, 0xac, 0x76, 0xab, 0x9a, 0x72, 0x68, 0x4b, 0xb5, 0x0b, 0x1b, 0xf4, 0x6b, 0xd8, 0x92,
+	0x41, 0x43, 0xd8, 0xe3, 0xa2, 0x94, 0xe3, 0x5d, 0x68, 0xa2, 0x21, 0x74, 0xdc, 0xdf, 0x29, 0xc5,
+	0x7d, 0x65, 0x50, 0x57, 0xf1, 0x10, 0x02, 0xdb, 0xf7, 0x59, 0xc8, 0x38, 0xcb, 0x51, 0x5f, 0x35,
+	0x36, 0xf9, 0x7f, 0xd8, 0x2a, 0xf0, 0x5c, 0xb0, 0x7c, 0x0e, 0x35, 0x39, 0xf8, 0xa7, 0xd0, 0xf8,
+	0x6c, 0x1e, 0xf9, 0xa9, 0x38, 0xf2, 0xc7, 0xdc, 0x53, 0xc8, 0x14, 0x9f, 0x82, 0xf2, 0x7c, 0xb6,
+	0x50, 0xde, 0x13, 0x9f, 0x42, 0xf9, 0x31, 0x4d, 0x33, 0x5c, 0x8a, 0x6f, 0xf2, 0x1b, 0x1b, 0xb6,
+	0x9f, 0x25, 0x34, 0x4a, 0xa9, 0x27, 0x52, 0xa3, 0xd4, 0x88, 0x87, 0x6b, 0xd0, 0xce, 0xb6, 0x8a,
+	0x5c, 0xbb, 0x15, 0xcb, 0x4d, 0x52, 0xb1, 0x77, 0xad, 0x6a, 0xef, 0xb7, 0xa1, 0x71, 0x22, 0xe4,
+	0x43, 0x34, 0x74, 0xf7, 0x5e, 0x2a, 0x5a, 0x0b, 0x05, 0x77, 0x65, 0x7f, 0xe6, 0x98, 0x86, 0x61,
+	0xeb, 0x37, 0x0b, 0xfb, 0xe8, 0x16, 0xf4, 0x4e, 0x18, 0x1b, 0x79, 0xf3, 0x24, 0x61, 0x91, 0xb7,
+	0x50, 0x1e, 0xee, 0x9e, 0x30, 0x76, 0xa8, 0x48, 0x42, 0xf1, 0x13, 0xc6, 0x94, 0x8b, 0xc5, 0xa7,
+	0x38, 0x1b, 0xc3, 0xe0, 0x9b, 0x79, 0xe0, 0x07, 0x7c, 0x31, 0xe8, 0x48, 0x19, 0x33, 0x82, 0x58,
+	0x26, 0x0d, 0x7c, 0x36, 0x00, 0xb9, 0x8c, 0xf8, 0x16, 0x07, 0x8a, 0xc4, 0xc4, 0x92, 0x6d, 0x56,
+	0xfb, 0xe6, 0x53, 0xe8, 0xf1, 0x02, 0xb7, 0x02, 0xc8, 0x8d, 0x4a, 0x76, 0x59, 0x9a, 0xcd, 0x2d,
+	0x8d, 0x20, 0xff, 0xb2, 0xe1, 0xca, 0xbe, 0xe7, 0x09, 0xb8, 0xa7, 0x07, 0x34, 0xa4, 0x91, 0x77,
+	0xd1, 0x21, 0xb6, 0xec, 0x64, 0x05, 0x84, 0x5a, 0x0e, 0x84, 0x5b, 0xd0, 0x7b, 0x3e, 0x5b, 0x8c,
+	0x12, 0x96, 0xb2, 0xe4, 0x9c, 0xf9, 0x6a, 0x63, 0x76, 0x9f, 0xcf, 0x16, 0xae, 0x22, 0x09, 0x96,
+	0x31, 0xf7, 0x72, 0x16, 0xe9, 0x85, 0xee, 0x98, 0x7b, 0x19, 0xcb, 0x9b, 0xb0, 0x25, 0x66, 0x09,
+	0x59, 0xe4, 0x8f, 0x82, 0x68, 0x34, 0x4f, 0xb3, 0xfd, 0xf7, 0x7c, 0xb6, 0x38, 0x62, 0x91, 0xff,
+	0x38, 0xfa, 0x32, 0x15, 0x71, 0x60, 0x4b, 0xcc, 0x54, 0x64, 0x93, 0x2e, 0x12, 0x0b, 0xe4, 0x6c,
+	0xd7, 0xa0, 0xad, 0x66, 0xd3, 0xb1, 0xa9, 0x25, 0xa7, 0xe1, 0xa2, 0x4b, 0xcd, 0xc0, 0x95, 0xaf,
+	0x5a, 0x72, 0x28, 0xd7, 0xa3, 0x7c, 0x36, 0xe6, 0x03, 0xc8, 0x46, 0xdd, 0x67, 0xe3, 0x6c, 0x14,
+	0x76, 0x75, 0xb3, 0x51, 0xa2, 0x8b, 0x7c, 0x0a, 0xf5, 0xcf, 0x18, 0x4b, 0x9d, 0xeb, 0xd0, 0xe1,
+	0xf4, 0x8c, 0x25, 0x23, 0x81, 0x0e, 0xb9, 0x51, 0xda, 0x48, 0xf8, 0x8c, 0x31, 0xd1, 0x39, 0xcd,
+	0x3a, 0x55, 0xe2, 0x3a, 0x55, 0x9d, 0xc4, 0x87, 0x9e, 0x4e, 0x2f, 0x70, 0xa6, 0x3b, 0x20, 0x26,
+	0x1f, 0x09, 0xcb, 0x5b, 0x88, 0xeb, 0xed, 0x12, 0xae, 0x19, 0x4b, 0xdd, 0xe6, 0x98, 0x7b, 0x9f,
+	0xcf, 0x16, 0x82, 0xf5, 0x44, 0xb1, 0xda, 0xab, 0x58, 0x4f, 0x90, 0x95, 0xfc, 0xc3, 0x86, 0x9e,
+	0xf6, 0xfe, 0x8b, 0x85, 0x01, 0x91, 0xb7, 0xb0, 0x29, 0x0d, 0x42, 0x9d, 0xb7, 0x60, 0xc3, 0x79,
+	0x1b, 0xb6, 0x02, 0x9f, 0x45, 0x3c, 0xe0, 0x8b, 0x51, 0xca, 0x29, 0x9f, 0xa7, 0xca, 0xf7, 0x9b,
+	0x9a, 0xfc, 0x14, 0xa9, 0x82, 0x11, 0x85, 0x0a, 0xa2, 0x11, 0xf5, 0xfd, 0x44, 0x2c, 0x28, 0x11,
+	0xb0, 0xa9, 0xc8, 0xfb, 0x92, 0xea, 0xdc, 0x81, 0xed, 0x50, 0x85, 0xef, 0x90, 0x9d, 0xb3, 0x84,
+	0x9e, 0x4a, 0x14, 0xf4, 0xdd, 0x2d, 0x45, 0x3f, 0x52, 0xe4, 0xb2, 0xb5, 0x5b, 0xeb, 0xac, 0xdd,
+	0x2e, 0x5b, 0xdb, 0xf9, 0x09, 0xf4, 0x99, 0xb2, 0xb6, 0xe8, 0x4f, 0x11, 0x05, 0xdd, 0xbd, 0x41,
+	0xd1, 0x70, 0x45, 0x77, 0xb8, 0x3d, 0x56, 0x68, 0x91, 0x3f, 0x5a, 0xb0, 0x25, 0xef, 0x0f, 0x1e,
+	0x05, 0x29, 0x97, 0x31, 0x77, 0x07, 0x64, 0xc1, 0x5d, 0xae, 0xbe, 0x75, 0x6d, 0x66, 0x2f, 0xd5,
+	0x66, 0x35, 0x5d, 0x9b, 0x89, 0x91, 0x18, 0xea, 0x74, 0xbe, 0x89, 0x8d, 0x55, 0xd1, 0x2a, 0xe5,
+	0x6c, 0xa6, 0xec, 0x81, 0xdf, 0xe2, 0xd4, 0xf7, 0xe6, 0x49, 0x1a, 0xeb, 0xa3, 0x59, 0xb5, 0x48,
+	0x00, 0x9b, 0xb9, 0x88, 0xe8, 0xeb, 0x7b, 0x00, 0x1c, 0x29, 0xa2, 0x34, 0x31, 0xd5, 0x1f, 0xf9,
+	0x95, 0x88, 0x5b, 0xe0, 0x74, 0x5e, 0x83, 0x6e, 0xc4, 0xbe, 0xe3, 0x23, 0xb5, 0x8c, 0x44, 0x2e,
+	0x08, 0xd2, 0xa1, 0x5c, 0xea, 0xd7, 0x16, 0x74, 0x1f, 0x7c, 0x37, 0x8b, 0x13, 0x65, 0x8a, 0x01,
+	0xb4, 0x7c, 0xca, 0x69, 0xca, 0xb8, 0x4e, 0x63, 0x54, 0x53, 0x08, 0x7b, 0x12, 0x27, 0x53, 0x9a,
+	0x95, 0x20, 0xb2, 0x95, 0x29, 0x5b, 0x2b, 0x2b, 0x8b, 0xa6, 0xab, 0x2f, 0x99, 0xae, 0x91, 0x99,
+	0xae, 0x58, 0x0a, 0x36, 0xcb, 0xa5, 0x20, 0xb9, 0xa5, 0x85, 0x3a, 0x9c, 0xcc, 0x23, 0xbc, 0xac,
+	0xc1, 0x82, 0x5c, 0x48, 0xd4, 0x53, 0x95, 0xf7, 0x63, 0xed, 0xc6, 0x87, 0x74, 0xb6, 0xa6, 0xf8,
+	0xbe, 0x84, 0x13, 0xc9, 0x08, 0x3a, 0xd9, 0x54, 0xd9, 0x00, 0x6b, 0x69, 0x80, 0x9d, 0x89, 0xbe,
+	0x0b, 0x4d, 0xb5, 0x61, 0xa4, 0xd2, 0xaa, 0x55, 0x28, 0x8c, 0xeb, 0xa5, 0xc2, 0xf8, 0x09, 0x6c,
+	0xe6, 0xb2, 0xae, 0x2c, 0xd1, 0xee, 0x40, 0xfd, 0x94, 0xce, 0xf4, 0xc1, 0x70, 0x75, 0xd9, 0xbb,
+	0x0f, 0xe9, 0xcc, 0x45, 0x16, 0xf2, 0x08, 0x3a, 0x8f, 0x68, 0xa8, 0x5c, 0xb6, 0x0b, 0xcd, 0x84,
+	0xd1, 0x34, 0x8e, 0xd4, 0x6c, 0xaa, 0xe5, 0xbc, 0x0e, 0x7d, 0x4f, 0x9c, 0x11, 0xe1, 0x28, 0x4b,
+	0x49, 0x44, 0x94, 0xe8, 0x49, 0xa2, 0x3c, 0xbf, 0xc8, 0x6f, 0x2d, 0x68, 0x8b, 0xa9, 0x50, 0xaa,
+	0x5d, 0x68, 0x4e, 0x68, 0xc8, 0x99, 0xaf, 0x02, 0x8a, 0x6a, 0x15, 0x56, 0xb0, 0x4b, 0x2b, 0x6c,
+	0x82, 0x3d, 0x5e, 0x28, 0x1b, 0xd8, 0x63, 0xac, 0xa3, 0xd3, 0x20, 0xf2, 0x98, 0xf2, 0xbb, 0x6c,
+	0x88, 0xe3, 0x55, 0x2e, 0x19, 0xe2, 0xd1, 0x51, 0xbb, 0xdd, 0x77, 0x73, 0x02, 0xc2, 0x8a, 0x06,
+	0xa2, 0xab, 0x89, 0x5d, 0xaa, 0x45, 0x1e, 0x42, 0xff, 0x21, 0xe3, 0xc6, 0xc4, 0x48, 0xa6, 0xd1,
+	0x86, 0x3c, 0xdd, 0x36, 0xe5, 0xe9, 0x4f, 0x61, 0xeb, 0x28, 0x48, 0x65, 0x33, 0xcd, 0x2c, 0xa6,
+	0xfc, 0x27, 0x0b, 0x7e, 0xd5, 0x32, 0x16, 0x18, 0x59, 0x6c, 0xa8, 0x15, 0x62, 0x03, 0x61, 0x70,
+	0x45, 0xde, 0x6b, 0x89, 0xf3, 0x39, 0x10, 0xc7, 0xb3, 0x76, 0x6b, 0x06, 0x9e, 0xce, 0x12, 0x78,
+	0x3a, 0x1a, 0x3c, 0x27, 0x01, 0xda, 0x42, 0x81, 0x47, 0xb6, 0xc4, 0x58, 0x51, 0x90, 0xeb, 0x3d,
+	0x23, 0xbe, 0xc9, 0xdf, 0x6d, 0x75, 0x7f, 0x56, 0xc9, 0xbb, 0x5e, 0xc8, 0x02, 0xc6, 0x1d, 0x5a,
+	0xce, 0xe1, 0xeb, 0xab, 0x72, 0xf8, 0x86, 0xb1, 0x46, 0x69, 0x56, 0xee, 0x03, 0xb5, 0x35, 0x5b,
+	0xd5, 0xdd, 0xa0, 0x14, 0x6d, 0x97, 0x14, 0x1d, 0x40, 0x4b, 0x65, 0x80, 0x18, 0xba, 0xeb, 0xae,
+	0x6e, 0x8a, 0x9e, 0xf9, 0xcc, 0xc7, 0x1e, 0x90, 0x3d, 0xaa, 0xe9, 0x7c, 0x08, 0xad, 0x49, 0x90,
+	0xf2, 0x38, 0x59, 0x0c, 0xba, 0xb8, 0x3d, 0x5e, 0x5b, 0xba, 0x50, 0x29, 0xbb, 0xc2, 0xd5, 0xfc,
+	0xe4, 0x23, 0x65, 0x42, 0x01, 0x02, 0xe7, 0x7f, 0xb2, 0xfc, 0xdc, 0x5a, 0xde, 0x65, 0x99, 0xa5,
+	0xb3, 0x04, 0x5d, 0x5f, 0x32, 0x3d, 0x38, 0x67, 0x11, 0x4f, 0xb3, 0xc3, 0x82, 0xe2, 0xb5, 0xab,
+	0x74, 0x84, 0x6c, 0x98, 0xa0, 0x43, 0xbe, 0xb7, 0x01, 0xf2, 0xe1, 0x22, 0xf5, 0x4a, 0xd9, 0x37,
+	0x6a, 0x98, 0xf8, 0x44, 0x97, 0xe7, 0x25, 0x14, 0x7e, 0x97, 0x92, 0x69, 0x19, 0xa2, 0xb2, 0x64,
+	0xda, 0xe0, 0xef, 0xfa, 0x3a, 0x7f, 0x37, 0x56, 0xfa, 0xbb, 0x59, 0xf5, 0x77, 0xee, 0xdb, 0x56,
+	0xd5, 0xb7, 0x46, 0x1f, 0xe6, 0x11, 0xa1, 0x53, 0x8a, 0x08, 0x1a, 0xc4, 0x50, 0x00, 0xf1, 0x3f,
+	0x2d, 0xd8, 0x3d, 0x8c, 0x23, 0x1f, 0x7d, 0x43, 0xc3, 0x62, 0x85, 0xef, 0x40, 0xfd, 0x2c, 0x88,
+	0x7c, 0xbd, 0x5f, 0xc4, 0xf7, 0xaa, 0x2a, 0x1f, 0x73, 0xf0, 0x5a, 0x9e, 0x83, 0x8b, 0xa4, 0x94,
+	0x27, 0xc1, 0xe9, 0x29, 0x4b, 0x64, 0xb9, 0xa7, 0xf2, 0x56, 0x45, 0x73, 0x55, 0xd5, 0xc9, 0x13,
+	0x1a, 0x84, 0x23, 0x3f, 0x48, 0xb9, 0x88, 0x38, 0xca, 0x24, 0x7d, 0xa4, 0xde, 0x57, 0xc4, 0x95,
+	0xc0, 0xd6, 0x9b, 0xa0, 0x55, 0xb8, 0x8a, 0xf9, 0xa5, 0x05, 0x9b, 0x4f, 0x0e, 0x9f, 0x14, 0x95,
+	0xf8, 0x00, 0x1a, 0x27, 0x41, 0xa2, 0xde, 0x20, 0xba, 0x7b, 0xa4, 0x74, 0x6b, 0x69, 0xd4, 0xdb,
+	0x95, 0x03, 0x9c, 0x8f, 0xa0, 0x99, 0x32, 0x2f, 0x8e, 0xfc, 0x81, 0x7d, 0xe9, 0xa1, 0x6a, 0x04,
+	0xf9, 0x6b, 0x0d, 0x76, 0xaa, 0x2c, 0x95, 0x28, 0xd1, 0xc1, 0x28, 0x71, 0x15, 0x9a, 0xb1, 0x17,
+	0xe7, 0xc1, 0xa1, 0x11, 0x7b, 0xb1, 0x04, 0x09, 0x9a, 0xbe, 0x66, 0x30, 0x7d, 0xdd, 0x60, 0xfa,
+	0xc6, 0x1a, 0xd3, 0x37, 0x2f, 0x63, 0xfa, 0x96, 0xc9, 0xf4, 0xd7, 0xa1, 0x33, 0x66, 0x29, 0x97,
+	0xd3, 0xa8, 0x4c, 0x4f, 0x10, 0xdc, 0x72, 0xc0, 0xe9, 0x18, 0xfd, 0x02, 0xe5, 0xab, 0x39, 0x11,
+	0x76, 0x98, 0xca, 0xee, 0x65, 0xc3, 0xb4, 0x63, 0x7a, 0xa6, 0x1d, 0x53, 0xdc, 0x74, 0xfd, 0xf2,
+	0xa6, 0x13, 0xb9, 0x73, 0x92, 0xc4, 0xc9, 0x60, 0x53, 0x4e, 0x8c, 0x8d, 0x62, 0x0c, 0xdb, 0x5a,
+	0x19, 0xc3, 0xb6, 0x4b, 0x31, 0x8c, 0x1c, 0x2f, 0x3b, 0x0c, 0x63, 0xd2, 0x07, 0x95, 0x98, 0x74,
+	0x73, 0x1d, 0x0a, 0x4a, 0xe1, 0xe9, 0x6d, 0xb8, 0x6a, 0x44, 0x49, 0x15, 0x03, 0x64, 0x0f, 0x86,
+	0x62, 0xa9, 0x2a, 0x73, 0x1e, 0xd1, 0xa4, 0xed, 0xd4, 0xf5, 0x37, 0x36, 0xc8, 0x9f, 0x2c, 0xd8,
+	0x71, 0x99, 0xa8, 0xa4, 0x83, 0xe8, 0xb4, 0xb2, 0x69, 0x23, 0x3a, 0xcd, 0x6e, 0x28, 0xc5, 0xf7,
+	0xa5, 0x37, 0xed, 0xaa, 0x7b, 0xae, 0x21, 0xb4, 0x53, 0x6f, 0xc2, 0xfc, 0x79, 0xa8, 0x91, 0x96,
+	0xb5, 0x45, 0xe8, 0xc2, 0x43, 0xb7, 0x88, 0xb5, 0x0e, 0x52, 0x04, 0x4a, 0xc8, 0x8f, 0x36, 0x38,
+	0x65, 0x39, 0x8d, 0xdb, 0x40, 0x4b, 0x6d, 0x1b, 0xa4, 0xae, 0x19, 0xa4, 0xae, 0x1b, 0xa5, 0x6e,
+	0xac, 0x94, 0xba, 0xb9, 0x56, 0xea, 0x56, 0x45, 0xea, 0xdc, 0xe6, 0xed, 0x22, 0x5e, 0xc5, 0x51,
+	0x1a, 0xcf, 0x13, 0x8f, 0x69, 0xc4, 0xcb, 0x96, 0xc0, 0x27, 0xa6, 0xf1, 0xc9, 0x3c, 0xd2, 0x27,
+	0xa3, 0x68, 0xbb, 0xf3, 0xa8, 0x88, 0xc4, 0xee, 0x4a, 0x24, 0xf6, 0xca, 0x48, 0x3c, 0xaa, 0x5a,
+	0x0c, 0x71, 0x78, 0xaf, 0x82, 0xc3, 0x57, 0x8b, 0x38, 0x5c, 0xb6, 0x70, 0x86, 0xc2, 0x37, 0xe1,
+	0x8a, 0x01, 0x27, 0x4b, 0x18, 0xfc, 0xa8, 0xb0, 0xa8, 0x3b, 0x8f, 0x52, 0x23, 0x57, 0x9e, 0x6e,
+	0xd9, 0xc5, 0x74, 0xeb, 0x77, 0x36, 0x6c, 0x17, 0x07, 0x1b, 0xd3, 0xa1, 0x5b, 0xd0, 0x4b, 0x34,
+	0x4f, 0x1e, 0xee, 0xba, 0x19, 0xed, 0x31, 0x9a, 0x24, 0x9e, 0x73, 0x2f, 0x9e, 0x6a, 0x54, 0xea,
+	0x66, 0xe1, 0x40, 0xab, 0x97, 0x0e, 0xb4, 0x0b, 0x2e, 0x60, 0x4d, 0x77, 0x50, 0xab, 0xce, 0x52,
+	0x43, 0x30, 0x6a, 0x5f, 0x14, 0x8c, 0x3a, 0xe5, 0x60, 0x64, 0x3a, 0x5e, 0xef, 0x97, 0x4d, 0x83,
+	0xae, 0x7c, 0x0f, 0xea, 0xc9, 0x3c, 0xd2, 0x8e, 0xbc, 0x61, 0x74, 0xa4, 0x32, 0xa3, 0x8b, 0x9c,
+	0x7b, 0x7f, 0xb9, 0x02, 0x9d, 0xc3, 0x38, 0x88, 0x90, 0xc9, 0xf9, 0x18, 0x9a, 0xb2, 0xe4, 0x70,
+	0x06, 0xcb, 0x65, 0x88, 0x0c, 0x03, 0xc3, 0x15, 0xe5, 0x27, 0xd9, 0x70, 0x1e, 0x02, 0xe4, 0xe5,
+	0xab, 0x73, 0x7d, 0x99, 0x2f, 0xab, 0xbc, 0x87, 0x43, 0x73, 0x67, 0x75, 0x22, 0x51, 0x37, 0x99,
+	0x26, 0xca, 0x6a, 0xbf, 0xe1, 0xd0, 0xdc, 0xa9, 0x26, 0x12, 0xfa, 0x60, 0x29, 0x56, 0xd1, 0xa7,
+	0xf0, 0x4a, 0x3f, 0xdc, 0x5d, 0xee, 0x51, 0xa3, 0x3f, 0x81, 0x96, 0x7a, 0xd7, 0x2c, 0x0f, 0x2f,
+	0xbe, 0xfc, 0x0e, 0x5f, 0x36, 0xf4, 0x64, 0xe3, 0x9b, 0xb2, 0x9a, 0x75, 0x5e, 0x2e, 0x5f, 0x52,
+	0x64, 0x65, 0xf7, 0xd0, 0xd0, 0x81, 0xa5, 0x2f, 0xd9, 0x78, 0xcf, 0x72, 0x7e, 0x06, 0x90, 0xbf,
+	0xf1, 0x39, 0x37, 0x96, 0x52, 0xd6, 0xc2, 0x13, 0xe8, 0x70, 0x68, 0xee, 0x45, 0x49, 0x6a, 0x3f,
+	0xd8, 0x96, 0x73, 0x04, 0xbd, 0xe2, 0x83, 0xe1, 0x05, 0xd3, 0xad, 0xe8, 0x95, 0x0f, 0x8d, 0x64,
+	0xc3, 0x19, 0x81, 0xb3, 0xfc, 0xb2, 0xe6, 0xbc, 0x6e, 0xba, 0x8b, 0xa9, 0xbc, 0xff, 0x0d, 0xc9,
+	0x7a, 0x26, 0xb5, 0xc0, 0x01, 0xb4, 0xf5, 0xf3, 0x98, 0x53, 0xd2, 0xad, 0xfc, 0xb4, 0x36, 0x1c,
+	0x98, 0xfa, 0xb2, 0x39, 0x3a, 0xd9, 0x6b, 0x41, 0x19, 0x45, 0x95, 0x57, 0xb5, 0xe1, 0xee, 0x72,
+	0xa7, 0x9a, 0xe3, 0x10, 0x20, 0x7f, 0x07, 0x33, 0x4d, 0x92, 0xbd, 0x8f, 0xad, 0x99, 0xe4, 0x00,
+	0xda, 0xf8, 0xc8, 0x25, 0xe4, 0x28, 0x59, 0xb6, 0xfa, 0xf4, 0xb5, 0x56, 0x90, 0x0e, 0x72, 0xa3,
+	0x1c, 0xff, 0xed, 0x24, 0x0f, 0xe1, 0x4a, 0xd9, 0xe2, 0xf8, 0xb6, 0xe1, 0x94, 0xee, 0xdf, 0xf1,
+	0x97, 0x9c, 0xe1, 0xf5, 0x25, 0x00, 0xe4, 0xef, 0x20, 0x64, 0xc3, 0x71, 0xe1, 0x8a, 0x7c, 0x9d,
+	0x28, 0x4d, 0x57, 0x96, 0xab, 0xfa, 0xc4, 0x31, 0xbc, 0xbe, 0xa2, 0x57, 0xcd, 0xf9, 0x25, 0x0c,
+	0xcb, 0xc2, 0x15, 0xaf, 0xc5, 0x4d, 0x32, 0x92, 0x65, 0x19, 0xab, 0x37, 0xe9, 0xa8, 0xf3, 0x56,
+	0xe5, 0xf2, 0xdc, 0x34, 0x57, 0xa9, 0xae, 0x34, 0x5c, 0xb6, 0x93, 0x0d, 0xe7, 0x43, 0x68, 0xeb,
+	0x0e, 0xd3, 0x0c, 0x03, 0xd3, 0x0c, 0x6a, 0xe8, 0xff, 0x42, 0x5d, 0x5c, 0xb6, 0x38, 0xa5, 0xb2,
+	0x33, 0xbb, 0xc9, 0x19, 0xee, 0x54, 0xc9, 0x6a, 0xd8, 0xfb, 0xd0, 0x74, 0x59, 0x2a, 0x7e, 0xbd,
+	0x30, 0xac, 0xb7, 0x6a, 0xd0, 0xff, 0x01, 0x88, 0x96, 0xba, 0xc3, 0x7d, 0x81, 0x81, 0x9f, 0x40,
+	0x5b, 0xdf, 0xbc, 0x38, 0xd7, 0x8a, 0x3c, 0xa5, 0xfb, 0x98, 0xa1, 0xb9, 0x74, 0x46, 0x94, 0x43,
+	0x7e, 0xe1, 0x52, 0xde, 0x2a, 0x95, 0x8b, 0x18, 0xc3, 0x1c, 0x82, 0x83, 0x6c, 0x38, 0xc7, 0xb0,
+	0xf3, 0x74, 0x3e, 0x4e, 0xbd, 0x24, 0x18, 0xb3, 0x42, 0x05, 0x6e, 0x88, 0x56, 0x85, 0xd2, 0x7c,
+	0xb8, 0x6b, 0xee, 0xc5, 0x20, 0x3a, 0x82, 0xab, 0xc7, 0x21, 0xf5, 0x58, 0x35, 0x07, 0x76, 0x2e,
+	0x51, 0x74, 0x0d, 0x2f, 0x4c, 0xc9, 0xc9, 0x86, 0xf3, 0x04, 0xfa, 0xb8, 0x80, 0xae, 0x0e, 0xcb,
+	0xe1, 0xaa, 0x5c, 0x33, 0xae, 0x9f, 0x50, 0xd9, 0x60, 0x04, 0xbb, 0x87, 0x78, 0x4d, 0xb6, 0x24,
+	0xf2, 0xad, 0x0b, 0x45, 0xbe, 0xd4, 0x02, 0x1e, 0x5c, 0x35, 0x56, 0x05, 0xce, 0x5b, 0x55, 0x9f,
+	0x99, 0x0b, 0x87, 0x4b, 0x2d, 0xf2, 0x73, 0x78, 0x69, 0xdf, 0xf7, 0xcb, 0x09, 0xa2, 0x73, 0x73,
+	0x75, 0x6a, 0xa9, 0x0c, 0x74, 0x41, 0xf2, 0x49, 0x36, 0x9c, 0xaf, 0x61, 0x47, 0x9a, 0xa7, 0x32,
+	0xf7, 0x6b, 0x17, 0xcc, 0x7d, 0x89, 0xa9, 0x3f, 0x87, 0x2b, 0x42, 0xfa, 0x72, 0x9f, 0x71, 0x0f,
+	0xad, 0x99, 0x4b, 0xe9, 0xff, 0xac, 0x9a, 0x6b, 0x8b, 0xdc, 0xd7, 0x79, 0x75, 0x55, 0x4a, 0xa6,
+	0x2c, 0xbb, 0x32, 0x65, 0x93, 0xb3, 0x1e, 0xdc, 0x83, 0xb7, 0xbc, 0x78, 0x7a, 0xf7, 0x34, 0xe0,
+	0x93, 0xf9, 0xf8, 0xee, 0x64, 0x31, 0x8b, 0x7d, 0xca, 0xe9, 0x98, 0x46, 0x67, 0x77, 0xc3, 0xd8,
+	0xa3, 0xa1, 0x47, 0xbd, 0x09, 0x3b, 0x4d, 0x66, 0xde, 0x41, 0xe1, 0x47, 0xcb, 0x63, 0x6b, 0xdc,
+	0xc4, 0xbf, 0x2f, 0xdf, 0xff, 0xcf, 0x00, 0xd6, 0xbe, 0xfd, 0xee, 0x91, 0x29, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// CoincheckClient is the client API for Coincheck service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CoincheckClient interface {
+	// You can get the latest information easily.
+	Ticker(ctx context.Context, in *TickerParams, opts ...grpc.CallOption) (*TickerItem, error)
+	TickerHist(ctx context.Context, in *TickerHistParam, opts ...grpc.CallOption) (*TickerHistItem, error)
+	// Holes in the ticker history and whether they were backfilled from trades.
+	TickerGaps(ctx context.Context, in *TickerGapsParam, opts ...grpc.CallOption) (*TickerGapsItem, error)
+	// You can get the latest transaction history.
+	Trades(ctx context.Context, in *TradesParams, opts ...grpc.CallOption) (*TradesItem, error)
+	// OHLCV candles built from the collected trades.
+	Candles(ctx context.Context, in *CandlesParam, opts ...grpc.CallOption) (*CandlesItem, error)
+	// Dumps ticker history, candles or transactions as CSV, JSON Lines or Parquet.
+	Export(ctx context.Context, in *ExportParam, opts ...grpc.CallOption) (Coincheck_ExportClient, error)
+	// Board information can be obtained.
+	// Deprecated: use OrderBooksV2, which returns typed price levels.
+	//
+	// Deprecated: Do not use.
+	OrderBooks(ctx context.Context, in *OrderBooksParams, opts ...grpc.CallOption) (*OrderBooksItem, error)
+	// Board information as typed price levels with pair and timestamp.
+	OrderBooksV2(ctx context.Context, in *OrderBooksParams, opts ...grpc.CallOption) (*OrderBooksV2Item, error)
+	// The rate is calculated based on the exchange's order.
+	ExchangeOrdersRate(ctx context.Context, in *ExchangeOrdersRateParam, opts ...grpc.CallOption) (*ExchangeOrdersRateItem, error)
+	// Get a dealership rate
+	RatePair(ctx context.Context, in *RatePairParams, opts ...grpc.CallOption) (*RatePairItem, error)
+	// Market order Cash transaction Buy
+	MarketBuy(ctx context.Context, in *MarketBuyParams, opts ...grpc.CallOption) (*MarketItem, error)
+	// Market orders, spot trading, selling
+	MarketSell(ctx context.Context, in *MarketSellParam, opts ...grpc.CallOption) (*MarketItem, error)
+	// Limit order, spot trading, buy.
+	LimitBuy(ctx context.Context, in *LimitOrderParams, opts ...grpc.CallOption) (*MarketItem, error)
+	// Limit order, spot trading, sell.
+	LimitSell(ctx context.Context, in *LimitOrderParams, opts ...grpc.CallOption) (*MarketItem, error)
+	// View a list of pending orders in your account.
+	ExchangeOrdersOpens(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrdersOpensItem, error)
+	// You can cancel a new order or a pending order by specifying an ID in the order list.
+	DeleteExchangeOrder(ctx context.Context, in *DeleteOrderParam, opts ...grpc.CallOption) (*DeleteOrderItem, error)
+	// You can see your recent transaction history.
+	ExchangeOrdersTransactions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrdersTransactionsItem, error)
+	// You can check the balance of your account.
+	AccountsBalance(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountsBalanceItem, error)
+	// View your account information.
+	Accounts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountsItem, error)
+	// Halt trading: new orders are rejected until Resume. Survives restarts.
+	Halt(ctx context.Context, in *HaltParam, opts ...grpc.CallOption) (*HaltItem, error)
+	// Resume trading after a halt.
+	Resume(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HaltItem, error)
+	// Whether trading is halted.
+	HaltStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HaltItem, error)
+	// An order placed through the server, with its status history.
+	GetOrder(ctx context.Context, in *GetOrderParam, opts ...grpc.CallOption) (*OrderItem, error)
+	// The orders placed through the server, newest first.
+	ListOrders(ctx context.Context, in *ListOrdersParam, opts ...grpc.CallOption) (*OrderList, error)
+	// Events of the orders placed through the server, from a cursor on. The
+	// stream stays open and sends the new events as they happen.
+	SubscribeOrderEvents(ctx context.Context, in *OrderEventsParam, opts ...grpc.CallOption) (Coincheck_SubscribeOrderEventsClient, error)
+	// Keeps a stop-loss, take-profit or trailing stop order, sent once the
+	// ticker of its pair reaches the trigger.
+	PlaceConditionalOrder(ctx context.Context, in *ConditionalOrderParams, opts ...grpc.CallOption) (*ConditionalOrderItem, error)
+	// Keeps two conditional orders as an OCO pair: the trigger of one
+	// cancels the other.
+	PlaceOCOOrder(ctx context.Context, in *OCOOrderParams, opts ...grpc.CallOption) (*ConditionalOrderList, error)
+	// Cancels an active conditional order and the other order of its OCO pair.
+	CancelConditionalOrder(ctx context.Context, in *ConditionalOrderParam, opts ...grpc.CallOption) (*ConditionalOrderList, error)
+	// The conditional orders, oldest first.
+	ListConditionalOrders(ctx context.Context, in *ListConditionalOrdersParam, opts ...grpc.CallOption) (*ConditionalOrderList, error)
+	// Sends an order of a JPY amount on a cron schedule.
+	AddRecurringOrder(ctx context.Context, in *RecurringOrderParams, opts ...grpc.CallOption) (*RecurringOrderItem, error)
+	// Stops a recurring order added through AddRecurringOrder.
+	CancelRecurringOrder(ctx context.Context, in *RecurringOrderParam, opts ...grpc.CallOption) (*RecurringOrderItem, error)
+	// The recurring orders, of the config and of AddRecurringOrder.
+	ListRecurringOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RecurringOrderList, error)
+	// The runs of the recurring orders, newest first, with their outcome.
+	RecurringOrderRuns(ctx context.Context, in *RecurringRunsParam, opts ...grpc.CallOption) (*RecurringRunList, error)
+}
+
+type coincheckClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCoincheckClient(cc grpc.ClientConnInterface) CoincheckClient {
+	return &coincheckClient{cc}
+}
+
+func (c *coincheckClient) Ticker(ctx context.Context, in *TickerParams, opts ...grpc.CallOption) (*TickerItem, error) {
+	out := new(TickerItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/Ticker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) TickerHist(ctx context.Context, in *TickerHistParam, opts ...grpc.CallOption) (*TickerHistItem, error) {
+	out := new(TickerHistItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/TickerHist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) TickerGaps(ctx context.Context, in *TickerGapsParam, opts ...grpc.CallOption) (*TickerGapsItem, error) {
+	out := new(TickerGapsItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/TickerGaps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) Trades(ctx context.Context, in *TradesParams, opts ...grpc.CallOption) (*TradesItem, error) {
+	out := new(TradesItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/Trades", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) Candles(ctx context.Context, in *CandlesParam, opts ...grpc.CallOption) (*CandlesItem, error) {
+	out := new(CandlesItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/Candles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) Export(ctx context.Context, in *ExportParam, opts ...grpc.CallOption) (Coincheck_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Coincheck_serviceDesc.Streams[0], "/bitcocheck.Coincheck/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &coincheckExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Coincheck_ExportClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type coincheckExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *coincheckExportClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Deprecated: Do not use.
+func (c *coincheckClient) OrderBooks(ctx context.Context, in *OrderBooksParams, opts ...grpc.CallOption) (*OrderBooksItem, error) {
+	out := new(OrderBooksItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/OrderBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) OrderBooksV2(ctx context.Context, in *OrderBooksParams, opts ...grpc.CallOption) (*OrderBooksV2Item, error) {
+	out := new(OrderBooksV2Item)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/OrderBooksV2", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) ExchangeOrdersRate(ctx context.Context, in *ExchangeOrdersRateParam, opts ...grpc.CallOption) (*ExchangeOrdersRateItem, error) {
+	out := new(ExchangeOrdersRateItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/ExchangeOrdersRate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) RatePair(ctx context.Context, in *RatePairParams, opts ...grpc.CallOption) (*RatePairItem, error) {
+	out := new(RatePairItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/RatePair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) MarketBuy(ctx context.Context, in *MarketBuyParams, opts ...grpc.CallOption) (*MarketItem, error) {
+	out := new(MarketItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/MarketBuy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) MarketSell(ctx context.Context, in *MarketSellParam, opts ...grpc.CallOption) (*MarketItem, error) {
+	out := new(MarketItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/MarketSell", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) LimitBuy(ctx context.Context, in *LimitOrderParams, opts ...grpc.CallOption) (*MarketItem, error) {
+	out := new(MarketItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/LimitBuy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) LimitSell(ctx context.Context, in *LimitOrderParams, opts ...grpc.CallOption) (*MarketItem, error) {
+	out := new(MarketItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/LimitSell", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (c *coincheckClient) ExchangeOrdersOpens(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OrdersOpensItem, error) {
@@ -2077,15 +4600,175 @@ func (c *coincheckClient) Accounts(ctx context.Context, in *Empty, opts ...grpc.
 	return out, nil
 }
 
+func (c *coincheckClient) Halt(ctx context.Context, in *HaltParam, opts ...grpc.CallOption) (*HaltItem, error) {
+	out := new(HaltItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/Halt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) Resume(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HaltItem, error) {
+	out := new(HaltItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) HaltStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HaltItem, error) {
+	out := new(HaltItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/HaltStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) GetOrder(ctx context.Context, in *GetOrderParam, opts ...grpc.CallOption) (*OrderItem, error) {
+	out := new(OrderItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) ListOrders(ctx context.Context, in *ListOrdersParam, opts ...grpc.CallOption) (*OrderList, error) {
+	out := new(OrderList)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/ListOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) SubscribeOrderEvents(ctx context.Context, in *OrderEventsParam, opts ...grpc.CallOption) (Coincheck_SubscribeOrderEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Coincheck_serviceDesc.Streams[1], "/bitcocheck.Coincheck/SubscribeOrderEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &coincheckSubscribeOrderEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Coincheck_SubscribeOrderEventsClient interface {
+	Recv() (*OrderEvent, error)
+	grpc.ClientStream
+}
+
+type coincheckSubscribeOrderEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *coincheckSubscribeOrderEventsClient) Recv() (*OrderEvent, error) {
+	m := new(OrderEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *coincheckClient) PlaceConditionalOrder(ctx context.Context, in *ConditionalOrderParams, opts ...grpc.CallOption) (*ConditionalOrderItem, error) {
+	out := new(ConditionalOrderItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/PlaceConditionalOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) PlaceOCOOrder(ctx context.Context, in *OCOOrderParams, opts ...grpc.CallOption) (*ConditionalOrderList, error) {
+	out := new(ConditionalOrderList)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/PlaceOCOOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) CancelConditionalOrder(ctx context.Context, in *ConditionalOrderParam, opts ...grpc.CallOption) (*ConditionalOrderList, error) {
+	out := new(ConditionalOrderList)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/CancelConditionalOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) ListConditionalOrders(ctx context.Context, in *ListConditionalOrdersParam, opts ...grpc.CallOption) (*ConditionalOrderList, error) {
+	out := new(ConditionalOrderList)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/ListConditionalOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) AddRecurringOrder(ctx context.Context, in *RecurringOrderParams, opts ...grpc.CallOption) (*RecurringOrderItem, error) {
+	out := new(RecurringOrderItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/AddRecurringOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) CancelRecurringOrder(ctx context.Context, in *RecurringOrderParam, opts ...grpc.CallOption) (*RecurringOrderItem, error) {
+	out := new(RecurringOrderItem)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/CancelRecurringOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) ListRecurringOrders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*RecurringOrderList, error) {
+	out := new(RecurringOrderList)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/ListRecurringOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coincheckClient) RecurringOrderRuns(ctx context.Context, in *RecurringRunsParam, opts ...grpc.CallOption) (*RecurringRunList, error) {
+	out := new(RecurringRunList)
+	err := c.cc.Invoke(ctx, "/bitcocheck.Coincheck/RecurringOrderRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CoincheckServer is the server API for Coincheck service.
 type CoincheckServer interface {
 	// You can get the latest information easily.
-	Ticker(context.Context, *Empty) (*TickerItem, error)
+	Ticker(context.Context, *TickerParams) (*TickerItem, error)
 	TickerHist(context.Context, *TickerHistParam) (*TickerHistItem, error)
+	// Holes in the ticker history and whether they were backfilled from trades.
+	TickerGaps(context.Context, *TickerGapsParam) (*TickerGapsItem, error)
 	// You can get the latest transaction history.
 	Trades(context.Context, *TradesParams) (*TradesItem, error)
+	// OHLCV candles built from the collected trades.
+	Candles(context.Context, *CandlesParam) (*CandlesItem, error)
+	// Dumps ticker history, candles or transactions as CSV, JSON Lines or Parquet.
+	Export(*ExportParam, Coincheck_ExportServer) error
 	// Board information can be obtained.
-	OrderBooks(context.Context, *Empty) (*OrderBooksItem, error)
+	// Deprecated: use OrderBooksV2, which returns typed price levels.
+	//
+	// Deprecated: Do not use.
+	OrderBooks(context.Context, *OrderBooksParams) (*OrderBooksItem, error)
+	// Board information as typed price levels with pair and timestamp.
+	OrderBooksV2(context.Context, *OrderBooksParams) (*OrderBooksV2Item, error)
 	// The rate is calculated based on the exchange's order.
 	ExchangeOrdersRate(context.Context, *ExchangeOrdersRateParam) (*ExchangeOrdersRateItem, error)
 	// Get a dealership rate
@@ -2100,6 +4783,7 @@ type CoincheckServer interface {
 	LimitSell(context.Context, *LimitOrderParams) (*MarketItem, error)
 	// View a list of pending orders in your account.
 	ExchangeOrdersOpens(context.Context, *Empty) (*OrdersOpensItem, error)
+	// You can cancel a new order or a pending order by specifying an ID in the order list.
 	DeleteExchangeOrder(context.Context, *DeleteOrderParam) (*DeleteOrderItem, error)
 	// You can see your recent transaction history.
 	ExchangeOrdersTransactions(context.Context, *Empty) (*OrdersTransactionsItem, error)
@@ -2107,24 +4791,67 @@ type CoincheckServer interface {
 	AccountsBalance(context.Context, *Empty) (*AccountsBalanceItem, error)
 	// View your account information.
 	Accounts(context.Context, *Empty) (*AccountsItem, error)
+	// Halt trading: new orders are rejected until Resume. Survives restarts.
+	Halt(context.Context, *HaltParam) (*HaltItem, error)
+	// Resume trading after a halt.
+	Resume(context.Context, *Empty) (*HaltItem, error)
+	// Whether trading is halted.
+	HaltStatus(context.Context, *Empty) (*HaltItem, error)
+	// An order placed through the server, with its status history.
+	GetOrder(context.Context, *GetOrderParam) (*OrderItem, error)
+	// The orders placed through the server, newest first.
+	ListOrders(context.Context, *ListOrdersParam) (*OrderList, error)
+	// Events of the orders placed through the server, from a cursor on. The
+	// stream stays open and sends the new events as they happen.
+	SubscribeOrderEvents(*OrderEventsParam, Coincheck_SubscribeOrderEventsServer) error
+	// Keeps a stop-loss, take-profit or trailing stop order, sent once the
+	// ticker of its pair reaches the trigger.
+	PlaceConditionalOrder(context.Context, *ConditionalOrderParams) (*ConditionalOrderItem, error)
+	// Keeps two conditional orders as an OCO pair: the trigger of one
+	// cancels the other.
+	PlaceOCOOrder(context.Context, *OCOOrderParams) (*ConditionalOrderList, error)
+	// Cancels an active conditional order and the other order of its OCO pair.
+	CancelConditionalOrder(context.Context, *ConditionalOrderParam) (*ConditionalOrderList, error)
+	// The conditional orders, oldest first.
+	ListConditionalOrders(context.Context, *ListConditionalOrdersParam) (*ConditionalOrderList, error)
+	// Sends an order of a JPY amount on a cron schedule.
+	AddRecurringOrder(context.Context, *RecurringOrderParams) (*RecurringOrderItem, error)
+	// Stops a recurring order added through AddRecurringOrder.
+	CancelRecurringOrder(context.Context, *RecurringOrderParam) (*RecurringOrderItem, error)
+	// The recurring orders, of the config and of AddRecurringOrder.
+	ListRecurringOrders(context.Context, *Empty) (*RecurringOrderList, error)
+	// The runs of the recurring orders, newest first, with their outcome.
+	RecurringOrderRuns(context.Context, *RecurringRunsParam) (*RecurringRunList, error)
 }
 
 // UnimplementedCoincheckServer can be embedded to have forward compatible implementations.
 type UnimplementedCoincheckServer struct {
 }
 
-func (*UnimplementedCoincheckServer) Ticker(ctx context.Context, req *Empty) (*TickerItem, error) {
+func (*UnimplementedCoincheckServer) Ticker(ctx context.Context, req *TickerParams) (*TickerItem, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ticker not implemented")
 }
 func (*UnimplementedCoincheckServer) TickerHist(ctx context.Context, req *TickerHistParam) (*TickerHistItem, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TickerHist not implemented")
 }
+func (*UnimplementedCoincheckServer) TickerGaps(ctx context.Context, req *TickerGapsParam) (*TickerGapsItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TickerGaps not implemented")
+}
 func (*UnimplementedCoincheckServer) Trades(ctx context.Context, req *TradesParams) (*TradesItem, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Trades not implemented")
 }
-func (*UnimplementedCoincheckServer) OrderBooks(ctx context.Context, req *Empty) (*OrderBooksItem, error) {
+func (*UnimplementedCoincheckServer) Candles(ctx context.Context, req *CandlesParam) (*CandlesItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Candles not implemented")
+}
+func (*UnimplementedCoincheckServer) Export(req *ExportParam, srv Coincheck_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (*UnimplementedCoincheckServer) OrderBooks(ctx context.Context, req *OrderBooksParams) (*OrderBooksItem, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OrderBooks not implemented")
 }
+func (*UnimplementedCoincheckServer) OrderBooksV2(ctx context.Context, req *OrderBooksParams) (*OrderBooksV2Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OrderBooksV2 not implemented")
+}
 func (*UnimplementedCoincheckServer) ExchangeOrdersRate(ctx context.Context, req *ExchangeOrdersRateParam) (*ExchangeOrdersRateItem, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExchangeOrdersRate not implemented")
 }
@@ -2158,79 +4885,196 @@ func (*UnimplementedCoincheckServer) AccountsBalance(ctx context.Context, req *E
 func (*UnimplementedCoincheckServer) Accounts(ctx context.Context, req *Empty) (*AccountsItem, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Accounts not implemented")
 }
+func (*UnimplementedCoincheckServer) Halt(ctx context.Context, req *HaltParam) (*HaltItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Halt not implemented")
+}
+func (*UnimplementedCoincheckServer) Resume(ctx context.Context, req *Empty) (*HaltItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (*UnimplementedCoincheckServer) HaltStatus(ctx context.Context, req *Empty) (*HaltItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HaltStatus not implemented")
+}
+func (*UnimplementedCoincheckServer) GetOrder(ctx context.Context, req *GetOrderParam) (*OrderItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (*UnimplementedCoincheckServer) ListOrders(ctx context.Context, req *ListOrdersParam) (*OrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (*UnimplementedCoincheckServer) SubscribeOrderEvents(req *OrderEventsParam, srv Coincheck_SubscribeOrderEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOrderEvents not implemented")
+}
+func (*UnimplementedCoincheckServer) PlaceConditionalOrder(ctx context.Context, req *ConditionalOrderParams) (*ConditionalOrderItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceConditionalOrder not implemented")
+}
+func (*UnimplementedCoincheckServer) PlaceOCOOrder(ctx context.Context, req *OCOOrderParams) (*ConditionalOrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOCOOrder not implemented")
+}
+func (*UnimplementedCoincheckServer) CancelConditionalOrder(ctx context.Context, req *ConditionalOrderParam) (*ConditionalOrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelConditionalOrder not implemented")
+}
+func (*UnimplementedCoincheckServer) ListConditionalOrders(ctx context.Context, req *ListConditionalOrdersParam) (*ConditionalOrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConditionalOrders not implemented")
+}
+func (*UnimplementedCoincheckServer) AddRecurringOrder(ctx context.Context, req *RecurringOrderParams) (*RecurringOrderItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRecurringOrder not implemented")
+}
+func (*UnimplementedCoincheckServer) CancelRecurringOrder(ctx context.Context, req *RecurringOrderParam) (*RecurringOrderItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelRecurringOrder not implemented")
+}
+func (*UnimplementedCoincheckServer) ListRecurringOrders(ctx context.Context, req *Empty) (*RecurringOrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecurringOrders not implemented")
+}
+func (*UnimplementedCoincheckServer) RecurringOrderRuns(ctx context.Context, req *RecurringRunsParam) (*RecurringRunList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecurringOrderRuns not implemented")
+}
 
 func RegisterCoincheckServer(s *grpc.Server, srv CoincheckServer) {
 	s.RegisterService(&_Coincheck_serviceDesc, srv)
 }
 
-func _Coincheck_Ticker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _Coincheck_Ticker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TickerParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).Ticker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/Ticker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).Ticker(ctx, req.(*TickerParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_TickerHist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TickerHistParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).TickerHist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/TickerHist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).TickerHist(ctx, req.(*TickerHistParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_TickerGaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TickerGapsParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).TickerGaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/TickerGaps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).TickerGaps(ctx, req.(*TickerGapsParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_Trades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TradesParams)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CoincheckServer).Ticker(ctx, in)
+		return srv.(CoincheckServer).Trades(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bitcocheck.Coincheck/Ticker",
+		FullMethod: "/bitcocheck.Coincheck/Trades",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CoincheckServer).Ticker(ctx, req.(*Empty))
+		return srv.(CoincheckServer).Trades(ctx, req.(*TradesParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Coincheck_TickerHist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TickerHistParam)
+func _Coincheck_Candles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CandlesParam)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CoincheckServer).TickerHist(ctx, in)
+		return srv.(CoincheckServer).Candles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bitcocheck.Coincheck/TickerHist",
+		FullMethod: "/bitcocheck.Coincheck/Candles",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CoincheckServer).TickerHist(ctx, req.(*TickerHistParam))
+		return srv.(CoincheckServer).Candles(ctx, req.(*CandlesParam))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Coincheck_Trades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TradesParams)
+func _Coincheck_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportParam)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoincheckServer).Export(m, &coincheckExportServer{stream})
+}
+
+type Coincheck_ExportServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type coincheckExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *coincheckExportServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Coincheck_OrderBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderBooksParams)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CoincheckServer).Trades(ctx, in)
+		return srv.(CoincheckServer).OrderBooks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bitcocheck.Coincheck/Trades",
+		FullMethod: "/bitcocheck.Coincheck/OrderBooks",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CoincheckServer).Trades(ctx, req.(*TradesParams))
+		return srv.(CoincheckServer).OrderBooks(ctx, req.(*OrderBooksParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Coincheck_OrderBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _Coincheck_OrderBooksV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderBooksParams)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CoincheckServer).OrderBooks(ctx, in)
+		return srv.(CoincheckServer).OrderBooksV2(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/bitcocheck.Coincheck/OrderBooks",
+		FullMethod: "/bitcocheck.Coincheck/OrderBooksV2",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CoincheckServer).OrderBooks(ctx, req.(*Empty))
+		return srv.(CoincheckServer).OrderBooksV2(ctx, req.(*OrderBooksParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -2433,6 +5277,261 @@ func _Coincheck_Accounts_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Coincheck_Halt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HaltParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).Halt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/Halt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).Halt(ctx, req.(*HaltParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).Resume(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_HaltStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).HaltStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/HaltStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).HaltStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).GetOrder(ctx, req.(*GetOrderParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/ListOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).ListOrders(ctx, req.(*ListOrdersParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_SubscribeOrderEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OrderEventsParam)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoincheckServer).SubscribeOrderEvents(m, &coincheckSubscribeOrderEventsServer{stream})
+}
+
+type Coincheck_SubscribeOrderEventsServer interface {
+	Send(*OrderEvent) error
+	grpc.ServerStream
+}
+
+type coincheckSubscribeOrderEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *coincheckSubscribeOrderEventsServer) Send(m *OrderEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Coincheck_PlaceConditionalOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConditionalOrderParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).PlaceConditionalOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/PlaceConditionalOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).PlaceConditionalOrder(ctx, req.(*ConditionalOrderParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_PlaceOCOOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OCOOrderParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).PlaceOCOOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/PlaceOCOOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).PlaceOCOOrder(ctx, req.(*OCOOrderParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_CancelConditionalOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConditionalOrderParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).CancelConditionalOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/CancelConditionalOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).CancelConditionalOrder(ctx, req.(*ConditionalOrderParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_ListConditionalOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConditionalOrdersParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).ListConditionalOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/ListConditionalOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).ListConditionalOrders(ctx, req.(*ListConditionalOrdersParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_AddRecurringOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecurringOrderParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).AddRecurringOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/AddRecurringOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).AddRecurringOrder(ctx, req.(*RecurringOrderParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_CancelRecurringOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecurringOrderParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).CancelRecurringOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/CancelRecurringOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).CancelRecurringOrder(ctx, req.(*RecurringOrderParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_ListRecurringOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).ListRecurringOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/ListRecurringOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).ListRecurringOrders(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coincheck_RecurringOrderRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecurringRunsParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoincheckServer).RecurringOrderRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bitcocheck.Coincheck/RecurringOrderRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoincheckServer).RecurringOrderRuns(ctx, req.(*RecurringRunsParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Coincheck_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "bitcocheck.Coincheck",
 	HandlerType: (*CoincheckServer)(nil),
@@ -2445,14 +5544,26 @@ var _Coincheck_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TickerHist",
 			Handler:    _Coincheck_TickerHist_Handler,
 		},
+		{
+			MethodName: "TickerGaps",
+			Handler:    _Coincheck_TickerGaps_Handler,
+		},
 		{
 			MethodName: "Trades",
 			Handler:    _Coincheck_Trades_Handler,
 		},
+		{
+			MethodName: "Candles",
+			Handler:    _Coincheck_Candles_Handler,
+		},
 		{
 			MethodName: "OrderBooks",
 			Handler:    _Coincheck_OrderBooks_Handler,
 		},
+		{
+			MethodName: "OrderBooksV2",
+			Handler:    _Coincheck_OrderBooksV2_Handler,
+		},
 		{
 			MethodName: "ExchangeOrdersRate",
 			Handler:    _Coincheck_ExchangeOrdersRate_Handler,
@@ -2497,7 +5608,70 @@ var _Coincheck_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Accounts",
 			Handler:    _Coincheck_Accounts_Handler,
 		},
+		{
+			MethodName: "Halt",
+			Handler:    _Coincheck_Halt_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Coincheck_Resume_Handler,
+		},
+		{
+			MethodName: "HaltStatus",
+			Handler:    _Coincheck_HaltStatus_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _Coincheck_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListOrders",
+			Handler:    _Coincheck_ListOrders_Handler,
+		},
+		{
+			MethodName: "PlaceConditionalOrder",
+			Handler:    _Coincheck_PlaceConditionalOrder_Handler,
+		},
+		{
+			MethodName: "PlaceOCOOrder",
+			Handler:    _Coincheck_PlaceOCOOrder_Handler,
+		},
+		{
+			MethodName: "CancelConditionalOrder",
+			Handler:    _Coincheck_CancelConditionalOrder_Handler,
+		},
+		{
+			MethodName: "ListConditionalOrders",
+			Handler:    _Coincheck_ListConditionalOrders_Handler,
+		},
+		{
+			MethodName: "AddRecurringOrder",
+			Handler:    _Coincheck_AddRecurringOrder_Handler,
+		},
+		{
+			MethodName: "CancelRecurringOrder",
+			Handler:    _Coincheck_CancelRecurringOrder_Handler,
+		},
+		{
+			MethodName: "ListRecurringOrders",
+			Handler:    _Coincheck_ListRecurringOrders_Handler,
+		},
+		{
+			MethodName: "RecurringOrderRuns",
+			Handler:    _Coincheck_RecurringOrderRuns_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Export",
+			Handler:       _Coincheck_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeOrderEvents",
+			Handler:       _Coincheck_SubscribeOrderEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "bitcocheck.proto",
 }
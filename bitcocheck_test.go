@@ -1,6 +1,7 @@
 package bitcocheck
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -235,6 +236,7 @@ func TestTickercc(t *testing.T) {
 	}
 	type args struct {
 		conf Config
+		pair Pair
 	}
 	tests := []struct {
 		name    string
@@ -245,14 +247,14 @@ func TestTickercc(t *testing.T) {
 		// TODO: Add test cases.
 		{
 			name:    "ticker test",
-			args:    args{conf: conf},
+			args:    args{conf: conf, pair: Btcjpy},
 			want:    TickerItem{},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := Tickercc(tt.args.conf)
+			got, err := Tickercc(tt.args.conf, tt.args.pair)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Tickercc() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -308,6 +310,7 @@ func TestOrderBookscc(t *testing.T) {
 	}
 	type args struct {
 		conf Config
+		pair Pair
 	}
 	tests := []struct {
 		name    string
@@ -318,14 +321,14 @@ func TestOrderBookscc(t *testing.T) {
 		// TODO: Add test cases.
 		{
 			name:    "order books test",
-			args:    args{conf: conf},
+			args:    args{conf: conf, pair: Btcjpy},
 			want:    OrderBooksItem{},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := OrderBookscc(tt.args.conf)
+			got, err := OrderBookscc(tt.args.conf, tt.args.pair)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("OrderBookscc() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -418,3 +421,167 @@ func TestExchangeOrdersRatecc(t *testing.T) {
 		})
 	}
 }
+
+func Test_priceLevels(t *testing.T) {
+	type args struct {
+		orders [][]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*PriceLevel
+		wantErr bool
+	}{
+		{
+			name: "price levels test",
+			args: args{orders: [][]string{{"964163.0", "0.0139"}, {"964200.0", "0.2"}}},
+			want: []*PriceLevel{
+				{Rate: "964163.0", Amount: "0.0139"},
+				{Rate: "964200.0", Amount: "0.2"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty board test",
+			args:    args{orders: [][]string{}},
+			want:    []*PriceLevel{},
+			wantErr: false,
+		},
+		{
+			name:    "malformed entry test",
+			args:    args{orders: [][]string{{"964163.0"}}},
+			want:    []*PriceLevel{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := priceLevels(tt.args.orders)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("priceLevels() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("priceLevels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitOrderPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload LimitOrderPayload
+		want    string
+	}{
+		{
+			name:    "limit order test",
+			payload: LimitOrderPayload{Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.01"},
+			want:    `{"pair":"btc_jpy","order_type":"buy","rate":"1000000","amount":"0.01"}`,
+		},
+		{
+			name:    "stop loss rate test",
+			payload: LimitOrderPayload{Pair: "btc_jpy", OrderType: "sell", Rate: "900000", Amount: "0.01", StopLossRate: "950000"},
+			want:    `{"pair":"btc_jpy","order_type":"sell","rate":"900000","amount":"0.01","stop_loss_rate":"950000"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePair(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Pair
+		wantErr bool
+	}{
+		{name: "default pair test", args: args{s: ""}, want: Btcjpy, wantErr: false},
+		{name: "btc_jpy test", args: args{s: "btc_jpy"}, want: Btcjpy, wantErr: false},
+		{name: "fct_jpy test", args: args{s: "fct_jpy"}, want: Fctjpy, wantErr: false},
+		{name: "legacy ftc_jpy test", args: args{s: "ftc_jpy"}, want: Fctjpy, wantErr: false},
+		{name: "unknown pair test", args: args{s: "doge_jpy"}, want: Btcjpy, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePair(tt.args.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePair() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParsePair() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_decodeTrades(t *testing.T) {
+	type args struct {
+		jsonBlob []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    TradesItem
+		wantErr bool
+	}{
+		{
+			name: "decode trades test",
+			args: args{jsonBlob: []byte(`{"success":true,"pagination":{"limit":1,"order":"desc","starting_after":null,"ending_before":null},"data":[{"id":82,"amount":"0.28391","rate":"35400.0","pair":"btc_jpy","order_type":"sell","created_at":"2015-01-10T05:55:38.000Z"},{"id":81,"amount":"0.1","rate":35390,"pair":"btc_jpy","order_type":"buy","created_at":"2015-01-10T05:55:37.000Z"}]}`)},
+			want: TradesItem{
+				Success:    true,
+				Pagination: &Pagenation{Limit: 1, Order: "desc"},
+				Data: []*TradeData{
+					{ID: 82, Amount: "0.28391", Rate: 35400, RateV2: 35400, Pair: "btc_jpy", OrderType: "sell", CreatedAt: "2015-01-10T05:55:38.000Z"},
+					{ID: 81, Amount: "0.1", Rate: 35390, RateV2: 35390, Pair: "btc_jpy", OrderType: "buy", CreatedAt: "2015-01-10T05:55:37.000Z"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rate past the precision of a float",
+			args: args{jsonBlob: []byte(`{"success":true,"pagination":{"limit":1,"order":"desc"},"data":[{"id":83,"amount":"0.01","rate":"16777217.0","pair":"btc_jpy","order_type":"buy","created_at":"2024-03-01T00:00:00.000Z"}]}`)},
+			want: TradesItem{
+				Success:    true,
+				Pagination: &Pagenation{Limit: 1, Order: "desc"},
+				Data: []*TradeData{
+					{ID: 83, Amount: "0.01", Rate: 16777216, RateV2: 16777217, Pair: "btc_jpy", OrderType: "buy", CreatedAt: "2024-03-01T00:00:00.000Z"},
+				},
+			},
+		},
+		{
+			name:    "invalid rate test",
+			args:    args{jsonBlob: []byte(`{"success":true,"data":[{"id":82,"rate":"abc"}]}`)},
+			want:    TradesItem{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeTrades(tt.args.jsonBlob)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeTrades() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeTrades() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
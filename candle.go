@@ -0,0 +1,60 @@
+package bitcocheck
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TradeTime parses the created_at of a trade.
+func TradeTime(trade *TradeData) (time.Time, error) {
+	return time.Parse(time.RFC3339, trade.CreatedAt)
+}
+
+// BuildCandles aggregates trades into OHLCV candles of the interval. Trades
+// are ordered by ID and a trade ID seen more than once is counted once.
+func BuildCandles(trades []*TradeData, interval time.Duration) ([]*Candle, error) {
+	sorted := make([]*TradeData, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	candles := []*Candle{}
+	byStart := map[int64]*Candle{}
+	seen := map[uint32]bool{}
+	for _, trade := range sorted {
+		if seen[trade.ID] {
+			continue
+		}
+		seen[trade.ID] = true
+		tm, err := TradeTime(trade)
+		if err != nil {
+			return candles, err
+		}
+		amount, err := strconv.ParseFloat(trade.Amount, 64)
+		if err != nil {
+			return candles, err
+		}
+		rate := trade.RateV2
+		start := tm.Truncate(interval).Unix()
+		candle, ok := byStart[start]
+		if !ok {
+			candle = &Candle{Timestamp: uint64(start), Open: rate, High: rate, Low: rate}
+			byStart[start] = candle
+			candles = append(candles, candle)
+		}
+		if rate > candle.High {
+			candle.High = rate
+		}
+		if rate < candle.Low {
+			candle.Low = rate
+		}
+		candle.Close = rate
+		candle.Volume += amount
+		candle.Trades++
+	}
+	sort.SliceStable(candles, func(i, j int) bool {
+		return candles[i].Timestamp < candles[j].Timestamp
+	})
+	return candles, nil
+}
@@ -0,0 +1,76 @@
+package bitcocheck
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuildCandles(t *testing.T) {
+	trade := func(id uint32, rate float64, amount, createdAt string) *TradeData {
+		return &TradeData{ID: id, Amount: amount, Rate: float32(rate), RateV2: rate, Pair: "btc_jpy", OrderType: "buy", CreatedAt: createdAt}
+	}
+	type args struct {
+		trades   []*TradeData
+		interval time.Duration
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*Candle
+		wantErr bool
+	}{
+		{
+			name: "minute candles test",
+			args: args{
+				trades: []*TradeData{
+					trade(3, 1010, "0.5", "2020-03-15T10:00:40.000Z"),
+					trade(1, 1000, "0.1", "2020-03-15T10:00:01.000Z"),
+					trade(2, 1020, "0.2", "2020-03-15T10:00:20.000Z"),
+					trade(4, 990, "1", "2020-03-15T10:01:05.000Z"),
+				},
+				interval: time.Minute,
+			},
+			want: []*Candle{
+				{Timestamp: 1584266400, Open: 1000, High: 1020, Low: 1000, Close: 1010, Volume: 0.8, Trades: 3},
+				{Timestamp: 1584266460, Open: 990, High: 990, Low: 990, Close: 990, Volume: 1, Trades: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicated trades test",
+			args: args{
+				trades: []*TradeData{
+					trade(1, 1000, "0.5", "2020-03-15T10:00:01.000Z"),
+					trade(1, 1000, "0.5", "2020-03-15T10:00:01.000Z"),
+				},
+				interval: time.Hour,
+			},
+			want: []*Candle{
+				{Timestamp: 1584266400, Open: 1000, High: 1000, Low: 1000, Close: 1000, Volume: 0.5, Trades: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid time test",
+			args: args{
+				trades:   []*TradeData{trade(1, 1000, "0.5", "yesterday")},
+				interval: time.Minute,
+			},
+			want:    []*Candle{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildCandles(tt.args.trades, tt.args.interval)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildCandles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildCandles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
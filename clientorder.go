@@ -0,0 +1,205 @@
+package bitcocheck
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// States of a client order.
+const (
+	// ClientOrderPending The order is being sent.
+	ClientOrderPending = "pending"
+	// ClientOrderPlaced The exchange took the order.
+	ClientOrderPlaced = "placed"
+	// ClientOrderRejected The order was refused or never reached the exchange.
+	ClientOrderRejected = "rejected"
+	// ClientOrderUnknown The order was sent, but its answer was lost. It is
+	// settled by looking for it among the orders of the account.
+	ClientOrderUnknown = "unknown"
+)
+
+// ClientOrder An order submitted with an ID chosen by the client, kept to
+// answer the retries of the submission. Amount is the JPY amount of a
+// market_buy. Item is the answer of the exchange as JSON once placed.
+type ClientOrder struct {
+	ClientID     string
+	Pair         string
+	OrderType    string
+	Rate         string
+	Amount       string
+	StopLossRate string
+	State        string
+	OrderID      uint64
+	Item         string
+	Error        string
+	Created      time.Time
+	Updated      time.Time
+}
+
+// SameOrder tells whether other submits the same order as o.
+func (o ClientOrder) SameOrder(other ClientOrder) bool {
+	return o.Pair == other.Pair && o.OrderType == other.OrderType && sameNumber(o.Rate, other.Rate) &&
+		sameNumber(o.Amount, other.Amount) && sameNumber(o.StopLossRate, other.StopLossRate)
+}
+
+func sameNumber(a, b string) bool {
+	if a == b {
+		return true
+	}
+	x, errx := strconv.ParseFloat(a, 64)
+	y, erry := strconv.ParseFloat(b, 64)
+	return errx == nil && erry == nil && x == y
+}
+
+// ClientOrderStore The client orders of the server, by client ID.
+type ClientOrderStore interface {
+	// AddClientOrder saves a new client order. When the client ID is taken,
+	// it returns the stored order and false.
+	AddClientOrder(o ClientOrder) (ClientOrder, bool, error)
+	// UpdateClientOrder replaces the client order of the same client ID.
+	UpdateClientOrder(o ClientOrder) error
+	// ClientOrder returns the client order of a client ID, false when there is none.
+	ClientOrder(clientID string) (ClientOrder, bool, error)
+	// ClientOrders returns the client orders of a state, of every state for
+	// "", created at or after since, ordered by creation time.
+	ClientOrders(state string, since time.Time) ([]ClientOrder, error)
+}
+
+// OrdersConfig Settings of the orders placed through the server.
+type OrdersConfig struct {
+	ReconcileSchedule string `toml:"reconcile_schedule"`
+	UnknownTimeout    string `toml:"unknown_timeout"`
+	TrackSchedule     string `toml:"track_schedule"`
+	WatchSchedule     string `toml:"watch_schedule"`
+}
+
+// WatchSpec returns the cron spec of the checks of the conditional orders.
+func (c OrdersConfig) WatchSpec() string {
+	if c.WatchSchedule == "" {
+		return "@every 10s"
+	}
+	return c.WatchSchedule
+}
+
+// TrackSpec returns the cron spec of the polling of the tracked orders.
+func (c OrdersConfig) TrackSpec() string {
+	if c.TrackSchedule == "" {
+		return "@every 30s"
+	}
+	return c.TrackSchedule
+}
+
+// ReconcileSpec returns the cron spec of the reconciliation of the orders
+// with an unknown outcome.
+func (c OrdersConfig) ReconcileSpec() string {
+	if c.ReconcileSchedule == "" {
+		return "@every 1m"
+	}
+	return c.ReconcileSchedule
+}
+
+// UnknownTimeoutDuration returns how long an order with an unknown outcome is
+// looked for before it is taken as not placed, 10m when unset.
+func (c OrdersConfig) UnknownTimeoutDuration() (time.Duration, error) {
+	if c.UnknownTimeout == "" {
+		return 10 * time.Minute, nil
+	}
+	return time.ParseDuration(c.UnknownTimeout)
+}
+
+// clockSkew How much older than its client order an order of the exchange
+// may look.
+const clockSkew = time.Minute
+
+// amountTolerance How far, as a fraction, the amount of an order of the
+// exchange may be from the amount of its client order, for the rounding of
+// the fills.
+const amountTolerance = 0.001
+
+// ReconcileClientOrder looks for the order of a client order among the open
+// orders and the transactions of the account, the oldest matching order
+// created after the client order. An order matches when it has the pair and
+// the side of the client order, and its pending amount and fills add up to
+// the amount of the client order: in the base currency, in JPY for a
+// market_buy. A limit order must also have its rate and fill at it or
+// better. taken are the order IDs of the other client orders. It returns 0
+// when no order matches.
+func ReconcileClientOrder(o ClientOrder, opens OrdersOpensItem, txs OrdersTransactionsItem, taken map[uint64]bool) uint64 {
+	rate, _ := strconv.ParseFloat(o.Rate, 64)
+	amount, err := strconv.ParseFloat(o.Amount, 64)
+	if err != nil || amount <= 0 {
+		return 0
+	}
+	side := o.OrderType
+	switch o.OrderType {
+	case MarketBuy.String():
+		side = Buy.String()
+	case MarketSell.String():
+		side = Sell.String()
+	}
+	limit := o.OrderType == Buy.String() || o.OrderType == Sell.String()
+	after := o.Created.Add(-clockSkew)
+
+	type candidate struct {
+		amount float64
+		at     time.Time
+	}
+	candidates := map[uint64]*candidate{}
+	add := func(id uint64, createdAt string, amount float64) {
+		at, err := time.Parse(time.RFC3339, createdAt)
+		if err != nil || at.Before(after) || taken[id] {
+			return
+		}
+		c, ok := candidates[id]
+		if !ok {
+			c = &candidate{at: at}
+			candidates[id] = c
+		}
+		if at.Before(c.at) {
+			c.at = at
+		}
+		c.amount += amount
+	}
+	if limit {
+		for _, open := range opens.Orders {
+			if open.OrderType != o.OrderType || (open.Pair != "" && open.Pair != o.Pair) || float64(open.Rate) != rate {
+				continue
+			}
+			pending, err := strconv.ParseFloat(open.PendingAmount, 64)
+			if err != nil {
+				continue
+			}
+			add(uint64(open.Id), open.CreatedAt, pending)
+		}
+	}
+	for _, tx := range txs.Transactions {
+		if tx.Side != side || (tx.Pair != "" && tx.Pair != o.Pair) || tx.Funds == nil {
+			continue
+		}
+		if limit {
+			// A limit order fills at its rate or better.
+			filled, err := strconv.ParseFloat(tx.Rate, 64)
+			if err != nil || (side == Buy.String() && filled > rate) || (side == Sell.String() && filled < rate) {
+				continue
+			}
+		}
+		filled, err := fundsAmount(tx.Funds.Base, tx.Funds.Btc, tx.Funds.Jpy, o.OrderType == MarketBuy.String())
+		if err != nil {
+			continue
+		}
+		add(uint64(tx.OrderId), tx.CreatedAt, filled)
+	}
+
+	var found uint64
+	var foundAt time.Time
+	for id, c := range candidates {
+		if math.Abs(c.amount-amount) > amountTolerance*amount {
+			continue
+		}
+		if found == 0 || c.at.Before(foundAt) || (c.at.Equal(foundAt) && id < found) {
+			found, foundAt = id, c.at
+		}
+	}
+	return found
+}
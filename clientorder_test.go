@@ -0,0 +1,125 @@
+package bitcocheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClientOrderSameOrder(t *testing.T) {
+	o := ClientOrder{ClientID: "c1", Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1"}
+	tests := []struct {
+		name  string
+		other ClientOrder
+		want  bool
+	}{
+		{"same", o, true},
+		{"same numbers", ClientOrder{Pair: "btc_jpy", OrderType: "buy", Rate: "1000000.0", Amount: "0.10"}, true},
+		{"other rate", ClientOrder{Pair: "btc_jpy", OrderType: "buy", Rate: "990000", Amount: "0.1"}, false},
+		{"other side", ClientOrder{Pair: "btc_jpy", OrderType: "sell", Rate: "1000000", Amount: "0.1"}, false},
+		{"stop loss", ClientOrder{Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1", StopLossRate: "1100000"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := o.SameOrder(tt.other); got != tt.want {
+				t.Errorf("SameOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileClientOrder(t *testing.T) {
+	created := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	opens := OrdersOpensItem{Orders: []*OpenItem{
+		{Id: 10, OrderType: "buy", Pair: "btc_jpy", Rate: 1000000, PendingAmount: "0.1", CreatedAt: "2020-09-01T11:30:00.000Z"},
+		{Id: 11, OrderType: "buy", Pair: "btc_jpy", Rate: 1000000, PendingAmount: "0.1", CreatedAt: "2020-09-01T12:00:01.000Z"},
+		{Id: 12, OrderType: "buy", Pair: "btc_jpy", Rate: 1000000, PendingAmount: "0.05", CreatedAt: "2020-09-01T12:00:02.000Z"},
+		{Id: 13, OrderType: "sell", Pair: "btc_jpy", Rate: 1100000, PendingAmount: "0.1", CreatedAt: "2020-09-01T12:00:01.000Z"},
+		{Id: 14, OrderType: "buy", Pair: "fct_jpy", Rate: 1000000, PendingAmount: "0.1", CreatedAt: "2020-09-01T12:00:00.000Z"},
+	}}
+	txs := OrdersTransactionsItem{Transactions: []*TransactionsItem{
+		{Id: 1, OrderId: 20, Pair: "btc_jpy", Side: "buy", Rate: "990000", Funds: &Funds{Btc: "0.1", Jpy: "-99000"}, CreatedAt: "2020-09-01T12:00:03.000Z"},
+		{Id: 2, OrderId: 21, Pair: "btc_jpy", Side: "sell", Rate: "1050000", Funds: &Funds{Btc: "-0.6", Jpy: "630000"}, CreatedAt: "2020-09-01T12:00:01.000Z"},
+		{Id: 3, OrderId: 21, Pair: "btc_jpy", Side: "sell", Rate: "1049000", Funds: &Funds{Btc: "-0.4", Jpy: "419600"}, CreatedAt: "2020-09-01T12:00:01.000Z"},
+		{Id: 4, OrderId: 22, Pair: "eth_jpy", Side: "buy", Rate: "30000", Funds: &Funds{Jpy: "-10000"}, CreatedAt: "2020-09-01T12:00:00.000Z"},
+		{Id: 5, OrderId: 12, Pair: "btc_jpy", Side: "buy", Rate: "1000000", Funds: &Funds{Btc: "0.05", Jpy: "-50000"}, CreatedAt: "2020-09-01T12:00:04.000Z"},
+		// Two market buys of the same side, the smaller one first.
+		{Id: 6, OrderId: 30, Pair: "btc_jpy", Side: "buy", Rate: "1000000", Funds: &Funds{Btc: "0.005", Jpy: "-5000"}, CreatedAt: "2020-09-01T12:00:05.000Z"},
+		{Id: 7, OrderId: 31, Pair: "btc_jpy", Side: "buy", Rate: "1000000", Funds: &Funds{Btc: "0.02", Jpy: "-20000"}, CreatedAt: "2020-09-01T12:00:06.000Z"},
+	}}
+	tests := []struct {
+		name  string
+		o     ClientOrder
+		taken map[uint64]bool
+		want  uint64
+	}{
+		{
+			name: "oldest open order after the submission",
+			o:    ClientOrder{Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1", Created: created},
+			want: 11,
+		},
+		{
+			name:  "orders of other client orders are skipped",
+			o:     ClientOrder{Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1", Created: created},
+			taken: map[uint64]bool{11: true},
+			want:  12,
+		},
+		{
+			name:  "filled at a better rate",
+			o:     ClientOrder{Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1", Created: created},
+			taken: map[uint64]bool{11: true, 12: true},
+			want:  20,
+		},
+		{
+			name: "open order of another amount",
+			o:    ClientOrder{Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.2", Created: created},
+			want: 0,
+		},
+		{
+			name: "open order of another pair",
+			o:    ClientOrder{Pair: "fct_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1", Created: created.Add(-time.Minute)},
+			want: 14,
+		},
+		{
+			name: "sell below the rate does not match",
+			o:    ClientOrder{Pair: "btc_jpy", OrderType: "sell", Rate: "1060000", Amount: "0.1", Created: created},
+			want: 0,
+		},
+		{
+			name: "market sell of several fills",
+			o:    ClientOrder{Pair: "btc_jpy", OrderType: "market_sell", Amount: "1", Created: created},
+			want: 21,
+		},
+		{
+			name: "market sell of another amount",
+			o:    ClientOrder{Pair: "btc_jpy", OrderType: "market_sell", Amount: "0.5", Created: created},
+			want: 0,
+		},
+		{
+			name: "market buy of another pair",
+			o:    ClientOrder{Pair: "eth_jpy", OrderType: "market_buy", Amount: "10000", Created: created},
+			want: 22,
+		},
+		{
+			name: "market buy matched by its JPY",
+			o:    ClientOrder{Pair: "btc_jpy", OrderType: "market_buy", Amount: "20000", Created: created},
+			want: 31,
+		},
+		{
+			name: "the other market buy",
+			o:    ClientOrder{Pair: "btc_jpy", OrderType: "market_buy", Amount: "5000", Created: created},
+			want: 30,
+		},
+		{
+			name: "orders before the submission do not match",
+			o:    ClientOrder{Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1", Created: created.Add(time.Hour)},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReconcileClientOrder(tt.o, opens, txs, tt.taken); got != tt.want {
+				t.Errorf("ReconcileClientOrder() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,193 +3,92 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/bvinc/go-sqlite-lite/sqlite3"
 	bitco "github.com/hypoballad/bitcocheck"
 	"github.com/rs/xid"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var addr = flag.String("addr", "localhost:50051", "server address")
-var actualMode = flag.Bool("actual", false, "actual mode")
+var actualMode = flag.Bool("actual", false, "place the orders; without it limitbuy and limitsell only show them. Paper trading is the [paper] mode of bitcocheck")
 var debugMode = flag.Bool("debug", false, "mode debug")
 var commandName = flag.String("c", "", "")
-var dbFile = flag.String("db", "bitcobuy.db", "")
-
-var conf *sqlite3.Conn
-
-const OrderInfo = `create table if not exists order_info (
-	id text PRIMARY_KEY,
-	order_id int NOT NULL,
-	order_type text NOT NULL,
-	ts timestamp NOT NULL,
-	btc text NOT NULL,
-	yen text NOT NULL,
-	item json NOT NULL
-)
-`
-
-const TradeHist = `create table if not exists trade_hist (
-	id text PRIMARY_KEY,
-	ts timestamp NOT NULL,
-	btc text not NULL,
-	yen text NOT NULL
-)
-`
-
-func createSQL(conn *sqlite3.Conn) error {
-	for _, stmt := range []string{OrderInfo, TradeHist} {
-		if err := conn.Exec(stmt); err != nil {
-			return errors.New(fmt.Sprintf("%v, %s", err, stmt))
-		}
-	}
-	return nil
-}
-
-type Hist struct {
-	ID  string
-	Ts  string
-	Btc string
-	Yen string
-}
-
-func FindTradeHist(conn *sqlite3.Conn) ([]Hist, error) {
-	hists := []Hist{}
-	stmt, err := conn.Prepare(`select id, ts, btc, yen from trade_hist order by ts asc`)
-	if err != nil {
-		return hists, err
-	}
-	defer stmt.Close()
-	for {
-		hasRow, err := stmt.Step()
-		if err != nil {
-			return hists, err
-		}
-		if !hasRow {
-			break
-		}
-		var id string
-		var ts string
-		var btc string
-		var yen string
-		if err := stmt.Scan(&id, &ts, &btc, &yen); err != nil {
-			return hists, err
-		}
-		hists = append(hists, Hist{ID: id, Ts: ts, Btc: btc, Yen: yen})
-	}
-	return hists, nil
-}
-
-func SaveTradeHist(conn *sqlite3.Conn, btc, yen string) error {
-	guid := xid.New()
-	if err := conn.Begin(); err != nil {
-		return err
-	}
-	stmt, err := conn.Prepare(`insert into trade_hist values (?,?,?,?)`)
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
-	tm := time.Now()
-
-	if err := stmt.Exec(guid.String(), tm.Format("2006-01-02 15:04:05"), btc, yen); err != nil {
-		return err
-	}
-	if err := conn.Commit(); err != nil {
-		return err
-	}
-	return nil
+var dbFile = flag.String("db", "bitcobuy.db", "sqlite3 db file name")
+var storeDriver = flag.String("store", "sqlite", "storage backend, sqlite, memory or postgres")
+var storeDSN = flag.String("dsn", "", "PostgreSQL connection string of the postgres store")
+var useTLS = flag.Bool("tls", false, "connect with tls, verifying the server with the system roots unless -tls-ca is set")
+var tlsCA = flag.String("tls-ca", "", "CA file of the server certificate")
+var tlsCert = flag.String("tls-cert", "", "client certificate file for mutual tls")
+var tlsKey = flag.String("tls-key", "", "client key file for mutual tls")
+var tlsServerName = flag.String("tls-server-name", "", "server name expected in the server certificate")
+var token = flag.String("token", "", "API token or JWT, $BITCOCHECK_TOKEN when not set")
+var haltReason = flag.String("reason", "", "reason of the halt command")
+var cancelOrders = flag.Bool("cancel-orders", false, "halt command: also cancel the open orders")
+var cursorFile = flag.String("cursor", "bitcobuy-events.cursor", "events command: file keeping the last order event received")
+
+// dial connects to bitcocheck, with tls when one of the -tls flags is set,
+// sending the API token with every RPC.
+func dial(addr string) (*grpc.ClientConn, error) {
+	creds, err := bitco.DialCredentials(bitco.TLSConfig{
+		Enable:     *useTLS,
+		CA:         *tlsCA,
+		Cert:       *tlsCert,
+		Key:        *tlsKey,
+		ServerName: *tlsServerName,
+	}, addr)
+	if err != nil {
+		return nil, err
+	}
+	t := *token
+	if t == "" {
+		t = os.Getenv("BITCOCHECK_TOKEN")
+	}
+	opts := append(bitco.TokenCredentials(t), creds, grpc.WithBlock())
+	return grpc.Dial(addr, opts...)
 }
 
-type Order struct {
-	ID        string
-	OredrID   uint32
-	OrderType string
-	Ts        string
-	Btc       string
-	Yen       string
-	Item      string
+// SaveTradeHist records a settled position.
+func SaveTradeHist(store bitco.Store, btc, yen string) error {
+	return store.SaveTradeHist(bitco.TradeHist{
+		ID:   xid.New().String(),
+		Time: time.Now(),
+		Btc:  btc,
+		Yen:  yen,
+		Pair: bitco.Btcjpy.String(),
+		Fee:  "0",
+	})
 }
 
-func FindBuyList(conn *sqlite3.Conn) ([]Order, error) {
-	orders := []Order{}
-	stmt, err := conn.Prepare(`select id, order_id, order_type, ts, btc, yen, item from order_info order by ts asc`)
-	if err != nil {
-		return orders, err
-	}
-	defer stmt.Close()
-	for {
-		hasRow, err := stmt.Step()
-		if err != nil {
-			return orders, err
-		}
-		if !hasRow {
-			break
-		}
-		var id string
-		var orderid int
-		var ordertype string
-		var ts string
-		var btc string
-		var yen string
-		var item string
-		if err := stmt.Scan(&id, &orderid, &ordertype, &ts, &btc, &yen, &item); err != nil {
-			return orders, err
-		}
-		orders = append(orders, Order{ID: id, OredrID: uint32(orderid), OrderType: ordertype, Ts: ts, Btc: btc, Yen: yen, Item: item})
-	}
-	return orders, nil
-}
-
-func DelBuyInfo(conn *sqlite3.Conn, id string) error {
-	if err := conn.Begin(); err != nil {
-		return err
-	}
-	stmt, err := conn.Prepare(`delete order_info where id = ?`)
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
-	if err := stmt.Exec(id); err != nil {
-		return err
-	}
-	if err := conn.Commit(); err != nil {
-		return err
-	}
-	return nil
-}
-
-func SaveBuyInfo(conn *sqlite3.Conn, orderid int, ordertype, btc, yen string, item *bitco.MarketItem) error {
-	guid := xid.New()
-	if err := conn.Begin(); err != nil {
-		return err
-	}
-	stmt, err := conn.Prepare(`insert into order_info values (?,?,?,?,?,?,?)`)
-	if err != nil {
-		return err
-	}
-	defer stmt.Close()
-	tm := time.Now()
+// SaveOrderInfo records an order together with the order response.
+func SaveOrderInfo(store bitco.Store, orderid int, ordertype, btc, yen string, item *bitco.MarketItem) error {
 	bstr, err := json.Marshal(item)
 	if err != nil {
 		return err
 	}
-	if err := stmt.Exec(guid.String(), orderid, ordertype, tm.Format("2006-01-02 15:04:05"), btc, yen, string(bstr)); err != nil {
-		return err
-	}
-	if err := conn.Commit(); err != nil {
-		return err
-	}
-	return nil
+	return store.SaveOrder(bitco.StoredOrder{
+		ID:        xid.New().String(),
+		OrderID:   uint64(orderid),
+		OrderType: ordertype,
+		Time:      time.Now(),
+		Btc:       btc,
+		Yen:       yen,
+		Item:      string(bstr),
+		Pair:      bitco.Btcjpy.String(),
+		Status:    "open",
+		Fee:       "0",
+	})
 }
 
 func BuyRateBtc(conn *grpc.ClientConn, value string) (*bitco.ExchangeOrdersRateItem, error) {
@@ -283,7 +182,7 @@ func debugJson(v interface{}) {
 }
 
 func TotalAssets(addr string, debug bool) {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := dial(addr)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -335,7 +234,7 @@ func humanizeYen(yen string) string {
 }
 
 func SuggestBuy(addr string, debug bool) {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := dial(addr)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -362,7 +261,7 @@ func SuggestBuy(addr string, debug bool) {
 }
 
 func SuggestSell(addr string, debug bool) {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := dial(addr)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -403,7 +302,7 @@ func ExchangeOrdersOpens(conn *grpc.ClientConn) (*bitco.OrdersOpensItem, error)
 }
 
 func Pendings(addr string, debug bool) {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := dial(addr)
 	if err != nil {
 		log.Printf("did not connect: %v\n", err)
 		return
@@ -417,7 +316,7 @@ func Pendings(addr string, debug bool) {
 	fmt.Println("== 未決済一覧 ==")
 	for _, item := range items.Orders {
 		fmt.Printf("ID: %d\n", item.Id)
-		fmt.Printf("売買: %d\n", item.OrderType)
+		fmt.Printf("売買: %s\n", item.OrderType)
 		fmt.Printf("レート: %d\n", item.Rate)
 		fmt.Printf("量: %s\n", item.PendingAmount)
 		fmt.Println()
@@ -436,14 +335,14 @@ func DeleteExchangeOrder(conn *grpc.ClientConn, id uint32) (uint32, error) {
 	return item.Id, nil
 }
 
-func CancelOrder(sqlcon *sqlite3.Conn, addr string, debug bool) {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
+func CancelOrder(store bitco.Store, addr string, debug bool) {
+	conn, err := dial(addr)
 	if err != nil {
 		log.Printf("did not connect: %v\n", err)
 		return
 	}
 	defer conn.Close()
-	orders, err := FindBuyList(sqlcon)
+	orders, err := store.Orders()
 	if err != nil {
 		log.Println("find buy list error:", err)
 		return
@@ -453,7 +352,7 @@ func CancelOrder(sqlcon *sqlite3.Conn, addr string, debug bool) {
 		fmt.Println("注文はありません")
 		return
 	}
-	id, err := DeleteExchangeOrder(conn, orders[0].OredrID)
+	id, err := DeleteExchangeOrder(conn, uint32(orders[0].OrderID))
 	if err != nil {
 		fmt.Println("注文キャンセルエラー:", err)
 		return
@@ -463,25 +362,95 @@ func CancelOrder(sqlcon *sqlite3.Conn, addr string, debug bool) {
 
 }
 
-func LimitBuy(conn *grpc.ClientConn, in *bitco.LimitOrderParams) (*bitco.MarketItem, error) {
-	c := bitco.NewCoincheckClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
+// orderRetryFor How long an order whose outcome is not known is resubmitted
+// with its client order ID.
+const orderRetryFor = 2 * time.Minute
 
-	item, err := c.LimitBuy(ctx, in)
+// submitOrder calls an order RPC, and again with the same client order ID
+// while the outcome is not known, e.g. after a timeout. The server places
+// the order once and answers the retries with its outcome.
+func submitOrder(clientID string, call func(ctx context.Context) (*bitco.MarketItem, error)) (*bitco.MarketItem, error) {
+	deadline := time.Now().Add(orderRetryFor)
+	wait := time.Second
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		item, err := call(ctx)
+		cancel()
+		code := status.Code(err)
+		if code != codes.DeadlineExceeded && code != codes.Unavailable {
+			return item, err
+		}
+		if time.Now().Add(wait).After(deadline) {
+			return item, fmt.Errorf("outcome of order %s still not known: %v", clientID, err)
+		}
+		log.Printf("order %s: %v, retrying in %s\n", clientID, err, wait)
+		time.Sleep(wait)
+		if wait < 10*time.Second {
+			wait *= 2
+		}
+	}
+}
+
+// settlePosition updates the status of the stored sell orders from the order
+// tracking of the server, and returns the stored orders left. A filled sell
+// closes the position: it is recorded in the trade history and the orders of
+// the position are deleted. A cancelled sell is deleted, so that the position
+// can be sold again.
+func settlePosition(store bitco.Store, conn *grpc.ClientConn) ([]bitco.StoredOrder, error) {
+	orders, err := store.Orders()
 	if err != nil {
-		return item, err
+		return orders, err
 	}
-	return item, nil
+	c := bitco.NewCoincheckClient(conn)
+	for _, order := range orders {
+		if order.OrderType != bitco.Sell.String() {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		item, err := c.GetOrder(ctx, &bitco.GetOrderParam{Id: order.OrderID})
+		cancel()
+		switch {
+		case err == nil:
+			order.Status = item.Status
+		case status.Code(err) != codes.NotFound:
+			return orders, err
+		}
+		switch order.Status {
+		case bitco.OrderFilled:
+			if err := SaveTradeHist(store, order.Btc, order.Yen); err != nil {
+				return orders, err
+			}
+			for _, o := range orders {
+				if err := store.DeleteOrder(o.ID); err != nil {
+					return orders, err
+				}
+			}
+			fmt.Printf("売り注文 %d が約定しました\n", order.OrderID)
+			return store.Orders()
+		case bitco.OrderCancelled:
+			if err := store.DeleteOrder(order.ID); err != nil {
+				return orders, err
+			}
+			fmt.Printf("売り注文 %d は取消されました\n", order.OrderID)
+		}
+	}
+	return store.Orders()
 }
 
-func BuyOrder(sqlcon *sqlite3.Conn, addr string, actual bool) {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
+func LimitBuy(conn *grpc.ClientConn, in *bitco.LimitOrderParams) (*bitco.MarketItem, error) {
+	c := bitco.NewCoincheckClient(conn)
+	return submitOrder(in.ClientOrderId, func(ctx context.Context) (*bitco.MarketItem, error) {
+		return c.LimitBuy(ctx, in)
+	})
+}
+
+func BuyOrder(store bitco.Store, addr string, actual bool) {
+	conn, err := dial(addr)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
 	defer conn.Close()
-	orders, err := FindBuyList(sqlcon)
+	orders, err := settlePosition(store, conn)
 	if err != nil {
 		log.Println("find buy list error:", err)
 		return
@@ -492,7 +461,7 @@ func BuyOrder(sqlcon *sqlite3.Conn, addr string, actual bool) {
 		item := orders[0]
 		// fmt.Println(item)
 		//fmt.Printf("買値: %s 円(1btc)\n", humanizeYen(item.Rate))
-		fmt.Printf("注文番号:%d\n", item.OredrID)
+		fmt.Printf("注文番号:%d\n", item.OrderID)
 		fmt.Printf("%s円 : %sbtc\n", humanizeYen(item.Yen), item.Btc)
 		fmt.Println()
 		return
@@ -529,30 +498,23 @@ func BuyOrder(sqlcon *sqlite3.Conn, addr string, actual bool) {
 	in.Pair = bitco.Btcjpy.String()
 	in.Rate = buyrate.Rate
 	in.Amount = buyrate.Amount
+	in.ClientOrderId = xid.New().String()
 	// debugJson(in)
-	var item *bitco.MarketItem
-	if actual {
-		// LimitBuy
-		item, err = LimitBuy(conn, &in)
-		if err != nil {
-			log.Println("limit buy error:", err)
-			return
-		}
-	} else {
-		now := time.Now()
-		item = &bitco.MarketItem{
-			Success:      "true",
-			Id:           uint64(now.Unix()),
-			Rate:         buyrate.Rate,
-			Amount:       buyrate.Amount,
-			OrderType:    bitco.Buy.String(),
-			StopLossRate: "",
-			Pair:         bitco.Btcjpy.String(),
-			CreatedAt:    now.Format("2006-01-02 15:04:05"),
-		}
+	if !actual {
+		fmt.Println("注文しません (-actual で注文)")
+		fmt.Printf("レート: %s 円(1btc)\n", humanizeYen(salesrate.Rate))
+		fmt.Printf("買値: %s 円(1btc)\n", humanizeYen(in.Rate))
+		fmt.Printf("%s円 : %sbtc\n", humanizeYen(buyrate.Price), in.Amount)
+		fmt.Println()
+		return
+	}
+	item, err := LimitBuy(conn, &in)
+	if err != nil {
+		log.Println("limit buy error:", err)
+		return
 	}
 
-	if err := SaveBuyInfo(sqlcon, int(item.Id), item.OrderType, item.Amount, buyrate.Price, item); err != nil {
+	if err := SaveOrderInfo(store, int(item.Id), item.OrderType, item.Amount, buyrate.Price, item); err != nil {
 		log.Println("save buy info error:", err)
 		return
 	}
@@ -565,18 +527,19 @@ func BuyOrder(sqlcon *sqlite3.Conn, addr string, actual bool) {
 
 func LimitSell(conn *grpc.ClientConn, in *bitco.LimitOrderParams) (*bitco.MarketItem, error) {
 	c := bitco.NewCoincheckClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
+	return submitOrder(in.ClientOrderId, func(ctx context.Context) (*bitco.MarketItem, error) {
+		return c.LimitSell(ctx, in)
+	})
+}
 
-	item, err := c.LimitSell(ctx, in)
+func SellOrder(store bitco.Store, addr string, actual bool) {
+	conn, err := dial(addr)
 	if err != nil {
-		return item, err
+		log.Fatalf("did not connect: %v", err)
+		return
 	}
-	return item, nil
-}
-
-func SellOrder(sqlcon *sqlite3.Conn, addr string, actual bool) {
-	orders, err := FindBuyList(sqlcon)
+	defer conn.Close()
+	orders, err := settlePosition(store, conn)
 	if err != nil {
 		log.Println("find buy list error:", err)
 		return
@@ -586,12 +549,15 @@ func SellOrder(sqlcon *sqlite3.Conn, addr string, actual bool) {
 		fmt.Println("ポジションはありません")
 		return
 	}
-	conn, err := grpc.Dial(addr, grpc.WithInsecure(), grpc.WithBlock())
-	if err != nil {
-		log.Fatalf("did not connect: %v", err)
-		return
+	// The sell orders left are still open.
+	for _, order := range orders {
+		if order.OrderType == bitco.Sell.String() {
+			fmt.Println("すでに売り注文済みです")
+			fmt.Printf("注文番号:%d %s\n", order.OrderID, order.Status)
+			fmt.Println()
+			return
+		}
 	}
-	defer conn.Close()
 	salesrate, err := SalesRate(conn)
 	if err != nil {
 		log.Println("sales rate error:", err)
@@ -608,27 +574,24 @@ func SellOrder(sqlcon *sqlite3.Conn, addr string, actual bool) {
 	in.Pair = bitco.Btcjpy.String()
 	in.Rate = sellrate.Rate
 	in.Amount = sellrate.Amount
+	in.ClientOrderId = xid.New().String()
 
-	var item *bitco.MarketItem
-	if actual {
-		// LimitSell
-		item, err = LimitSell(conn, &in)
-		if err != nil {
-			log.Println("limit sell error:", err)
-			return
-		}
-	} else {
-		now := time.Now()
-		item = &bitco.MarketItem{
-			Success:      "true",
-			Id:           uint64(now.Unix()),
-			Rate:         sellrate.Rate,
-			Amount:       sellrate.Amount,
-			OrderType:    bitco.Sell.String(),
-			StopLossRate: "",
-			Pair:         bitco.Btcjpy.String(),
-			CreatedAt:    now.Format("2006-01-02 15:04:05"),
-		}
+	if !actual {
+		fmt.Println("注文しません (-actual で注文)")
+		fmt.Printf("レート: %s 円(1btc)\n", humanizeYen(salesrate.Rate))
+		fmt.Printf("値: %s 円(1btc)\n", humanizeYen(in.Rate))
+		fmt.Printf("%s円 : %sbtc\n", humanizeYen(sellrate.Price), in.Amount)
+		fmt.Println()
+		return
+	}
+	item, err := LimitSell(conn, &in)
+	if err != nil {
+		log.Println("limit sell error:", err)
+		return
+	}
+	if err := SaveOrderInfo(store, int(item.Id), item.OrderType, item.Amount, sellrate.Price, item); err != nil {
+		log.Println("save sell info error:", err)
+		return
 	}
 
 	// debugJson(item)
@@ -638,15 +601,229 @@ func SellOrder(sqlcon *sqlite3.Conn, addr string, actual bool) {
 	fmt.Println()
 }
 
+func printHalt(item *bitco.HaltItem) {
+	if !item.Halted {
+		fmt.Println("取引中")
+		return
+	}
+	fmt.Println("取引停止中")
+	fmt.Printf("理由: %s\n", item.Reason)
+	fmt.Printf("停止者: %s\n", item.By)
+	fmt.Printf("停止日時: %s\n", time.Unix(int64(item.Since), 0).Format("2006-01-02 15:04:05"))
+	for _, id := range item.Cancelled {
+		fmt.Printf("キャンセル済み: %d\n", id)
+	}
+	for _, id := range item.Failed {
+		fmt.Printf("キャンセル失敗: %d\n", id)
+	}
+}
+
+// Halt halts trading on the server, until Resume.
+func Halt(addr, reason string, cancelOrders bool) {
+	if reason == "" {
+		log.Println("halt needs -reason")
+		return
+	}
+	conn, err := dial(addr)
+	if err != nil {
+		log.Printf("did not connect: %v\n", err)
+		return
+	}
+	defer conn.Close()
+	c := bitco.NewCoincheckClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	item, err := c.Halt(ctx, &bitco.HaltParam{Reason: reason, CancelOrders: cancelOrders})
+	if err != nil {
+		log.Println("halt error:", err)
+		return
+	}
+	fmt.Println("== 取引停止 ==")
+	printHalt(item)
+}
+
+// Resume resumes trading on the server.
+func Resume(addr string) {
+	conn, err := dial(addr)
+	if err != nil {
+		log.Printf("did not connect: %v\n", err)
+		return
+	}
+	defer conn.Close()
+	c := bitco.NewCoincheckClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	item, err := c.Resume(ctx, &bitco.Empty{})
+	if err != nil {
+		log.Println("resume error:", err)
+		return
+	}
+	fmt.Println("== 取引再開 ==")
+	printHalt(item)
+}
+
+// HaltStatus shows whether trading is halted.
+func HaltStatus(addr string) {
+	conn, err := dial(addr)
+	if err != nil {
+		log.Printf("did not connect: %v\n", err)
+		return
+	}
+	defer conn.Close()
+	c := bitco.NewCoincheckClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	item, err := c.HaltStatus(ctx, &bitco.Empty{})
+	if err != nil {
+		log.Println("halt status error:", err)
+		return
+	}
+	fmt.Println("== 取引状態 ==")
+	printHalt(item)
+}
+
+// Orders updates the status of the stored orders from the order tracking of
+// the server and shows them.
+func Orders(store bitco.Store, addr string) {
+	orders, err := store.Orders()
+	if err != nil {
+		log.Println("find order list error:", err)
+		return
+	}
+	conn, err := dial(addr)
+	if err != nil {
+		log.Printf("did not connect: %v\n", err)
+		return
+	}
+	defer conn.Close()
+	c := bitco.NewCoincheckClient(conn)
+	fmt.Println("== 注文状況 ==")
+	for _, order := range orders {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		item, err := c.GetOrder(ctx, &bitco.GetOrderParam{Id: order.OrderID})
+		cancel()
+		if status.Code(err) == codes.NotFound {
+			fmt.Printf("注文番号:%d %s (追跡なし)\n", order.OrderID, order.Status)
+			continue
+		}
+		if err != nil {
+			log.Println("get order error:", err)
+			return
+		}
+		if item.Status != order.Status {
+			order.Status = item.Status
+			if err := store.SaveOrder(order); err != nil {
+				log.Println("save order error:", err)
+				return
+			}
+		}
+		fmt.Printf("注文番号:%d %s %s 約定: %s/%s\n", order.OrderID, order.OrderType, item.Status, item.Filled, item.Amount)
+	}
+}
+
+// readCursor returns the last order event received, 0 when there is none.
+func readCursor(path string) uint64 {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	after, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		log.Printf("cursor %s ignored: %v\n", path, err)
+		return 0
+	}
+	return after
+}
+
+var eventNames = map[string]string{
+	"accepted":         "受付",
+	"open":             "注文中",
+	"partially_filled": "一部約定",
+	"filled":           "約定",
+	"cancelled":        "取消",
+	"rejected":         "拒否",
+}
+
+// followOrderEvents shows the order events after the cursor until the
+// stream breaks, updating the stored orders and the cursor file.
+func followOrderEvents(store bitco.Store, addr, cursorPath string, after *uint64) (received bool, err error) {
+	conn, err := dial(addr)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	c := bitco.NewCoincheckClient(conn)
+	stream, err := c.SubscribeOrderEvents(context.Background(), &bitco.OrderEventsParam{After: *after})
+	if err != nil {
+		return false, err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+		fmt.Printf("[%s] 注文番号:%d %s %s 約定: %s/%s %s\n", eventNames[event.Type], event.OrderId, event.ClientOrderId,
+			event.OrderType, event.Filled, event.Amount, event.Reason)
+		if event.OrderId != 0 {
+			if err := updateOrderStatus(store, event); err != nil {
+				log.Println("save order error:", err)
+			}
+		}
+		*after = event.Seq
+		if err := ioutil.WriteFile(cursorPath, []byte(strconv.FormatUint(event.Seq, 10)), 0644); err != nil {
+			log.Println("cursor save error:", err)
+		}
+	}
+}
+
+// updateOrderStatus sets the status of the stored order of an event.
+func updateOrderStatus(store bitco.Store, event *bitco.OrderEvent) error {
+	orders, err := store.Orders()
+	if err != nil {
+		return err
+	}
+	status := event.Type
+	if status == "accepted" {
+		status = "new"
+	}
+	for _, order := range orders {
+		if order.OrderID == event.OrderId && order.Status != status {
+			order.Status = status
+			return store.SaveOrder(order)
+		}
+	}
+	return nil
+}
+
+// WatchOrderEvents shows the order events of the server as they happen, and
+// resumes after the last one received when the connection breaks.
+func WatchOrderEvents(store bitco.Store, addr, cursorPath string) {
+	after := readCursor(cursorPath)
+	fmt.Println("== 注文イベント ==")
+	wait := time.Second
+	for {
+		received, err := followOrderEvents(store, addr, cursorPath, &after)
+		if received {
+			wait = time.Second
+		}
+		log.Printf("order events: %v, resuming after %d in %s\n", err, after, wait)
+		time.Sleep(wait)
+		if wait < 30*time.Second {
+			wait *= 2
+		}
+	}
+}
+
 func main() {
 	flag.Parse()
-	conn, err := sqlite3.Open(*dbFile)
-	if err != nil {
-		log.Printf("sqlite3 connection error: %v\n", err)
-		os.Exit(0)
+	storeConf := bitco.StoreConfig{Driver: *storeDriver, DSN: *dbFile}
+	if *storeDriver == "postgres" {
+		storeConf.DSN = *storeDSN
 	}
-	if err := createSQL(conn); err != nil {
-		log.Printf("create sql error: %v\n", err)
+	store, err := bitco.OpenStore(storeConf, bitco.OrderMigrations)
+	if err != nil {
+		log.Printf("store open error: %v\n", err)
 		os.Exit(0)
 	}
 	switch *commandName {
@@ -659,14 +836,26 @@ func main() {
 	case "pending":
 		Pendings(*addr, *debugMode)
 	case "cancel":
-		CancelOrder(conn, *addr, *debugMode)
+		CancelOrder(store, *addr, *debugMode)
 	case "limitbuy":
-		BuyOrder(conn, *addr, *actualMode)
+		BuyOrder(store, *addr, *actualMode)
 	case "limitsell":
-		SellOrder(conn, *addr, *actualMode)
+		SellOrder(store, *addr, *actualMode)
+	case "orders":
+		Orders(store, *addr)
+	case "events":
+		WatchOrderEvents(store, *addr, *cursorFile)
+	case "halt":
+		Halt(*addr, *haltReason, *cancelOrders)
+	case "resume":
+		Resume(*addr)
+	case "haltstatus":
+		HaltStatus(*addr)
 	default:
 		log.Printf("command not found: %s\n", *commandName)
-		os.Exit(0)
+	}
+	if err := store.Close(); err != nil {
+		log.Printf("store close error: %v\n", err)
 	}
 	os.Exit(0)
 }
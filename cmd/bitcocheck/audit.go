@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// auditedMethods are the RPCs that place or cancel orders or halt trading. A
+// withdrawal RPC belongs here too once there is one.
+var auditedMethods = map[string]bool{
+	"MarketBuy":              true,
+	"MarketSell":             true,
+	"LimitBuy":               true,
+	"LimitSell":              true,
+	"DeleteExchangeOrder":    true,
+	"PlaceConditionalOrder":  true,
+	"PlaceOCOOrder":          true,
+	"CancelConditionalOrder": true,
+	"AddRecurringOrder":      true,
+	"CancelRecurringOrder":   true,
+	"Halt":                   true,
+	"Resume":                 true,
+}
+
+var auditLog *bitco.AuditLog
+
+type recorderKey struct{}
+
+// upstreamRecorder collects the Coincheck requests of one audited RPC.
+type upstreamRecorder struct {
+	mu       sync.Mutex
+	requests []bitco.AuditRequest
+}
+
+func (r *upstreamRecorder) record(e bitco.RequestEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, bitco.NewAuditRequest(e))
+}
+
+// callConf returns the config for the Coincheck requests of an RPC, which
+// traces them in the span of the RPC and records them when it is audited.
+func callConf(ctx context.Context) bitco.Config {
+	c := conf.WithContext(ctx)
+	if rec, ok := ctx.Value(recorderKey{}).(*upstreamRecorder); ok {
+		return c.WithRecorder(rec.record)
+	}
+	return c
+}
+
+func auditJSON(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return bitco.RedactJSON(b)
+}
+
+// auditUnary writes an audit entry for every call of an audited RPC, with
+// its caller, parameters, Coincheck requests and outcome.
+func auditUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	name := strings.TrimPrefix(info.FullMethod, "/"+coincheckService+"/")
+	if auditLog == nil || !auditedMethods[name] {
+		return handler(ctx, req)
+	}
+	rec := &upstreamRecorder{}
+	resp, err := handler(context.WithValue(ctx, recorderKey{}, rec), req)
+	entry := bitco.AuditEntry{
+		Caller: "anonymous",
+		Method: name,
+		Params: auditJSON(req),
+	}
+	if id, ok := callerIdentity(ctx); ok {
+		entry.Caller, entry.Role = id.Name, id.Role.String()
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		entry.Peer = p.Addr.String()
+	}
+	rec.mu.Lock()
+	entry.Upstream = rec.requests
+	rec.mu.Unlock()
+	if err != nil {
+		entry.Outcome, entry.Error = bitco.AuditError, err.Error()
+	} else {
+		entry.Outcome, entry.Result = bitco.AuditOK, auditJSON(resp)
+	}
+	if aerr := auditLog.Append(entry); aerr != nil {
+		log.Printf("audit log error, %s by %s is not recorded: %v\n", name, entry.Caller, aerr)
+	}
+	return resp, err
+}
+
+// auditJob writes the audit entry of an action of a server job, such as a
+// risk halt or an order of a conditional or recurring order, with the
+// Coincheck requests rec recorded and the outcome.
+func auditJob(entry bitco.AuditEntry, rec *upstreamRecorder, result interface{}, err error) {
+	rec.mu.Lock()
+	entry.Upstream = rec.requests
+	rec.mu.Unlock()
+	if err != nil {
+		entry.Outcome, entry.Error = bitco.AuditError, err.Error()
+	} else {
+		entry.Outcome, entry.Result = bitco.AuditOK, auditJSON(result)
+	}
+	if aerr := auditLog.Append(entry); aerr != nil {
+		log.Printf("audit log error, %s by %s is not recorded: %v\n", entry.Method, entry.Caller, aerr)
+	}
+}
+
+// verifyAudit checks the hash chain of an audit log file.
+func verifyAudit(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	n, err := bitco.VerifyAuditLog(f)
+	if err != nil {
+		return fmt.Errorf("audit log %s does not verify after %d entries: %v", name, n, err)
+	}
+	log.Printf("audit log %s verified, %d entries\n", name, n)
+	return nil
+}
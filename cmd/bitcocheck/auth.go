@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// methodRoles is the role each Coincheck RPC needs. An RPC that is not listed
+// needs RoleAdmin, so a new RPC is closed until it is given a role here.
+var methodRoles = map[string]bitco.Role{
+	"Ticker":                     bitco.RoleRead,
+	"TickerHist":                 bitco.RoleRead,
+	"TickerGaps":                 bitco.RoleRead,
+	"Trades":                     bitco.RoleRead,
+	"Candles":                    bitco.RoleRead,
+	"Export":                     bitco.RoleRead,
+	"OrderBooks":                 bitco.RoleRead,
+	"OrderBooksV2":               bitco.RoleRead,
+	"ExchangeOrdersRate":         bitco.RoleRead,
+	"RatePair":                   bitco.RoleRead,
+	"MarketBuy":                  bitco.RoleTrade,
+	"MarketSell":                 bitco.RoleTrade,
+	"LimitBuy":                   bitco.RoleTrade,
+	"LimitSell":                  bitco.RoleTrade,
+	"ExchangeOrdersOpens":        bitco.RoleTrade,
+	"DeleteExchangeOrder":        bitco.RoleTrade,
+	"ExchangeOrdersTransactions": bitco.RoleTrade,
+	"AccountsBalance":            bitco.RoleTrade,
+	"Accounts":                   bitco.RoleTrade,
+	"HaltStatus":                 bitco.RoleRead,
+	"Halt":                       bitco.RoleAdmin,
+	"Resume":                     bitco.RoleAdmin,
+	"GetOrder":                   bitco.RoleRead,
+	"ListOrders":                 bitco.RoleRead,
+	"SubscribeOrderEvents":       bitco.RoleRead,
+	"PlaceConditionalOrder":      bitco.RoleTrade,
+	"PlaceOCOOrder":              bitco.RoleTrade,
+	"CancelConditionalOrder":     bitco.RoleTrade,
+	"ListConditionalOrders":      bitco.RoleRead,
+	"AddRecurringOrder":          bitco.RoleTrade,
+	"CancelRecurringOrder":       bitco.RoleTrade,
+	"ListRecurringOrders":        bitco.RoleRead,
+	"RecurringOrderRuns":         bitco.RoleRead,
+}
+
+// methodRole returns the role a full method name needs. Health checks are
+// open to anyone; reflection needs RoleRead.
+func methodRole(fullMethod string) bitco.Role {
+	if strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") {
+		return bitco.RoleNone
+	}
+	if strings.HasPrefix(fullMethod, "/grpc.reflection.") {
+		return bitco.RoleRead
+	}
+	if name := strings.TrimPrefix(fullMethod, "/"+coincheckService+"/"); name != fullMethod {
+		if role, ok := methodRoles[name]; ok {
+			return role
+		}
+	}
+	return bitco.RoleAdmin
+}
+
+type identityKey struct{}
+
+// callerIdentity returns the authenticated caller of an RPC. ok is false when
+// authentication is disabled.
+func callerIdentity(ctx context.Context) (id bitco.Identity, ok bool) {
+	id, ok = ctx.Value(identityKey{}).(bitco.Identity)
+	return id, ok
+}
+
+// requireRole checks a role an RPC needs beyond that of its method, for
+// example for one of its datasets.
+func requireRole(ctx context.Context, method string, need bitco.Role) error {
+	id, ok := callerIdentity(ctx)
+	if !ok || id.Role.Allows(need) {
+		return nil
+	}
+	log.Printf("auth denied %s to %s (%s): needs %s\n", method, id.Name, id.Role, need)
+	return status.Errorf(codes.PermissionDenied, "%s needs the %s role", method, need)
+}
+
+// authInterceptor authenticates the "authorization: Bearer" token of every
+// RPC and checks the role of the method.
+type authInterceptor struct {
+	auth *bitco.Authenticator
+}
+
+func (a authInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	need := methodRole(method)
+	if need == bitco.RoleNone {
+		return ctx, nil
+	}
+	caller := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		caller = p.Addr.String()
+	}
+	token := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get("authorization") {
+			if strings.HasPrefix(v, "Bearer ") {
+				token = strings.TrimPrefix(v, "Bearer ")
+			}
+		}
+	}
+	id, err := a.auth.Authenticate(token)
+	if err != nil {
+		log.Printf("auth denied %s from %s: %v\n", method, caller, err)
+		return ctx, status.Error(codes.Unauthenticated, "invalid or missing token")
+	}
+	if !id.Role.Allows(need) {
+		log.Printf("auth denied %s to %s (%s) from %s: needs %s\n", method, id.Name, id.Role, caller, need)
+		return ctx, status.Errorf(codes.PermissionDenied, "%s needs the %s role", method, need)
+	}
+	return context.WithValue(ctx, identityKey{}, id), nil
+}
+
+func (a authInterceptor) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// ctxStream is a stream with the context given by an interceptor.
+type ctxStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s ctxStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a authInterceptor) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, ctxStream{ServerStream: ss, ctx: ctx})
+}
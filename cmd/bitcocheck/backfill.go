@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/xid"
+)
+
+const (
+	gapBackfilled    = "backfilled"
+	gapIrrecoverable = "irrecoverable"
+)
+
+// maxBackfillPages bounds how far back /api/trades is paged for one gap.
+const maxBackfillPages = 100
+
+// maxBackfillAge The oldest end of a gap that is backfilled. /api/trades
+// pages do not reach older trades, so older gaps are marked irrecoverable
+// without a request.
+const maxBackfillAge = 24 * time.Hour
+
+// gapChecked The time of the newest tick of each pair already checked for
+// gaps. The first check after a start scans the whole history, so that the
+// holes of a downtime or an import are found; the later ones only the new
+// ticks.
+var gapChecked = map[string]time.Time{}
+
+// scheduleInterval returns the time between two runs of a cron spec.
+func scheduleInterval(spec string) (time.Duration, error) {
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		return 0, err
+	}
+	next := sched.Next(time.Now())
+	return sched.Next(next).Sub(next), nil
+}
+
+// loadTickTimes returns the times of the ticks of a pair from a time on.
+func loadTickTimes(store bitco.Store, pair string, from time.Time) ([]time.Time, error) {
+	times := []time.Time{}
+	err := store.ScanTicks(bitco.TickQuery{Pair: pair, From: from}, func(tick bitco.Tick) bool {
+		if n := len(times); n == 0 || !times[n-1].Equal(tick.Time) {
+			times = append(times, tick.Time)
+		}
+		return true
+	})
+	return times, err
+}
+
+// backfillTrades pages /api/trades back to the start of the gap and returns
+// the trades inside it. complete is false when the exchange no longer has the
+// trades of the whole gap.
+func backfillTrades(conf bitco.Config, pair bitco.Pair, gap bitco.Gap) (trades []*bitco.TradeData, complete bool, err error) {
+	trades = []*bitco.TradeData{}
+	page := bitco.Pagenation{Limit: 100, Order: "desc"}
+	for i := 0; i < maxBackfillPages; i++ {
+		item, err := bitco.TradesPagecc(conf, pair, page)
+		if err != nil {
+			return trades, false, err
+		}
+		if len(item.Data) == 0 {
+			return trades, false, nil
+		}
+		for _, trade := range item.Data {
+			tm, err := bitco.TradeTime(trade)
+			if err != nil {
+				return trades, false, err
+			}
+			if tm.Before(gap.From) {
+				return trades, true, nil
+			}
+			if tm.Before(gap.To) {
+				trades = append(trades, trade)
+			}
+		}
+		page.StartingAfter = strconv.FormatUint(uint64(item.Data[len(item.Data)-1].ID), 10)
+	}
+	return trades, false, nil
+}
+
+// gapJob records the holes of the ticker history that are longer than two
+// collection intervals and backfills their trades and candles where the
+// exchange still has them. A recorded gap is not looked at again. It runs
+// under jobMu.
+func gapJob(store bitco.Store, conf bitco.Config) error {
+	interval, err := scheduleInterval(conf.Ticker.CollectSpec())
+	if err != nil {
+		return err
+	}
+	pairs, err := conf.Ticker.PairList()
+	if err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		times, err := loadTickTimes(store, pair.String(), gapChecked[pair.String()])
+		if err != nil {
+			return err
+		}
+		for _, gap := range bitco.FindGaps(times, 2*interval) {
+			known, err := store.HasGap(pair.String(), gap.From)
+			if err != nil {
+				return err
+			}
+			if known {
+				continue
+			}
+			if time.Since(gap.To) > maxBackfillAge {
+				record := bitco.GapRecord{Pair: pair.String(), From: gap.From, To: gap.To, Status: gapIrrecoverable}
+				if err := store.SaveGap(record); err != nil {
+					return err
+				}
+				continue
+			}
+			trades, complete, err := backfillTrades(conf, pair, gap)
+			if err != nil {
+				return fmt.Errorf("%s: %v", pair, err)
+			}
+			if len(trades) > 0 {
+				if err := store.SaveTrades(pair.String(), trades); err != nil {
+					return err
+				}
+				if err := updateCandles(store, conf, pair.String(), gap.From); err != nil {
+					return err
+				}
+			}
+			state := gapIrrecoverable
+			if complete {
+				state = gapBackfilled
+			}
+			record := bitco.GapRecord{Pair: pair.String(), From: gap.From, To: gap.To, Status: state, Trades: len(trades)}
+			if err := store.SaveGap(record); err != nil {
+				return err
+			}
+		}
+		if n := len(times); n > 0 {
+			gapChecked[pair.String()] = times[n-1]
+		}
+	}
+	return nil
+}
+
+func importTrades(store bitco.Store, conf bitco.Config, f *os.File) (int, error) {
+	trades, err := bitco.ReadTradesCSV(f)
+	if err != nil {
+		return 0, err
+	}
+	byPair := map[bitco.Pair][]*bitco.TradeData{}
+	since := map[bitco.Pair]time.Time{}
+	for _, trade := range trades {
+		pair, err := bitco.ParsePair(trade.Pair)
+		if err != nil {
+			return 0, err
+		}
+		tm, err := bitco.TradeTime(trade)
+		if err != nil {
+			return 0, err
+		}
+		if s, ok := since[pair]; !ok || tm.Before(s) {
+			since[pair] = tm
+		}
+		byPair[pair] = append(byPair[pair], trade)
+	}
+	for pair, trades := range byPair {
+		if err := store.SaveTrades(pair.String(), trades); err != nil {
+			return 0, err
+		}
+		if err := updateCandles(store, conf, pair.String(), since[pair]); err != nil {
+			return 0, err
+		}
+	}
+	return len(trades), nil
+}
+
+// importTicks adds the ticks that tickhist does not have yet and forgets the
+// recorded gaps they fall into, so the next gap check looks at them again.
+func importTicks(store bitco.Store, f *os.File) (int, error) {
+	records, err := bitco.ReadTicksCSV(f)
+	if err != nil {
+		return 0, err
+	}
+	ticks := []bitco.Tick{}
+	for _, r := range records {
+		if _, err := bitco.ParsePair(r.Pair); err != nil {
+			return 0, err
+		}
+		ticks = append(ticks, bitco.Tick{
+			ID:     xid.New().String(),
+			Pair:   r.Pair,
+			Time:   time.Unix(r.Timestamp, 0),
+			Last:   r.Last,
+			Bid:    r.Bid,
+			Ask:    r.Ask,
+			High:   r.High,
+			Low:    r.Low,
+			Volume: r.Volume,
+		})
+	}
+	n, err := store.AddTicks(ticks)
+	if err != nil {
+		return n, err
+	}
+	for _, tick := range ticks {
+		if err := store.ForgetGaps(tick.Pair, tick.Time); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// importFile loads a CSV dump of the given type, "trades" or "ticks".
+func importFile(store bitco.Store, conf bitco.Config, name, kind string) (int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	switch kind {
+	case "trades":
+		return importTrades(store, conf, f)
+	case "ticks":
+		return importTicks(store, f)
+	}
+	return 0, fmt.Errorf("unknown import type: %s", kind)
+}
+
+func (s server) TickerGaps(ctx context.Context, in *bitco.TickerGapsParam) (*bitco.TickerGapsItem, error) {
+	var item bitco.TickerGapsItem
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
+	}
+	var from, to time.Time
+	if in.From > 0 {
+		from = time.Unix(int64(in.From), 0)
+	}
+	if in.To > 0 {
+		to = time.Unix(int64(in.To), 0)
+	}
+	gaps, err := store.Gaps(pair.String(), from, to)
+	if err != nil {
+		return &item, err
+	}
+	item.Pair = pair.String()
+	for _, gap := range gaps {
+		item.Gaps = append(item.Gaps, &bitco.TickerGap{
+			From:   uint64(gap.From.Unix()),
+			To:     uint64(gap.To.Unix()),
+			Status: gap.Status,
+			Trades: uint32(gap.Trades),
+		})
+	}
+	return &item, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTradePages bounds how far one poll pages back to catch up on missed trades.
+const maxTradePages = 10
+
+// pollTrades returns the trades newer than lastID, paging back while a whole
+// page is new.
+func pollTrades(conf bitco.Config, pair bitco.Pair, lastID uint32) ([]*bitco.TradeData, error) {
+	trades := []*bitco.TradeData{}
+	page := bitco.Pagenation{Limit: 100, Order: "desc"}
+	for i := 0; i < maxTradePages; i++ {
+		item, err := bitco.TradesPagecc(conf, pair, page)
+		if err != nil {
+			return trades, err
+		}
+		if len(item.Data) == 0 {
+			break
+		}
+		for _, trade := range item.Data {
+			if trade.ID > lastID {
+				trades = append(trades, trade)
+			}
+		}
+		oldest := item.Data[len(item.Data)-1]
+		if lastID == 0 || oldest.ID <= lastID+1 || uint32(len(item.Data)) < page.Limit {
+			break
+		}
+		page.StartingAfter = strconv.FormatUint(uint64(oldest.ID), 10)
+	}
+	return trades, nil
+}
+
+// updateCandles rebuilds the persisted candles from the start of the bucket
+// holding the given time.
+func updateCandles(store bitco.Store, conf bitco.Config, pair string, since time.Time) error {
+	intervals, err := conf.Candles.IntervalList()
+	if err != nil {
+		return err
+	}
+	now := time.Now().Add(time.Second)
+	for _, res := range intervals {
+		trades, err := store.Trades(pair, since.Truncate(res.Interval), now)
+		if err != nil {
+			return err
+		}
+		candles, err := bitco.BuildCandles(trades, res.Interval)
+		if err != nil {
+			return err
+		}
+		if err := store.SaveCandles(pair, res.Name, candles); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// candleJob polls the new trades of every configured pair and updates the candles.
+func candleJob(store bitco.Store, conf bitco.Config) error {
+	pairs, err := conf.Ticker.PairList()
+	if err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		lastID, err := store.MaxTradeID(pair.String())
+		if err != nil {
+			return err
+		}
+		trades, err := pollTrades(conf, pair, lastID)
+		if err != nil {
+			return fmt.Errorf("%s: %v", pair, err)
+		}
+		if len(trades) == 0 {
+			continue
+		}
+		observeTrades(pair.String(), trades)
+		feedPaper(pair.String(), trades)
+		if err := store.SaveTrades(pair.String(), trades); err != nil {
+			return err
+		}
+		since := time.Now()
+		for _, trade := range trades {
+			tm, err := bitco.TradeTime(trade)
+			if err != nil {
+				return err
+			}
+			if tm.Before(since) {
+				since = tm
+			}
+		}
+		if err := updateCandles(store, conf, pair.String(), since); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// candlesBetween returns the candles of the interval in [from, to), read from
+// the candles table when the interval is collected, otherwise built from trades.
+func candlesBetween(pair string, interval time.Duration, from, to time.Time) ([]*bitco.Candle, error) {
+	intervals, err := conf.Candles.IntervalList()
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range intervals {
+		if res.Interval == interval {
+			return store.Candles(pair, res.Name, from.Truncate(interval), to)
+		}
+	}
+	trades, err := store.Trades(pair, from.Truncate(interval), to)
+	if err != nil {
+		return nil, err
+	}
+	return bitco.BuildCandles(trades, interval)
+}
+
+func (s server) Candles(ctx context.Context, in *bitco.CandlesParam) (*bitco.CandlesItem, error) {
+	var item bitco.CandlesItem
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
+	}
+	name := in.Interval
+	if name == "" {
+		name = "1m"
+	}
+	interval, err := bitco.ParseInterval(name)
+	if err != nil {
+		return &item, status.Error(codes.InvalidArgument, err.Error())
+	}
+	to := time.Now()
+	if in.To > 0 {
+		to = time.Unix(int64(in.To), 0)
+	}
+	from := to.Add(-100 * interval)
+	if in.From > 0 {
+		from = time.Unix(int64(in.From), 0)
+	}
+	candles, err := candlesBetween(pair.String(), interval, from, to)
+	if err != nil {
+		return &item, err
+	}
+	item.Pair = pair.String()
+	item.Interval = name
+	item.Candles = candles
+	return &item, nil
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"github.com/rs/xid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// condMu serializes the changes of the conditional orders.
+var condMu sync.Mutex
+
+// conditionalClientID The client order ID of the order sent by a conditional
+// order, which makes its send idempotent and its outcome reconciled.
+func conditionalClientID(o bitco.ConditionalOrder) string {
+	return "cond-" + o.ID
+}
+
+// conditionalRiskOrder returns the order a conditional order sends, for the
+// risk checks.
+func conditionalRiskOrder(o bitco.ConditionalOrder) (bitco.RiskOrder, error) {
+	pair, err := parsePair(o.Pair)
+	if err != nil {
+		return bitco.RiskOrder{}, err
+	}
+	switch t := o.OrderType(); t {
+	case bitco.MarketBuy:
+		funds, err := strconv.ParseFloat(o.Amount, 64)
+		return bitco.RiskOrder{Pair: o.Pair, Type: t, Funds: funds}, err
+	case bitco.MarketSell:
+		amount, err := strconv.ParseFloat(o.Amount, 64)
+		return bitco.RiskOrder{Pair: o.Pair, Type: t, Amount: amount}, err
+	default:
+		return limitOrder(pair, t, o.Rate, o.Amount)
+	}
+}
+
+// conditionalAudit The audit params of the order of a conditional order.
+type conditionalAudit struct {
+	ConditionalID string `json:"conditional_id"`
+	Kind          string `json:"kind"`
+	Pair          string `json:"pair"`
+	OrderType     string `json:"order_type"`
+	Trigger       string `json:"trigger_rate"`
+	Price         string `json:"price,omitempty"`
+	Rate          string `json:"rate,omitempty"`
+	Amount        string `json:"amount"`
+	ClientOrderID string `json:"client_order_id"`
+}
+
+// fireConditional sends the order of a conditional order triggered at the
+// price last and returns the conditional order with its outcome: failed when
+// the order was refused, by a halt or the risk checks too. An order whose
+// answer was lost stays triggered and is reconciled by its client order ID.
+// The send is recorded in the audit log.
+func fireConditional(o bitco.ConditionalOrder, last float64) bitco.ConditionalOrder {
+	o.ClientID = conditionalClientID(o)
+	rec := &upstreamRecorder{}
+	params := conditionalAudit{
+		ConditionalID: o.ID,
+		Kind:          o.Kind,
+		Pair:          o.Pair,
+		OrderType:     o.OrderType().String(),
+		Trigger:       strconv.FormatFloat(o.Trigger, 'f', -1, 64),
+		Rate:          o.Rate,
+		Amount:        o.Amount,
+		ClientOrderID: o.ClientID,
+	}
+	if last > 0 {
+		params.Price = strconv.FormatFloat(last, 'f', -1, 64)
+	}
+	var item bitco.MarketItem
+	var err error
+	defer func() {
+		auditJob(bitco.AuditEntry{Caller: "conditional", Method: "ConditionalOrder", Params: auditJSON(params)}, rec, &item, err)
+	}()
+	pair, err := parsePair(o.Pair)
+	if err != nil {
+		o.State, o.Error = bitco.ConditionalFailed, err.Error()
+		return o
+	}
+	ro, err := conditionalRiskOrder(o)
+	if err != nil {
+		o.State, o.Error = bitco.ConditionalFailed, err.Error()
+		return o
+	}
+	t := o.OrderType()
+	c := bitco.ClientOrder{ClientID: o.ClientID, Pair: o.Pair, OrderType: t.String(), Rate: o.Rate, Amount: o.Amount}
+	ctx := context.WithValue(context.Background(), recorderKey{}, rec)
+	item, err = placeOrder(ctx, c, ro, func(cc bitco.Config) (bitco.MarketItem, error) {
+		amount, _ := strconv.ParseFloat(o.Amount, 64)
+		switch t {
+		case bitco.MarketBuy:
+			return exchange.MarketBuy(cc, pair, uint32(amount))
+		case bitco.MarketSell:
+			return exchange.MarketSell(cc, pair, uint32(amount))
+		}
+		return exchange.LimitOrder(cc, pair, t, o.Rate, o.Amount, "")
+	})
+	switch {
+	case err == nil:
+		o.OrderID = item.Id
+	case status.Code(err) == codes.Unavailable:
+		o.Error = err.Error()
+	default:
+		o.State, o.Error = bitco.ConditionalFailed, err.Error()
+	}
+	return o
+}
+
+// settleConditionals settles the triggered conditional orders whose order
+// is not known to be placed with their client orders, and sends again the
+// orders never sent. An order triggered within pendingTimeout may be being
+// sent by another server sharing the store, and is left.
+func settleConditionals(now time.Time) error {
+	triggered, err := store.ConditionalOrders(bitco.ConditionalTriggered)
+	if err != nil {
+		return err
+	}
+	for _, o := range triggered {
+		if o.OrderID != 0 {
+			continue
+		}
+		c, found, err := store.ClientOrder(conditionalClientID(o))
+		if err != nil {
+			return err
+		}
+		next, resend := bitco.SettleConditional(o, c, found)
+		if resend {
+			if now.Sub(o.Updated) <= pendingTimeout {
+				continue
+			}
+			log.Printf("conditional order %s triggered but not sent, sending\n", o.ID)
+			next = fireConditional(o, 0)
+		}
+		if next == o {
+			continue
+		}
+		next.Updated = now
+		if err := store.SaveConditionalOrders(next); err != nil {
+			return err
+		}
+		log.Printf("conditional order %s settled: %s %d %s\n", o.ID, next.State, next.OrderID, next.Error)
+	}
+	return nil
+}
+
+// conditionalJob settles the triggered conditional orders, then reads the
+// ticker of the pairs with active conditional orders and sends the orders it
+// triggers. The triggered orders, and the orders their OCO pairs cancel, are
+// saved before the sends, so that an order is never sent twice.
+func conditionalJob(conf bitco.Config) error {
+	condMu.Lock()
+	defer condMu.Unlock()
+	if err := settleConditionals(time.Now()); err != nil {
+		return fmt.Errorf("settle: %v", err)
+	}
+	active, err := store.ConditionalOrders(bitco.ConditionalActive)
+	if err != nil || len(active) == 0 {
+		return err
+	}
+	pairs := []string{}
+	seen := map[string]bool{}
+	for _, o := range active {
+		if !seen[o.Pair] {
+			seen[o.Pair] = true
+			pairs = append(pairs, o.Pair)
+		}
+	}
+	for _, name := range pairs {
+		pair, err := parsePair(name)
+		if err != nil {
+			return err
+		}
+		ticker, err := bitco.Tickercc(conf, pair)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		now := time.Now()
+		changed, fired := bitco.TriggerConditionals(active, name, float64(ticker.Last), now)
+		if len(changed) == 0 {
+			continue
+		}
+		if err := store.SaveConditionalOrders(changed...); err != nil {
+			return err
+		}
+		for _, o := range fired {
+			log.Printf("conditional order %s %s %s triggered at %v\n", o.ID, o.Kind, o.Pair, ticker.Last)
+			o = fireConditional(o, float64(ticker.Last))
+			o.Updated = time.Now()
+			if err := store.SaveConditionalOrders(o); err != nil {
+				return err
+			}
+			if o.State == bitco.ConditionalFailed {
+				log.Printf("conditional order %s failed: %s\n", o.ID, o.Error)
+			}
+		}
+		if active, err = store.ConditionalOrders(bitco.ConditionalActive); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseConditional returns the conditional order of the params.
+func parseConditional(in *bitco.ConditionalOrderParams, now time.Time) (bitco.ConditionalOrder, error) {
+	if in == nil {
+		return bitco.ConditionalOrder{}, status.Error(codes.InvalidArgument, "no order")
+	}
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return bitco.ConditionalOrder{}, err
+	}
+	o := bitco.ConditionalOrder{
+		ID:      xid.New().String(),
+		Kind:    in.Kind,
+		Pair:    pair.String(),
+		Side:    in.Side,
+		Amount:  in.Amount,
+		Rate:    in.Rate,
+		State:   bitco.ConditionalActive,
+		Created: now,
+		Updated: now,
+	}
+	if in.TriggerRate != "" {
+		if o.Trigger, err = strconv.ParseFloat(in.TriggerRate, 64); err != nil {
+			return o, status.Errorf(codes.InvalidArgument, "invalid trigger rate %q", in.TriggerRate)
+		}
+	}
+	if in.TrailDistance != "" {
+		if o.Trail, err = strconv.ParseFloat(in.TrailDistance, 64); err != nil {
+			return o, status.Errorf(codes.InvalidArgument, "invalid trail distance %q", in.TrailDistance)
+		}
+	}
+	if o.Kind == bitco.TrailingStop && o.Trigger != 0 {
+		return o, status.Error(codes.InvalidArgument, "a trailing_stop takes a trail distance, not a trigger rate")
+	}
+	if err := o.Validate(); err != nil {
+		return o, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return o, nil
+}
+
+// conditionalItem returns the RPC view of a conditional order.
+func conditionalItem(o bitco.ConditionalOrder) *bitco.ConditionalOrderItem {
+	item := &bitco.ConditionalOrderItem{
+		Id:            o.ID,
+		OcoId:         o.Group,
+		Kind:          o.Kind,
+		Pair:          o.Pair,
+		Side:          o.Side,
+		Amount:        o.Amount,
+		Rate:          o.Rate,
+		State:         o.State,
+		ClientOrderId: o.ClientID,
+		OrderId:       o.OrderID,
+		Error:         o.Error,
+		Created:       uint64(o.Created.Unix()),
+		Updated:       uint64(o.Updated.Unix()),
+	}
+	if o.Trigger != 0 {
+		item.TriggerRate = strconv.FormatFloat(o.Trigger, 'f', -1, 64)
+	}
+	if o.Trail != 0 {
+		item.TrailDistance = strconv.FormatFloat(o.Trail, 'f', -1, 64)
+	}
+	if o.Extreme != 0 {
+		item.BestRate = strconv.FormatFloat(o.Extreme, 'f', -1, 64)
+	}
+	return item
+}
+
+func conditionalList(orders []bitco.ConditionalOrder) *bitco.ConditionalOrderList {
+	list := &bitco.ConditionalOrderList{}
+	for _, o := range orders {
+		list.Orders = append(list.Orders, conditionalItem(o))
+	}
+	return list
+}
+
+func (s server) PlaceConditionalOrder(ctx context.Context, in *bitco.ConditionalOrderParams) (*bitco.ConditionalOrderItem, error) {
+	o, err := parseConditional(in, time.Now())
+	if err != nil {
+		return &bitco.ConditionalOrderItem{}, err
+	}
+	condMu.Lock()
+	defer condMu.Unlock()
+	if err := store.SaveConditionalOrders(o); err != nil {
+		return &bitco.ConditionalOrderItem{}, err
+	}
+	log.Printf("conditional order %s %s %s %s kept\n", o.ID, o.Kind, o.Side, o.Pair)
+	return conditionalItem(o), nil
+}
+
+func (s server) PlaceOCOOrder(ctx context.Context, in *bitco.OCOOrderParams) (*bitco.ConditionalOrderList, error) {
+	now := time.Now()
+	first, err := parseConditional(in.First, now)
+	if err != nil {
+		return &bitco.ConditionalOrderList{}, err
+	}
+	second, err := parseConditional(in.Second, now)
+	if err != nil {
+		return &bitco.ConditionalOrderList{}, err
+	}
+	if first, second, err = bitco.OCO(first, second); err != nil {
+		return &bitco.ConditionalOrderList{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	condMu.Lock()
+	defer condMu.Unlock()
+	if err := store.SaveConditionalOrders(first, second); err != nil {
+		return &bitco.ConditionalOrderList{}, err
+	}
+	log.Printf("OCO orders %s and %s %s kept\n", first.ID, second.ID, first.Pair)
+	return conditionalList([]bitco.ConditionalOrder{first, second}), nil
+}
+
+func (s server) CancelConditionalOrder(ctx context.Context, in *bitco.ConditionalOrderParam) (*bitco.ConditionalOrderList, error) {
+	condMu.Lock()
+	defer condMu.Unlock()
+	o, ok, err := store.ConditionalOrder(in.Id)
+	if err != nil {
+		return &bitco.ConditionalOrderList{}, err
+	}
+	if !ok {
+		return &bitco.ConditionalOrderList{}, status.Errorf(codes.NotFound, "no conditional order %s", in.Id)
+	}
+	if o.State != bitco.ConditionalActive {
+		return &bitco.ConditionalOrderList{}, status.Errorf(codes.FailedPrecondition, "conditional order %s is %s", in.Id, o.State)
+	}
+	cancelled := []bitco.ConditionalOrder{o}
+	if o.Group != "" {
+		active, err := store.ConditionalOrders(bitco.ConditionalActive)
+		if err != nil {
+			return &bitco.ConditionalOrderList{}, err
+		}
+		for _, other := range active {
+			if other.Group == o.Group && other.ID != o.ID {
+				cancelled = append(cancelled, other)
+			}
+		}
+	}
+	now := time.Now()
+	by := "client"
+	if id, ok := callerIdentity(ctx); ok {
+		by = id.Name
+	}
+	for i := range cancelled {
+		cancelled[i].State, cancelled[i].Error, cancelled[i].Updated = bitco.ConditionalCancelled, "cancelled by "+by, now
+	}
+	if err := store.SaveConditionalOrders(cancelled...); err != nil {
+		return &bitco.ConditionalOrderList{}, err
+	}
+	return conditionalList(cancelled), nil
+}
+
+func (s server) ListConditionalOrders(ctx context.Context, in *bitco.ListConditionalOrdersParam) (*bitco.ConditionalOrderList, error) {
+	for _, st := range in.State {
+		switch st {
+		case bitco.ConditionalActive, bitco.ConditionalTriggered, bitco.ConditionalCancelled, bitco.ConditionalFailed:
+		default:
+			return &bitco.ConditionalOrderList{}, status.Errorf(codes.InvalidArgument, "unknown conditional order state %q", st)
+		}
+	}
+	orders, err := store.ConditionalOrders(in.State...)
+	if err != nil {
+		return &bitco.ConditionalOrderList{}, err
+	}
+	return conditionalList(orders), nil
+}
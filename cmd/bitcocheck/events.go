@@ -0,0 +1,116 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventBatch How many order transitions a subscription reads at once.
+const eventBatch = 500
+
+// eventPoll How often a subscription also looks for the transitions saved by
+// other servers sharing the store.
+const eventPoll = 5 * time.Second
+
+// notifier wakes the goroutines waiting for a change.
+type notifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+// wait returns a channel closed on the next notify.
+func (n *notifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ch == nil {
+		n.ch = make(chan struct{})
+	}
+	return n.ch
+}
+
+func (n *notifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ch != nil {
+		close(n.ch)
+		n.ch = nil
+	}
+}
+
+// orderEvents is notified when an order transition is saved.
+var orderEvents = &notifier{}
+
+// stopping is closed when the server shuts down, ending the subscriptions.
+var stopping = make(chan struct{})
+
+// recordRejection adds the rejection of a client order to the order events.
+func recordRejection(c bitco.ClientOrder) {
+	tr := bitco.OrderTransition{
+		ClientID:  c.ClientID,
+		To:        bitco.OrderRejected,
+		Reason:    c.Error,
+		Time:      c.Updated,
+		Pair:      c.Pair,
+		OrderType: c.OrderType,
+		Amount:    c.Amount,
+	}
+	if err := store.AddOrderTransition(tr); err != nil {
+		log.Printf("order %s %s %s: rejection event not saved: %v\n", c.ClientID, c.OrderType, c.Pair, err)
+		return
+	}
+	orderEvents.notify()
+}
+
+// rejectOrder adds the rejection of an order refused by the halt or the risk
+// checks, or failed without a client order ID, to the order events.
+func rejectOrder(c bitco.ClientOrder, err error) {
+	c.Error, c.Updated = status.Convert(err).Message(), time.Now()
+	recordRejection(c)
+}
+
+func (s server) SubscribeOrderEvents(in *bitco.OrderEventsParam, stream bitco.Coincheck_SubscribeOrderEventsServer) error {
+	pair := ""
+	if in.Pair != "" {
+		p, err := parsePair(in.Pair)
+		if err != nil {
+			return err
+		}
+		pair = p.String()
+	}
+	reader := bitco.NewOrderEventReader(store, pair)
+	after := in.After
+	for {
+		// Taken before the read, so that a transition saved meanwhile wakes us.
+		wake := orderEvents.wait()
+		events, next, more, err := reader.Read(after, eventBatch)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+		after = next
+		if more {
+			continue
+		}
+		poll := time.NewTimer(eventPoll)
+		select {
+		case <-wake:
+		case <-poll.C:
+		case <-stream.Context().Done():
+			poll.Stop()
+			return stream.Context().Err()
+		case <-stopping:
+			poll.Stop()
+			return status.Errorf(codes.Unavailable, "server shutting down, resubscribe after %d", after)
+		}
+		poll.Stop()
+	}
+}
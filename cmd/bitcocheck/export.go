@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"strconv"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the size of the ExportChunk messages.
+const exportChunkSize = 64 * 1024
+
+// chunkWriter sends everything written to it as ExportChunk messages.
+type chunkWriter struct {
+	stream bitco.Coincheck_ExportServer
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.stream.Send(&bitco.ExportChunk{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func exportTicks(e bitco.Exporter, pair string, from, to time.Time) error {
+	var werr error
+	err := store.ScanTicks(bitco.TickQuery{Pair: pair, From: from, To: to}, func(tick bitco.Tick) bool {
+		werr = e.Write(bitco.TickRecord{
+			Pair:      pair,
+			Timestamp: tick.Time.Unix(),
+			Last:      tick.Last,
+			Bid:       tick.Bid,
+			Ask:       tick.Ask,
+			High:      tick.High,
+			Low:       tick.Low,
+			Volume:    tick.Volume,
+		})
+		return werr == nil
+	})
+	if err != nil {
+		return err
+	}
+	return werr
+}
+
+func exportCandles(e bitco.Exporter, pair, name string, from, to time.Time) error {
+	interval, err := bitco.ParseInterval(name)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	candles, err := candlesBetween(pair, interval, from, to)
+	if err != nil {
+		return err
+	}
+	for _, c := range candles {
+		r := bitco.CandleRecord{
+			Pair:      pair,
+			Interval:  name,
+			Timestamp: int64(c.Timestamp),
+			Open:      c.Open,
+			High:      c.High,
+			Low:       c.Low,
+			Close:     c.Close,
+			Volume:    c.Volume,
+			Trades:    int64(c.Trades),
+		}
+		if err := e.Write(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportTransactions writes our own transactions, paged back to from. A
+// range the pages do not reach is refused.
+func exportTransactions(ctx context.Context, e bitco.Exporter, pair string, from, to time.Time) error {
+	item, err := bitco.TransactionsSince(exchange, callConf(ctx), from)
+	if err != nil {
+		return err
+	}
+	for _, t := range item.Transactions {
+		if t.Pair != pair {
+			continue
+		}
+		tm, err := time.Parse(time.RFC3339, t.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if tm.Before(from) || !tm.Before(to) {
+			continue
+		}
+		r := bitco.TransactionRecord{
+			ID:          int64(t.Id),
+			OrderID:     int64(t.OrderId),
+			CreatedAt:   t.CreatedAt,
+			Pair:        t.Pair,
+			Side:        t.Side,
+			Rate:        t.Rate,
+			Fee:         t.Fee,
+			FeeCurrency: t.FeeCurrency,
+			Liquidity:   t.Liquidity,
+		}
+		if t.Funds != nil {
+			r.Btc = t.Funds.Btc
+			r.Jpy = t.Funds.Jpy
+		}
+		if err := e.Write(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s server) Export(in *bitco.ExportParam, stream bitco.Coincheck_ExportServer) error {
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return err
+	}
+	format, err := bitco.ParseExportFormat(in.Format)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	from := time.Unix(int64(in.From), 0)
+	to := time.Now()
+	if in.To > 0 {
+		to = time.Unix(int64(in.To), 0)
+	}
+	var record interface{}
+	switch in.Dataset {
+	case "ticks":
+		record = bitco.TickRecord{}
+	case "candles":
+		record = bitco.CandleRecord{}
+	case "transactions":
+		// Our own transactions are account data.
+		if err := requireRole(stream.Context(), "Export transactions", bitco.RoleTrade); err != nil {
+			return err
+		}
+		record = bitco.TransactionRecord{}
+	default:
+		return status.Error(codes.InvalidArgument, "unknown dataset: "+strconv.Quote(in.Dataset))
+	}
+	w := bufio.NewWriterSize(chunkWriter{stream: stream}, exportChunkSize)
+	e, err := bitco.NewExporter(w, format, record)
+	if err != nil {
+		return err
+	}
+	switch in.Dataset {
+	case "ticks":
+		err = exportTicks(e, pair.String(), from, to)
+	case "candles":
+		name := in.Interval
+		if name == "" {
+			name = "1m"
+		}
+		err = exportCandles(e, pair.String(), name, from, to)
+	case "transactions":
+		err = exportTransactions(stream.Context(), e, pair.String(), from, to)
+	}
+	if err != nil {
+		return err
+	}
+	if err := e.Close(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
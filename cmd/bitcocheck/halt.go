@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var killSwitch *bitco.KillSwitch
+
+// haltItem returns the RPC view of a halt state.
+func haltItem(state bitco.HaltState) *bitco.HaltItem {
+	item := &bitco.HaltItem{Halted: state.Halted, Reason: state.Reason, By: state.By}
+	if state.Halted {
+		item.Since = uint64(state.Since.Unix())
+	}
+	return item
+}
+
+// haltedError returns the status of an order refused by the halt, nil while
+// trading goes on.
+func haltedError() error {
+	state := killSwitch.State()
+	if !state.Halted {
+		return nil
+	}
+	return status.Errorf(codes.FailedPrecondition, "trading is halted since %s by %s: %s",
+		state.Since.Format(time.RFC3339), state.By, state.Reason)
+}
+
+// cancelOpenOrders cancels every open order of the account.
+func cancelOpenOrders(conf bitco.Config) (cancelled, failed []uint32, err error) {
+	opens, err := exchange.OpenOrders(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, order := range opens.Orders {
+		if _, err := exchange.CancelOrder(conf, order.Id); err != nil {
+			log.Printf("halt: cancel order %d error %v\n", order.Id, err)
+			failed = append(failed, order.Id)
+			continue
+		}
+		cancelled = append(cancelled, order.Id)
+	}
+	return cancelled, failed, nil
+}
+
+// halt halts trading and cancels the open orders when asked to, also when
+// trading was already halted.
+func halt(conf bitco.Config, reason, by string, cancel bool) (*bitco.HaltItem, error) {
+	state, halted, err := killSwitch.Halt(reason, by, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("halt not saved: %v", err)
+	}
+	if halted {
+		tradingHalted.Set(1)
+		log.Printf("trading halted by %s: %s\n", by, reason)
+	}
+	item := haltItem(state)
+	if cancel {
+		item.Cancelled, item.Failed, err = cancelOpenOrders(conf)
+		if err != nil {
+			return item, fmt.Errorf("trading is halted, but the open orders were not cancelled: %v", err)
+		}
+		log.Printf("halt cancelled %d open orders, %d failed\n", len(item.Cancelled), len(item.Failed))
+	}
+	return item, nil
+}
+
+// haltOnBreach halts trading on a breach of a rule of [risk] halt_on, and
+// records the halt in the audit log.
+func haltOnBreach(v *bitco.RiskViolation) {
+	if !conf.Risk.Halts(v.Rule) || killSwitch.State().Halted {
+		return
+	}
+	rec := &upstreamRecorder{}
+	item, err := halt(conf.WithRecorder(rec.record), v.Error(), "risk", conf.Risk.HaltCancelOrders)
+	if err != nil {
+		log.Printf("risk halt error %v\n", err)
+	}
+	auditJob(bitco.AuditEntry{
+		Caller: "risk",
+		Method: "Halt",
+		Params: auditJSON(&bitco.HaltParam{Reason: v.Error(), CancelOrders: conf.Risk.HaltCancelOrders}),
+	}, rec, item, err)
+}
+
+func (s server) Halt(ctx context.Context, in *bitco.HaltParam) (*bitco.HaltItem, error) {
+	if in.Reason == "" {
+		return &bitco.HaltItem{}, status.Error(codes.InvalidArgument, "a halt needs a reason")
+	}
+	by := "anonymous"
+	if id, ok := callerIdentity(ctx); ok {
+		by = id.Name
+	}
+	item, err := halt(callConf(ctx), in.Reason, by, in.CancelOrders)
+	if item == nil {
+		return &bitco.HaltItem{}, err
+	}
+	return item, err
+}
+
+func (s server) Resume(ctx context.Context, in *bitco.Empty) (*bitco.HaltItem, error) {
+	if err := killSwitch.Resume(); err != nil {
+		return &bitco.HaltItem{}, fmt.Errorf("resume not saved: %v", err)
+	}
+	tradingHalted.Set(0)
+	by := "anonymous"
+	if id, ok := callerIdentity(ctx); ok {
+		by = id.Name
+	}
+	log.Printf("trading resumed by %s\n", by)
+	return haltItem(killSwitch.State()), nil
+}
+
+func (s server) HaltStatus(ctx context.Context, in *bitco.Empty) (*bitco.HaltItem, error) {
+	return haltItem(killSwitch.State()), nil
+}
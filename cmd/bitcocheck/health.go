@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// coincheckService is the health service name of the Coincheck RPCs.
+const coincheckService = "bitcocheck.Coincheck"
+
+// upstreamFailureLimit is the number of failed Coincheck requests in a row
+// that marks the Coincheck service NOT_SERVING.
+const upstreamFailureLimit = 3
+
+// ready is set once the config is loaded and the store is open. Until then
+// the Coincheck RPCs are refused with Unavailable.
+var ready int32
+
+// upstreamHealth tracks the recent requests to the Coincheck API and reports
+// the Coincheck service NOT_SERVING while they keep failing.
+type upstreamHealth struct {
+	mu       sync.Mutex
+	health   *health.Server
+	failures int
+}
+
+func (u *upstreamHealth) observe(e bitco.RequestEvent) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !e.Failed() {
+		if u.failures >= upstreamFailureLimit && atomic.LoadInt32(&ready) == 1 {
+			log.Println("coincheck api recovered")
+			u.health.SetServingStatus(coincheckService, healthpb.HealthCheckResponse_SERVING)
+		}
+		u.failures = 0
+		return
+	}
+	u.failures++
+	if u.failures == upstreamFailureLimit {
+		log.Printf("coincheck api degraded: %d failed requests, last %s %s: status %d %v\n", u.failures, e.Method, e.URL, e.Status, e.Err)
+		u.health.SetServingStatus(coincheckService, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// setReady marks the server SERVING, the Coincheck service too unless the
+// Coincheck API is failing.
+func (u *upstreamHealth) setReady() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	atomic.StoreInt32(&ready, 1)
+	u.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	if u.failures < upstreamFailureLimit {
+		u.health.SetServingStatus(coincheckService, healthpb.HealthCheckResponse_SERVING)
+	}
+}
+
+func checkReady(method string) error {
+	if atomic.LoadInt32(&ready) == 0 && strings.HasPrefix(method, "/"+coincheckService+"/") {
+		return status.Error(codes.Unavailable, "server is starting")
+	}
+	return nil
+}
+
+func readyUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := checkReady(info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func readyStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := checkReady(info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -7,67 +7,115 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
 	"time"
 
-	"github.com/bvinc/go-sqlite-lite/sqlite3"
 	bitco "github.com/hypoballad/bitcocheck"
 	"github.com/robfig/cron/v3"
 	"github.com/rs/xid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
-const TickHist = `create table if not exists tickhist (
-	id text PRIMARY_kEY,
-	ts timestamp NOT NULL,
-	last real NOT NULL,
-	bid real NOT NULL,
-	ask real NOT NULL,
-	high real NOT NULL,
-	low real NOT NULL,
-	volume real NOT NULL)`
-
 var addr = flag.String("addr", ":50051", "server address")
 var configpath = flag.String("conf", "bitcocheck.toml", "config file name")
-var dbFile = flag.String("db", "bitcocheck.db", "sqlite3 db file name")
+var dbFile = flag.String("db", "bitcocheck.db", "sqlite3 db file name, unless [store] dsn is set")
+var importPath = flag.String("import", "", "CSV dump to load into the db, then exit")
+var importType = flag.String("import-type", "trades", "type of the -import dump, trades or ticks")
+var verifyAuditPath = flag.String("verify-audit", "", "audit log to verify, then exit")
+var tlsCert = flag.String("tls-cert", "", "server certificate file, overrides [server.tls] cert")
+var tlsKey = flag.String("tls-key", "", "server key file, overrides [server.tls] key")
+var tlsCA = flag.String("tls-ca", "", "CA file of the client certificates, overrides [server.tls] ca")
 
 var conf bitco.Config
-var conn *sqlite3.Conn
+var store bitco.Store
+
+// parsePair maps an unknown pair onto an InvalidArgument status.
+func parsePair(s string) (bitco.Pair, error) {
+	pair, err := bitco.ParsePair(s)
+	if err != nil {
+		return pair, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return pair, nil
+}
 
 type server struct {
 	bitco.UnimplementedCoincheckServer
 }
 
-func (s server) Ticker(ctx context.Context, in *bitco.Empty) (*bitco.TickerItem, error) {
+func (s server) Ticker(ctx context.Context, in *bitco.TickerParams) (*bitco.TickerItem, error) {
 	var item bitco.TickerItem
-	item, err := bitco.Tickercc(conf)
+	pair, err := parsePair(in.Pair)
 	if err != nil {
 		return &item, err
 	}
+	item, err = bitco.Tickercc(callConf(ctx), pair)
+	if err != nil {
+		return &item, err
+	}
+	risk.UpdatePrice(pair.String(), float64(item.Last), time.Unix(int64(item.Timestamp), 0))
 	return &item, nil
 }
 
 func (s server) Trades(ctx context.Context, in *bitco.TradesParams) (*bitco.TradesItem, error) {
 	var item bitco.TradesItem
-	var pair bitco.Pair
-	switch in.Pair {
-	case "ftc_jpy":
-		pair = bitco.Fctjpy
-	default:
-		pair = bitco.Btcjpy
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
 	}
-	item, err := bitco.Tradescc(conf, pair)
+	item, err = bitco.Tradescc(callConf(ctx), pair)
 	if err != nil {
 		return &item, err
 	}
 	return &item, nil
 }
 
-func (s server) OrderBooks(ctx context.Context, in *bitco.Empty) (*bitco.OrderBooksItem, error) {
+func (s server) OrderBooks(ctx context.Context, in *bitco.OrderBooksParams) (*bitco.OrderBooksItem, error) {
 	var item bitco.OrderBooksItem
-	item, err := bitco.OrderBookscc(conf)
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
+	}
+	item, err = bitco.OrderBookscc(callConf(ctx), pair)
+	if err != nil {
+		return &item, err
+	}
+	depth := int(in.Depth)
+	if depth > 0 && len(item.Asks) > depth {
+		item.Asks = item.Asks[:depth]
+	}
+	if depth > 0 && len(item.Bids) > depth {
+		item.Bids = item.Bids[:depth]
+	}
+	return &item, nil
+}
+
+func (s server) OrderBooksV2(ctx context.Context, in *bitco.OrderBooksParams) (*bitco.OrderBooksV2Item, error) {
+	var item bitco.OrderBooksV2Item
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
+	}
+	item, err = bitco.OrderBooksV2cc(callConf(ctx), pair)
 	if err != nil {
 		return &item, err
 	}
+	depth := int(in.Depth)
+	if depth > 0 && len(item.Asks) > depth {
+		item.Asks = item.Asks[:depth]
+	}
+	if depth > 0 && len(item.Bids) > depth {
+		item.Bids = item.Bids[:depth]
+	}
 	return &item, nil
 }
 
@@ -80,12 +128,9 @@ func (s server) ExchangeOrdersRate(ctx context.Context, in *bitco.ExchangeOrders
 	default:
 		orderType = bitco.Buy
 	}
-	var pair bitco.Pair
-	switch in.Pair {
-	case "ftc_jpy":
-		pair = bitco.Fctjpy
-	default:
-		pair = bitco.Btcjpy
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
 	}
 	var amountPrice bitco.AmountPriceType
 	switch in.Amountprice {
@@ -94,7 +139,7 @@ func (s server) ExchangeOrdersRate(ctx context.Context, in *bitco.ExchangeOrders
 	default:
 		amountPrice = bitco.Price
 	}
-	item, err := bitco.ExchangeOrdersRatecc(conf, orderType, pair, amountPrice, in.Value)
+	item, err = bitco.ExchangeOrdersRatecc(callConf(ctx), orderType, pair, amountPrice, in.Value)
 	if err != nil {
 		return &item, err
 	}
@@ -103,14 +148,11 @@ func (s server) ExchangeOrdersRate(ctx context.Context, in *bitco.ExchangeOrders
 
 func (s server) RatePair(ctx context.Context, in *bitco.RatePairParams) (*bitco.RatePairItem, error) {
 	var item bitco.RatePairItem
-	var pair bitco.Pair
-	switch in.Pair {
-	case "ftc_jpy":
-		pair = bitco.Fctjpy
-	default:
-		pair = bitco.Btcjpy
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
 	}
-	item, err := bitco.RatePaircc(conf, pair)
+	item, err = bitco.RatePaircc(callConf(ctx), pair)
 	if err != nil {
 		return &item, err
 	}
@@ -119,14 +161,18 @@ func (s server) RatePair(ctx context.Context, in *bitco.RatePairParams) (*bitco.
 
 func (s server) LimitBuy(ctx context.Context, in *bitco.LimitOrderParams) (*bitco.MarketItem, error) {
 	var item bitco.MarketItem
-	var pair bitco.Pair
-	switch in.Pair {
-	case "ftc_jpy":
-		pair = bitco.Fctjpy
-	default:
-		pair = bitco.Btcjpy
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
 	}
-	item, err := bitco.LimitOrdercc(conf, pair, bitco.Buy, in.Rate, in.Amount, in.StopLossRate)
+	o, err := limitOrder(pair, bitco.Buy, in.Rate, in.Amount)
+	if err != nil {
+		return &item, err
+	}
+	c := bitco.ClientOrder{ClientID: in.ClientOrderId, Pair: pair.String(), OrderType: bitco.Buy.String(), Rate: in.Rate, Amount: in.Amount, StopLossRate: in.StopLossRate}
+	item, err = placeOrder(ctx, c, o, func(conf bitco.Config) (bitco.MarketItem, error) {
+		return exchange.LimitOrder(conf, pair, bitco.Buy, in.Rate, in.Amount, in.StopLossRate)
+	})
 	if err != nil {
 		return &item, err
 	}
@@ -135,14 +181,18 @@ func (s server) LimitBuy(ctx context.Context, in *bitco.LimitOrderParams) (*bitc
 
 func (s server) LimitSell(ctx context.Context, in *bitco.LimitOrderParams) (*bitco.MarketItem, error) {
 	var item bitco.MarketItem
-	var pair bitco.Pair
-	switch in.Pair {
-	case "ftc_jpy":
-		pair = bitco.Fctjpy
-	default:
-		pair = bitco.Btcjpy
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
+	}
+	o, err := limitOrder(pair, bitco.Sell, in.Rate, in.Amount)
+	if err != nil {
+		return &item, err
 	}
-	item, err := bitco.LimitOrdercc(conf, pair, bitco.Sell, in.Rate, in.Amount, in.StopLossRate)
+	c := bitco.ClientOrder{ClientID: in.ClientOrderId, Pair: pair.String(), OrderType: bitco.Sell.String(), Rate: in.Rate, Amount: in.Amount, StopLossRate: in.StopLossRate}
+	item, err = placeOrder(ctx, c, o, func(conf bitco.Config) (bitco.MarketItem, error) {
+		return exchange.LimitOrder(conf, pair, bitco.Sell, in.Rate, in.Amount, in.StopLossRate)
+	})
 	if err != nil {
 		return &item, err
 	}
@@ -151,14 +201,15 @@ func (s server) LimitSell(ctx context.Context, in *bitco.LimitOrderParams) (*bit
 
 func (s server) MarketBuy(ctx context.Context, in *bitco.MarketBuyParams) (*bitco.MarketItem, error) {
 	var item bitco.MarketItem
-	var pair bitco.Pair
-	switch in.Pair {
-	case "ftc_jpy":
-		pair = bitco.Fctjpy
-	default:
-		pair = bitco.Btcjpy
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
 	}
-	item, err := bitco.MarketBuycc(conf, pair, in.MarketBuyAmount)
+	o := bitco.RiskOrder{Pair: pair.String(), Type: bitco.MarketBuy, Funds: float64(in.MarketBuyAmount)}
+	c := bitco.ClientOrder{ClientID: in.ClientOrderId, Pair: pair.String(), OrderType: bitco.MarketBuy.String(), Amount: strconv.FormatUint(uint64(in.MarketBuyAmount), 10)}
+	item, err = placeOrder(ctx, c, o, func(conf bitco.Config) (bitco.MarketItem, error) {
+		return exchange.MarketBuy(conf, pair, in.MarketBuyAmount)
+	})
 	if err != nil {
 		return &item, err
 	}
@@ -167,14 +218,15 @@ func (s server) MarketBuy(ctx context.Context, in *bitco.MarketBuyParams) (*bitc
 
 func (s server) MarketSell(ctx context.Context, in *bitco.MarketSellParam) (*bitco.MarketItem, error) {
 	var item bitco.MarketItem
-	var pair bitco.Pair
-	switch in.Pair {
-	case "ftc_jpy":
-		pair = bitco.Fctjpy
-	default:
-		pair = bitco.Btcjpy
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &item, err
 	}
-	item, err := bitco.MarketSellcc(conf, pair, in.Amount)
+	o := bitco.RiskOrder{Pair: pair.String(), Type: bitco.MarketSell, Amount: float64(in.Amount)}
+	c := bitco.ClientOrder{ClientID: in.ClientOrderId, Pair: pair.String(), OrderType: bitco.MarketSell.String(), Amount: strconv.FormatUint(uint64(in.Amount), 10)}
+	item, err = placeOrder(ctx, c, o, func(conf bitco.Config) (bitco.MarketItem, error) {
+		return exchange.MarketSell(conf, pair, in.Amount)
+	})
 	if err != nil {
 		return &item, err
 	}
@@ -183,16 +235,16 @@ func (s server) MarketSell(ctx context.Context, in *bitco.MarketSellParam) (*bit
 
 func (s server) ExchangeOrdersOpens(ctx context.Context, in *bitco.Empty) (*bitco.OrdersOpensItem, error) {
 	var item bitco.OrdersOpensItem
-	item, err := bitco.ExchangeOrdersOpenscc(conf)
+	item, err := exchange.OpenOrders(callConf(ctx))
 	if err != nil {
 		return &item, err
 	}
 	return &item, nil
 }
 
-func (s server) DeleteExchangeOrer(ctx context.Context, in *bitco.DeleteOrderParam) (*bitco.DeleteOrderItem, error) {
+func (s server) DeleteExchangeOrder(ctx context.Context, in *bitco.DeleteOrderParam) (*bitco.DeleteOrderItem, error) {
 	var item bitco.DeleteOrderItem
-	item, err := bitco.DeleteExchangeOrdercc(conf, in.Id)
+	item, err := exchange.CancelOrder(callConf(ctx), in.Id)
 	if err != nil {
 		return &item, err
 	}
@@ -201,7 +253,7 @@ func (s server) DeleteExchangeOrer(ctx context.Context, in *bitco.DeleteOrderPar
 
 func (s server) ExchangeOrdersTransactions(ctx context.Context, in *bitco.Empty) (*bitco.OrdersTransactionsItem, error) {
 	var item bitco.OrdersTransactionsItem
-	item, err := bitco.ExchangeOrdersTransactionscc(conf)
+	item, err := exchange.Transactions(callConf(ctx))
 	if err != nil {
 		return &item, err
 	}
@@ -210,7 +262,7 @@ func (s server) ExchangeOrdersTransactions(ctx context.Context, in *bitco.Empty)
 
 func (s server) AccountsBalance(ctx context.Context, in *bitco.Empty) (*bitco.AccountsBalanceItem, error) {
 	var item bitco.AccountsBalanceItem
-	item, err := bitco.AccountsBalancecc(conf)
+	item, err := exchange.Balance(callConf(ctx))
 	if err != nil {
 		return &item, err
 	}
@@ -220,7 +272,7 @@ func (s server) AccountsBalance(ctx context.Context, in *bitco.Empty) (*bitco.Ac
 func (s server) Accounts(ctx context.Context, in *bitco.Empty) (*bitco.AccountsItem, error) {
 	var item bitco.AccountsItem
 	//log.Println("accounts")
-	item, err := bitco.Accountscc(conf)
+	item, err := bitco.Accountscc(callConf(ctx))
 	if err != nil {
 		return &item, err
 	}
@@ -230,122 +282,396 @@ func (s server) Accounts(ctx context.Context, in *bitco.Empty) (*bitco.AccountsI
 
 func (s server) TickerHist(ctx context.Context, in *bitco.TickerHistParam) (*bitco.TickerHistItem, error) {
 	var item bitco.TickerHistItem
-	stmt, err := conn.Prepare(`select ts, last, bid, ask, high, low, volume from tickhist order by ts desc limit ?`)
+	pair, err := parsePair(in.Pair)
 	if err != nil {
 		return &item, err
 	}
-	defer stmt.Close()
-	limit := 1000
-	if in.Limit > 0 {
-		limit = int(in.Limit)
+	q := bitco.TickHistQuery{Pair: pair.String(), Desc: true, Limit: int(in.Limit), Step: time.Duration(in.Step) * time.Minute, Cursor: in.Cursor}
+	switch in.Order {
+	case "", "desc":
+	case "asc":
+		q.Desc = false
+	default:
+		return &item, status.Errorf(codes.InvalidArgument, "unknown order: %s", in.Order)
 	}
-	if err := stmt.Bind(limit); err != nil {
-		return &item, err
+	if in.From > 0 {
+		q.From = time.Unix(int64(in.From), 0)
 	}
-	result := []*bitco.TickerItem{}
-	for {
-		hasRow, err := stmt.Step()
-		if err != nil {
-			return &item, err
-		}
-		if !hasRow {
-			break
-		}
-		var ts string
-		var last float64
-		var bid float64
-		var ask float64
-		var high float64
-		var low float64
-		var volume float64
-		if err := stmt.Scan(&ts, &last, &bid, &ask, &high, &low, &volume); err != nil {
-			return &item, err
-		}
-		tm, err := time.Parse("2006-01-02 15:04:05", ts)
-		if err != nil {
-			return &item, err
-		}
-		ticker := bitco.TickerItem{
-			Timestamp: uint64(tm.Unix()),
-			Last:      float32(last),
-			Bid:       float32(bid),
-			Ask:       float32(ask),
-			High:      float32(high),
-			Low:       float32(low),
-			Volume:    float32(volume),
-		}
-		result = append(result, &ticker)
+	if in.To > 0 {
+		q.To = time.Unix(int64(in.To), 0)
+	}
+	ticks, next, err := bitco.TickHistPage(store, q)
+	if err == bitco.ErrInvalidCursor {
+		return &item, status.Error(codes.InvalidArgument, err.Error())
 	}
-	item.Tickeritem = result
+	if err != nil {
+		return &item, err
+	}
+	item.Tickeritem = []*bitco.TickerItem{}
+	for _, tick := range ticks {
+		item.Tickeritem = append(item.Tickeritem, &bitco.TickerItem{
+			Timestamp: uint64(tick.Time.Unix()),
+			Last:      float32(tick.Last),
+			Bid:       float32(tick.Bid),
+			Ask:       float32(tick.Ask),
+			High:      float32(tick.High),
+			Low:       float32(tick.Low),
+			Volume:    float32(tick.Volume),
+		})
+	}
+	item.NextCursor = next
 	return &item, nil
 }
 
-func createSQL(conn *sqlite3.Conn) error {
-	for _, stmt := range []string{TickHist} {
-		if err := conn.Exec(stmt); err != nil {
-			return errors.New(fmt.Sprintf("%v, %s", err, stmt))
+// job collects the ticker of every configured pair into tickhist.
+func job(store bitco.Store, conf bitco.Config) error {
+	pairs, err := conf.Ticker.PairList()
+	if err != nil {
+		return err
+	}
+	ticks := []bitco.Tick{}
+	for _, pair := range pairs {
+		item, err := bitco.Tickercc(conf, pair)
+		if err != nil {
+			return errors.New(fmt.Sprintf("%s: %v", pair, err))
 		}
+		ticks = append(ticks, bitco.Tick{
+			ID:     xid.New().String(),
+			Pair:   pair.String(),
+			Time:   time.Unix(int64(item.Timestamp), 0),
+			Last:   float64(item.Last),
+			Bid:    float64(item.Bid),
+			Ask:    float64(item.Ask),
+			High:   float64(item.High),
+			Low:    float64(item.Low),
+			Volume: float64(item.Volume),
+		})
+	}
+	if err := store.SaveTicks(ticks); err != nil {
+		return err
+	}
+	for _, tick := range ticks {
+		tickhistLastSuccess.WithLabelValues(tick.Pair).SetToCurrentTime()
+		risk.UpdatePrice(tick.Pair, tick.Last, tick.Time)
 	}
 	return nil
 }
 
-func job(conn *sqlite3.Conn, conf bitco.Config) error {
-	item, err := bitco.Tickercc(conf)
-	if err != nil {
-		return err
+// shutdown stops the server and the cron jobs. The running RPCs and jobs get
+// until the deadline to finish, then the RPCs are cancelled.
+func shutdown(s *grpc.Server, hs *health.Server, c *cron.Cron, timeout time.Duration) {
+	hs.Shutdown()
+	close(stopping)
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	jobs := c.Stop()
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-deadline.C:
+		log.Println("shutdown deadline exceeded, cancelling the running RPCs")
+		s.Stop()
+		<-stopped
+	}
+	select {
+	case <-jobs.Done():
+	case <-deadline.C:
+		log.Println("shutdown deadline exceeded, waiting for the running job")
+		<-jobs.Done()
 	}
-	guid := xid.New()
-	if err := conn.Begin(); err != nil {
-		return err
+}
+
+// openStore opens the store of the config, the -db file by default.
+func openStore() (bitco.Store, error) {
+	storeConf := conf.Store
+	if storeConf.DSN == "" {
+		storeConf.DSN = *dbFile
 	}
-	stmt, err := conn.Prepare(`insert into tickhist values (?,?,?,?,?,?,?,?)`)
+	st, err := bitco.OpenStore(storeConf, bitco.MarketMigrations)
 	if err != nil {
-		return err
-	}
-	defer stmt.Close()
-	tm := time.Unix(int64(item.Timestamp), 0)
-	if err := stmt.Exec(guid.String(), tm.Format("2006-01-02 15:04:05"), float64(item.Last), float64(item.Bid), float64(item.Ask), float64(item.High), float64(item.Low), float64(item.Volume)); err != nil {
-		return err
+		return nil, fmt.Errorf("store open error: %v", err)
 	}
-	if err := conn.Commit(); err != nil {
-		return err
+	return st, nil
+}
+
+func closeStore() {
+	if err := store.Close(); err != nil {
+		log.Println("store close error:", err)
 	}
-	return nil
 }
 
 func main() {
 	flag.Parse()
+	if *verifyAuditPath != "" {
+		if err := verifyAudit(*verifyAuditPath); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
 	var err error
 	conf, err = bitco.DecodeConfigToml(*configpath)
 	if err != nil {
 		log.Fatalln("config read error:", err)
 	}
-	conn, err = sqlite3.Open(*dbFile)
+	if *importPath != "" {
+		if err := runImport(); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+	tlsConf := conf.Server.TLS
+	if *tlsCert != "" {
+		tlsConf.Cert = *tlsCert
+	}
+	if *tlsKey != "" {
+		tlsConf.Key = *tlsKey
+	}
+	if *tlsCA != "" {
+		tlsConf.CA = *tlsCA
+	}
+	opts, err := bitco.ServerCredentials(tlsConf)
+	if err != nil {
+		log.Fatalln("tls config error:", err)
+	}
+	// The server answers health checks, NOT_SERVING, while it starts.
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	hs.SetServingStatus(coincheckService, healthpb.HealthCheckResponse_NOT_SERVING)
+	upstream := &upstreamHealth{health: hs}
+	bitco.AddRequestHook(upstream.observe)
+	reg := newMetricsRegistry()
+	stopTracing, err := bitco.StartTracing(conf.Tracing, "bitcocheck")
+	if err != nil {
+		log.Fatalln("tracing config error:", err)
+	}
+	defer stopTracing()
+	unary := []grpc.UnaryServerInterceptor{tracingUnary, metricsUnary}
+	stream := []grpc.StreamServerInterceptor{tracingStream, metricsStream}
+	if conf.Server.Auth.Enabled() {
+		auth, err := bitco.NewAuthenticator(conf.Server.Auth)
+		if err != nil {
+			log.Fatalln("auth config error:", err)
+		}
+		ai := authInterceptor{auth: auth}
+		unary = append(unary, ai.unary)
+		stream = append(stream, ai.stream)
+	} else {
+		log.Println("no [server.auth] tokens, the API is open to anyone reaching it")
+	}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(append(unary, readyUnary, auditUnary)...),
+		grpc.ChainStreamInterceptor(append(stream, readyStream)...),
+	)
+	s := grpc.NewServer(opts...)
+	bitco.RegisterCoincheckServer(s, &server{})
+	healthpb.RegisterHealthServer(s, hs)
+	reflection.Register(s)
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(lis)
+	}()
+	if tlsConf.CA != "" {
+		log.Printf("listen to %s with mutual tls\n", *addr)
+	} else if tlsConf.Enabled() {
+		log.Printf("listen to %s with tls\n", *addr)
+	} else {
+		log.Printf("listen to %s\n", *addr)
+	}
+	metrics := serveMetrics(conf.Metrics.ListenAddr(), reg)
+	log.Printf("metrics on http://%s/metrics\n", conf.Metrics.ListenAddr())
+	if err := run(s, metrics, upstream, served); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// runImport loads the -import dump into the store.
+func runImport() error {
+	var err error
+	store, err = openStore()
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+	n, err := importFile(store, conf, *importPath, *importType)
+	if err != nil {
+		return fmt.Errorf("import error: %v", err)
+	}
+	log.Printf("imported %d %s\n", n, *importType)
+	return nil
+}
+
+// run opens the store, marks the server ready and serves until SIGINT or
+// SIGTERM. The store is closed on every return, so an error
+// never leaves a transaction behind.
+func run(s *grpc.Server, metrics *http.Server, upstream *upstreamHealth, served <-chan error) error {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	timeout, err := conf.Server.ShutdownTimeoutDuration()
+	if err != nil {
+		return fmt.Errorf("server config error: %v", err)
+	}
+	names := []string{"raw"}
+	for _, res := range bitco.Resolutions {
+		names = append(names, res.Name)
+	}
+	for _, name := range names {
+		if _, err := conf.Ticker.Retention.Lookup(name); err != nil {
+			return fmt.Errorf("retention config error: %v", err)
+		}
+	}
+	if _, err := conf.Candles.IntervalList(); err != nil {
+		return fmt.Errorf("candles config error: %v", err)
+	}
+	risk, err = bitco.NewRiskEngine(conf.Risk)
 	if err != nil {
-		log.Fatalln("sqlite3 connection error:", err)
+		return fmt.Errorf("risk config error: %v", err)
 	}
-	if err := createSQL(conn); err != nil {
-		log.Fatalln("create sql error: ", err)
+	if err := openExchange(); err != nil {
+		return fmt.Errorf("paper config error: %v", err)
 	}
-	if err := job(conn, conf); err != nil {
-		log.Fatalln("job error:", err)
+	if _, err := conf.Orders.UnknownTimeoutDuration(); err != nil {
+		return fmt.Errorf("orders config error: %v", err)
 	}
+	store, err = openStore()
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+	auditLog, err = bitco.OpenAuditLog(conf.Server.AuditLogPath())
+	if err != nil {
+		return err
+	}
+	defer auditLog.Close()
+	killSwitch, err = bitco.OpenKillSwitch(conf.Server.HaltFilePath())
+	if err != nil {
+		return fmt.Errorf("halt file error: %v", err)
+	}
+	if state := killSwitch.State(); state.Halted {
+		tradingHalted.Set(1)
+		log.Printf("trading is halted since %s by %s: %s\n", state.Since.Format(time.RFC3339), state.By, state.Reason)
+	}
+	// The store is safe for concurrent use, but the jobs read and then write the
+	// same rows, so they never run at the same time.
+	var jobMu sync.Mutex
 	c := cron.New()
-	c.AddFunc("@every 1h", func() {
-		if err := job(conn, conf); err != nil {
+	if _, err := c.AddFunc(conf.Ticker.CollectSpec(), func() {
+		jobMu.Lock()
+		defer jobMu.Unlock()
+		err := job(store, conf)
+		observeJob("ticker", err)
+		if err != nil {
 			log.Printf("job error %v\n", err)
+			return
 		}
-	})
-	c.Start()
-	var lis net.Listener
-	lis, err = net.Listen("tcp", *addr)
-	if err != nil {
-		log.Fatalln("failed to listen: %v", err)
+		err = gapJob(store, conf)
+		observeJob("gaps", err)
+		if err != nil {
+			log.Printf("gap job error %v\n", err)
+		}
+	}); err != nil {
+		return fmt.Errorf("ticker schedule error: %v", err)
+	}
+	if _, err := c.AddFunc(conf.Ticker.RollupSpec(), func() {
+		jobMu.Lock()
+		defer jobMu.Unlock()
+		err := rollupJob(store, conf)
+		observeJob("rollup", err)
+		if err != nil {
+			log.Printf("rollup job error %v\n", err)
+		}
+	}); err != nil {
+		return fmt.Errorf("rollup schedule error: %v", err)
+	}
+	if _, err := c.AddFunc(conf.Candles.Spec(), func() {
+		jobMu.Lock()
+		defer jobMu.Unlock()
+		err := candleJob(store, conf)
+		observeJob("candles", err)
+		if err != nil {
+			log.Printf("candle job error %v\n", err)
+		}
+	}); err != nil {
+		return fmt.Errorf("candles schedule error: %v", err)
 	}
-	s := grpc.NewServer()
-	log.Printf("listen to %s\n", *addr)
-	bitco.RegisterCoincheckServer(s, &server{})
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	if _, err := c.AddFunc(conf.Metrics.AccountSpec(), func() {
+		err := accountJob(conf)
+		observeJob("account", err)
+		if err != nil {
+			log.Printf("account job error %v\n", err)
+		}
+	}); err != nil {
+		return fmt.Errorf("metrics schedule error: %v", err)
+	}
+	if _, err := c.AddFunc(conf.Orders.ReconcileSpec(), func() {
+		err := reconcileJob(conf)
+		observeJob("reconcile", err)
+		if err != nil {
+			log.Printf("reconcile job error %v\n", err)
+		}
+	}); err != nil {
+		return fmt.Errorf("orders schedule error: %v", err)
+	}
+	if _, err := c.AddFunc(conf.Orders.TrackSpec(), func() {
+		err := trackJob(conf)
+		observeJob("track", err)
+		if err != nil {
+			log.Printf("track job error %v\n", err)
+		}
+	}); err != nil {
+		return fmt.Errorf("orders schedule error: %v", err)
+	}
+	if _, err := c.AddFunc(conf.Orders.WatchSpec(), func() {
+		err := conditionalJob(conf)
+		observeJob("conditional", err)
+		if err != nil {
+			log.Printf("conditional job error %v\n", err)
+		}
+	}); err != nil {
+		return fmt.Errorf("orders schedule error: %v", err)
+	}
+	if err := startRecurring(c); err != nil {
+		return fmt.Errorf("recurring orders error: %v", err)
+	}
+	if _, err := c.AddFunc(recurringSyncSpec, func() {
+		err := syncRecurring()
+		observeJob("recurring", err)
+		if err != nil {
+			log.Printf("recurring job error %v\n", err)
+		}
+	}); err != nil {
+		return fmt.Errorf("recurring schedule error: %v", err)
+	}
+	upstream.setReady()
+	if err := job(store, conf); err != nil {
+		observeJob("ticker", err)
+		log.Printf("job error %v\n", err)
+	} else {
+		observeJob("ticker", nil)
+		err := gapJob(store, conf)
+		observeJob("gaps", err)
+		if err != nil {
+			log.Printf("gap job error %v\n", err)
+		}
+	}
+	c.Start()
+	select {
+	case sig := <-sigs:
+		log.Printf("%v received, shutting down\n", sig)
+		shutdown(s, upstream.health, c, timeout)
+		metrics.Close()
+		return nil
+	case err := <-served:
+		<-c.Stop().Done()
+		metrics.Close()
+		return fmt.Errorf("failed to serve: %v", err)
 	}
 }
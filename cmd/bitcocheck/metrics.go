@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "RPCs completed by the server by method and status code.",
+	}, []string{"method", "code"})
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Time the server took to complete an RPC.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+	jobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bitcocheck_job_runs_total",
+		Help: "Runs of the cron jobs by job and result.",
+	}, []string{"job", "result"})
+	jobLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bitcocheck_job_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful run of a cron job.",
+	}, []string{"job"})
+	tickhistLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bitcocheck_tickhist_last_success_timestamp_seconds",
+		Help: "Unix time a ticker of the pair was last saved to tickhist.",
+	}, []string{"pair"})
+	balance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "coincheck_balance",
+		Help: "Account balance by currency and state, available, reserved for open orders or debt.",
+	}, []string{"currency", "state"})
+	openOrders = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "coincheck_open_orders",
+		Help: "Open orders of the account by order type.",
+	}, []string{"order_type"})
+	riskRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bitcocheck_risk_rejections_total",
+		Help: "Orders rejected by the risk checks by rule, \"halted\" while trading is halted.",
+	}, []string{"rule"})
+	tradingHalted = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "bitcocheck_trading_halted",
+		Help: "1 while trading is halted, else 0.",
+	})
+	recurringRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bitcocheck_recurring_runs_total",
+		Help: "Runs of the recurring orders by order ID and outcome, placed, skipped, failed or unknown.",
+	}, []string{"id", "outcome"})
+)
+
+// newMetricsRegistry registers the metrics of the server and starts
+// counting the requests to the Coincheck API.
+func newMetricsRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	requests := bitco.NewRequestMetrics()
+	bitco.AddRequestHook(requests.Observe)
+	reg.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		requests,
+		rpcHandled, rpcDuration,
+		jobRuns, jobLastSuccess, tickhistLastSuccess,
+		balance, openOrders,
+		riskRejections, tradingHalted,
+		recurringRuns,
+	)
+	return reg
+}
+
+// serveMetrics serves /metrics until the server is shut down.
+func serveMetrics(addr string, reg *prometheus.Registry) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("metrics server error %v\n", err)
+		}
+	}()
+	return srv
+}
+
+func observeRPC(fullMethod string, start time.Time, err error) {
+	method := strings.TrimPrefix(fullMethod, "/"+coincheckService+"/")
+	rpcHandled.WithLabelValues(method, status.Code(err).String()).Inc()
+	rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func metricsUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeRPC(info.FullMethod, start, err)
+	return resp, err
+}
+
+func metricsStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeRPC(info.FullMethod, start, err)
+	return err
+}
+
+// observeJob counts a run of a cron job.
+func observeJob(name string, err error) {
+	if err != nil {
+		jobRuns.WithLabelValues(name, "error").Inc()
+		return
+	}
+	jobRuns.WithLabelValues(name, "ok").Inc()
+	jobLastSuccess.WithLabelValues(name).SetToCurrentTime()
+}
+
+func setGauge(g prometheus.Gauge, s string) {
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		g.Set(v)
+	}
+}
+
+// accountJob updates the balance and open order gauges and the account of
+// the risk checks.
+func accountJob(conf bitco.Config) error {
+	at := time.Now()
+	item, err := exchange.Balance(conf)
+	if err != nil {
+		return err
+	}
+	setGauge(balance.WithLabelValues("jpy", "available"), item.Jpy)
+	setGauge(balance.WithLabelValues("jpy", "reserved"), item.JpyReserved)
+	setGauge(balance.WithLabelValues("jpy", "debt"), item.JpyDebt)
+	setGauge(balance.WithLabelValues("btc", "available"), item.Btc)
+	setGauge(balance.WithLabelValues("btc", "reserved"), item.BtcReserved)
+	setGauge(balance.WithLabelValues("btc", "debt"), item.BtcDebt)
+	opens, err := exchange.OpenOrders(conf)
+	if err != nil {
+		return err
+	}
+	risk.UpdateBalance(item, opens, at)
+	if v := risk.Breach(); v != nil {
+		haltOnBreach(v)
+	}
+	openOrders.Reset()
+	for _, order := range opens.Orders {
+		openOrders.WithLabelValues(order.OrderType).Inc()
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxClientIDLen The longest client order ID taken.
+const maxClientIDLen = 64
+
+// pendingTimeout How long a client order may stay pending before it is taken
+// as left by a crash and reconciled.
+const pendingTimeout = time.Minute
+
+// unknownReservations The risk orders of the client orders of this process
+// whose outcome is unknown, by client ID. Their reservations are released
+// when reconcileJob takes them as not placed.
+var unknownReservations = struct {
+	sync.Mutex
+	orders map[string]bitco.RiskOrder
+}{orders: map[string]bitco.RiskOrder{}}
+
+// answerClientOrder answers a submission whose client order ID is already
+// stored with the outcome of the first submission.
+func answerClientOrder(c, stored bitco.ClientOrder) (bitco.MarketItem, error) {
+	var item bitco.MarketItem
+	if !c.SameOrder(stored) {
+		return item, status.Errorf(codes.InvalidArgument, "client order id %s was used for another order", c.ClientID)
+	}
+	switch stored.State {
+	case bitco.ClientOrderPlaced:
+		if err := json.Unmarshal([]byte(stored.Item), &item); err != nil {
+			return item, status.Errorf(codes.Internal, "client order %s: %v", c.ClientID, err)
+		}
+		return item, nil
+	case bitco.ClientOrderRejected:
+		return item, status.Errorf(codes.FailedPrecondition, "client order %s was rejected: %s", c.ClientID, stored.Error)
+	}
+	return item, status.Errorf(codes.Unavailable, "the outcome of client order %s is not known yet, retry later", c.ClientID)
+}
+
+// sendOutcome keeps the last Coincheck request of an order.
+type sendOutcome struct {
+	mu    sync.Mutex
+	sent  bool
+	event bitco.RequestEvent
+}
+
+func (s *sendOutcome) record(e bitco.RequestEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent, s.event = true, e
+}
+
+// state returns the state of an order whose send returned err: rejected
+// when it was never sent or Coincheck refused it with a 4xx status, unknown
+// when its answer was lost.
+func (s *sendOutcome) state(err error) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case err == nil:
+		return bitco.ClientOrderPlaced
+	case !s.sent || (s.event.Err == nil && s.event.Status >= 400 && s.event.Status < 500):
+		return bitco.ClientOrderRejected
+	}
+	return bitco.ClientOrderUnknown
+}
+
+// sendClientOrder reserves the client order ID, sends the order and saves
+// its outcome. An order whose outcome is unknown keeps its risk reservation
+// and is left to reconcileJob.
+func sendClientOrder(ctx context.Context, c bitco.ClientOrder, o bitco.RiskOrder, send func(bitco.Config) (bitco.MarketItem, error)) (bitco.MarketItem, error) {
+	c.State, c.Created = bitco.ClientOrderPending, time.Now()
+	c.Updated = c.Created
+	stored, added, err := store.AddClientOrder(c)
+	if err != nil || !added {
+		risk.Release(o, time.Now())
+		if err != nil {
+			return bitco.MarketItem{}, status.Errorf(codes.Internal, "client order not saved: %v", err)
+		}
+		return answerClientOrder(c, stored)
+	}
+	out := &sendOutcome{}
+	item, err := send(callConf(ctx).WithRecorder(out.record))
+	c.State, c.Updated = out.state(err), time.Now()
+	switch c.State {
+	case bitco.ClientOrderPlaced:
+		c.OrderID = item.Id
+		b, _ := json.Marshal(item)
+		c.Item = string(b)
+	case bitco.ClientOrderRejected:
+		c.Error = err.Error()
+		risk.Release(o, time.Now())
+	case bitco.ClientOrderUnknown:
+		c.Error = err.Error()
+		unknownReservations.Lock()
+		unknownReservations.orders[c.ClientID] = o
+		unknownReservations.Unlock()
+		log.Printf("client order %s: outcome unknown, reconciling: %v\n", c.ClientID, err)
+		err = status.Errorf(codes.Unavailable, "client order %s was sent but its outcome is not known (%v), retry later", c.ClientID, err)
+	}
+	if serr := store.UpdateClientOrder(c); serr != nil {
+		log.Printf("client order %s: %s not saved: %v\n", c.ClientID, c.State, serr)
+	}
+	switch c.State {
+	case bitco.ClientOrderPlaced:
+		trackOrder(c.OrderID, c, c.Created)
+	case bitco.ClientOrderRejected:
+		recordRejection(c)
+	}
+	return item, err
+}
+
+// reconciledItem returns the answer of a client order found among the orders
+// of the account.
+func reconciledItem(c bitco.ClientOrder) string {
+	item := bitco.MarketItem{
+		Success:      "true",
+		Id:           c.OrderID,
+		Rate:         c.Rate,
+		Amount:       c.Amount,
+		OrderType:    c.OrderType,
+		StopLossRate: c.StopLossRate,
+		Pair:         c.Pair,
+	}
+	b, _ := json.Marshal(item)
+	return string(b)
+}
+
+// reconcileJob settles the client orders whose outcome is unknown, and those
+// left pending by a crash, with the open orders and transactions of the
+// account. An order still not found after [orders] unknown_timeout is taken
+// as not placed, and its risk reservation is released.
+func reconcileJob(conf bitco.Config) error {
+	timeout, err := conf.Orders.UnknownTimeoutDuration()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	unsettled, err := store.ClientOrders(bitco.ClientOrderUnknown, time.Time{})
+	if err != nil {
+		return err
+	}
+	pending, err := store.ClientOrders(bitco.ClientOrderPending, time.Time{})
+	if err != nil {
+		return err
+	}
+	for _, o := range pending {
+		if now.Sub(o.Created) > pendingTimeout {
+			unsettled = append(unsettled, o)
+		}
+	}
+	if len(unsettled) == 0 {
+		return nil
+	}
+	oldest := now
+	for _, o := range unsettled {
+		if o.Created.Before(oldest) {
+			oldest = o.Created
+		}
+	}
+	opens, err := exchange.OpenOrders(conf)
+	if err != nil {
+		return fmt.Errorf("open orders: %v", err)
+	}
+	// The fills of an order may look a clock skew older than its submission.
+	txs, err := bitco.TransactionsSince(exchange, conf, oldest.Add(-time.Minute))
+	if err != nil {
+		return fmt.Errorf("transactions: %v", err)
+	}
+	placed, err := store.ClientOrders(bitco.ClientOrderPlaced, oldest.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	taken := map[uint64]bool{}
+	for _, o := range placed {
+		taken[o.OrderID] = true
+	}
+	for _, o := range unsettled {
+		if id := bitco.ReconcileClientOrder(o, opens, txs, taken); id != 0 {
+			taken[id] = true
+			o.State, o.OrderID, o.Error = bitco.ClientOrderPlaced, id, ""
+			o.Item = reconciledItem(o)
+		} else if now.Sub(o.Created) > timeout {
+			o.State = bitco.ClientOrderRejected
+			o.Error = fmt.Sprintf("no order of the account matched within %s", timeout)
+		} else {
+			continue
+		}
+		o.Updated = now
+		if err := store.UpdateClientOrder(o); err != nil {
+			return err
+		}
+		unknownReservations.Lock()
+		if ro, ok := unknownReservations.orders[o.ClientID]; ok {
+			if o.State == bitco.ClientOrderRejected {
+				risk.Release(ro, now)
+			}
+			delete(unknownReservations.orders, o.ClientID)
+		}
+		unknownReservations.Unlock()
+		log.Printf("client order %s reconciled: %s %d\n", o.ClientID, o.State, o.OrderID)
+		if o.State == bitco.ClientOrderPlaced {
+			trackOrder(o.OrderID, o, o.Created)
+		} else {
+			recordRejection(o)
+		}
+	}
+	return nil
+}
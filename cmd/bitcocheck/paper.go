@@ -0,0 +1,55 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"strconv"
+
+	bitco "github.com/hypoballad/bitcocheck"
+)
+
+// exchange takes the orders and answers for the account, the paper engine
+// in paper mode.
+var exchange bitco.Exchange = bitco.LiveExchange{}
+
+var paper *bitco.PaperExchange
+
+// openExchange switches to the paper engine when [paper] is enabled.
+func openExchange() error {
+	if !conf.Paper.Enable {
+		return nil
+	}
+	var err error
+	paper, err = bitco.NewPaperExchange(conf.Paper)
+	if err != nil {
+		return err
+	}
+	exchange = paper
+	log.Printf("paper trading, the orders are filled from %s and not sent to Coincheck\n", conf.Paper.StateFilePath())
+	return nil
+}
+
+// feedPaper passes the new trades of a pair, oldest first, to the paper engine.
+func feedPaper(pair string, trades []*bitco.TradeData) {
+	if paper == nil {
+		return
+	}
+	sorted := make([]*bitco.TradeData, len(trades))
+	copy(sorted, trades)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	for _, trade := range sorted {
+		tm, err := bitco.TradeTime(trade)
+		if err != nil {
+			continue
+		}
+		amount, err := strconv.ParseFloat(trade.Amount, 64)
+		if err != nil {
+			continue
+		}
+		if err := paper.Trade(pair, trade.RateV2, amount, tm); err != nil {
+			log.Printf("paper trade error %v\n", err)
+		}
+	}
+}
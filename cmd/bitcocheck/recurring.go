@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"github.com/robfig/cron/v3"
+	"github.com/rs/xid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recurringSyncSpec How often the scheduled recurring orders are matched
+// with the store, to pick up those added or cancelled on other servers.
+const recurringSyncSpec = "@every 1m"
+
+// recurringMu guards recurringEntries.
+var recurringMu sync.Mutex
+
+// recurringCron runs the recurring orders, with the other cron jobs.
+var recurringCron *cron.Cron
+
+// recurringEntries are the cron entries of the scheduled recurring orders by
+// ID.
+var recurringEntries = map[string]cron.EntryID{}
+
+// scheduleRecurring runs an order on its schedule, unless it already is.
+func scheduleRecurring(o bitco.RecurringOrder) error {
+	recurringMu.Lock()
+	defer recurringMu.Unlock()
+	if _, ok := recurringEntries[o.ID]; ok {
+		return nil
+	}
+	id := o.ID
+	entry, err := recurringCron.AddFunc(o.Schedule, func() {
+		runRecurring(id)
+	})
+	if err != nil {
+		return err
+	}
+	recurringEntries[o.ID] = entry
+	return nil
+}
+
+func unscheduleRecurring(id string) {
+	recurringMu.Lock()
+	defer recurringMu.Unlock()
+	if entry, ok := recurringEntries[id]; ok {
+		recurringCron.Remove(entry)
+		delete(recurringEntries, id)
+	}
+}
+
+// nextRun returns when an order runs next, zero when it is not scheduled.
+func nextRun(id string) time.Time {
+	recurringMu.Lock()
+	defer recurringMu.Unlock()
+	entry, ok := recurringEntries[id]
+	if !ok {
+		return time.Time{}
+	}
+	return recurringCron.Entry(entry).Next
+}
+
+// syncRecurring schedules the active recurring orders of the store and
+// unschedules the cancelled ones.
+func syncRecurring() error {
+	orders, err := store.RecurringOrders()
+	if err != nil {
+		return err
+	}
+	for _, o := range orders {
+		if o.State != bitco.RecurringActive {
+			unscheduleRecurring(o.ID)
+			continue
+		}
+		if err := scheduleRecurring(o); err != nil {
+			log.Printf("recurring order %s not scheduled: %v\n", o.ID, err)
+		}
+	}
+	return nil
+}
+
+// startRecurring saves the recurring orders of the config, cancels those
+// removed from it, and schedules every active recurring order on c.
+func startRecurring(c *cron.Cron) error {
+	recurringCron = c
+	now := time.Now()
+	stored, err := store.RecurringOrders()
+	if err != nil {
+		return err
+	}
+	byID := map[string]bitco.RecurringOrder{}
+	for _, o := range stored {
+		byID[o.ID] = o
+	}
+	names := map[string]bool{}
+	for _, rc := range conf.Recurring {
+		if rc.Name == "" {
+			return fmt.Errorf("[[recurring]] without a name")
+		}
+		if names[rc.Name] {
+			return fmt.Errorf("[[recurring]] %s defined twice", rc.Name)
+		}
+		names[rc.Name] = true
+		o := rc.Order(now)
+		if err := o.Validate(); err != nil {
+			return fmt.Errorf("[[recurring]] %s: %v", rc.Name, err)
+		}
+		if _, err := cron.ParseStandard(o.Schedule); err != nil {
+			return fmt.Errorf("[[recurring]] %s schedule: %v", rc.Name, err)
+		}
+		if old, ok := byID[o.ID]; ok {
+			o.Created = old.Created
+			if sameRecurring(old, o) {
+				continue
+			}
+		}
+		if err := store.SaveRecurringOrder(o); err != nil {
+			return err
+		}
+	}
+	for _, o := range stored {
+		if o.Source == bitco.RecurringFromConfig && o.State == bitco.RecurringActive && !names[o.Name] {
+			o.State, o.Updated = bitco.RecurringCancelled, now
+			if err := store.SaveRecurringOrder(o); err != nil {
+				return err
+			}
+			log.Printf("recurring order %s removed from the config, cancelled\n", o.ID)
+		}
+	}
+	return syncRecurring()
+}
+
+// sameRecurring tells whether two versions of an order have the same
+// settings and state.
+func sameRecurring(a, b bitco.RecurringOrder) bool {
+	return a.Pair == b.Pair && a.Side == b.Side && a.Amount == b.Amount && a.Schedule == b.Schedule &&
+		a.LimitRate == b.LimitRate && a.State == b.State
+}
+
+// runRecurring runs a recurring order once and records the run. A run
+// already sent by another server sharing the store is left to it.
+func runRecurring(id string) {
+	now := time.Now()
+	o, ok, err := store.RecurringOrder(id)
+	if err != nil {
+		log.Printf("recurring order %s: %v\n", id, err)
+		return
+	}
+	if !ok || o.State != bitco.RecurringActive {
+		return
+	}
+	clientID := bitco.RecurringClientID(o, now)
+	if _, ok, err := store.ClientOrder(clientID); err == nil && ok {
+		return
+	}
+	run := executeRecurring(o, bitco.RecurringRun{RecurringID: o.ID, Time: now, ClientID: clientID})
+	recurringRuns.WithLabelValues(o.ID, run.Outcome).Inc()
+	switch run.Outcome {
+	case bitco.RunPlaced:
+		log.Printf("recurring order %s: %s %s %s placed, order %d\n", o.ID, run.OrderType, run.Amount, o.Pair, run.OrderID)
+	default:
+		log.Printf("recurring order %s %s: %s\n", o.ID, run.Outcome, run.Reason)
+	}
+	if err := store.AddRecurringRun(run); err != nil {
+		log.Printf("recurring order %s: run not saved: %v\n", o.ID, err)
+	}
+}
+
+// recurringAudit The audit params of the order of a run.
+type recurringAudit struct {
+	RecurringID   string `json:"recurring_id"`
+	Pair          string `json:"pair"`
+	OrderType     string `json:"order_type"`
+	Rate          string `json:"rate,omitempty"`
+	Amount        string `json:"amount"`
+	ClientOrderID string `json:"client_order_id"`
+}
+
+// executeRecurring sends the order of a run, unless its rate is past the
+// limit rate, and returns the run with its outcome. The send is recorded in
+// the audit log.
+func executeRecurring(o bitco.RecurringOrder, run bitco.RecurringRun) bitco.RecurringRun {
+	pair, err := bitco.ParsePair(o.Pair)
+	if err != nil {
+		run.Outcome, run.Reason = bitco.RunFailed, err.Error()
+		return run
+	}
+	var ask, bid float64
+	if o.NeedsRate() {
+		ticker, err := bitco.Tickercc(conf, pair)
+		if err != nil {
+			run.Outcome, run.Reason = bitco.RunFailed, fmt.Sprintf("ticker: %v", err)
+			return run
+		}
+		ask, bid = float64(ticker.Ask), float64(ticker.Bid)
+	}
+	t, rate, amount, skip := o.Plan(ask, bid)
+	run.OrderType, run.Rate, run.Amount = t.String(), rate, amount
+	if skip != "" {
+		run.Outcome, run.Reason = bitco.RunSkipped, skip
+		return run
+	}
+	ro := bitco.RiskOrder{Pair: o.Pair, Type: t, Funds: o.Amount}
+	if t != bitco.MarketBuy {
+		if ro, err = limitOrder(pair, t, rate, amount); err != nil {
+			run.Outcome, run.Reason = bitco.RunFailed, err.Error()
+			return run
+		}
+	}
+	c := bitco.ClientOrder{ClientID: run.ClientID, Pair: o.Pair, OrderType: t.String(), Rate: rate, Amount: amount}
+	rec := &upstreamRecorder{}
+	item, err := placeOrder(context.WithValue(context.Background(), recorderKey{}, rec), c, ro, func(cc bitco.Config) (bitco.MarketItem, error) {
+		if t == bitco.MarketBuy {
+			return exchange.MarketBuy(cc, pair, uint32(o.Amount))
+		}
+		return exchange.LimitOrder(cc, pair, t, rate, amount, "")
+	})
+	params := recurringAudit{RecurringID: o.ID, Pair: o.Pair, OrderType: t.String(), Rate: rate, Amount: amount, ClientOrderID: run.ClientID}
+	auditJob(bitco.AuditEntry{Caller: "recurring", Method: "RecurringOrder", Params: auditJSON(params)}, rec, &item, err)
+	switch {
+	case err == nil:
+		run.Outcome, run.OrderID = bitco.RunPlaced, item.Id
+	case status.Code(err) == codes.Unavailable:
+		run.Outcome, run.Reason = bitco.RunUnknown, err.Error()
+	default:
+		run.Outcome, run.Reason = bitco.RunFailed, err.Error()
+	}
+	return run
+}
+
+// recurringItem returns the RPC view of a recurring order.
+func recurringItem(o bitco.RecurringOrder) *bitco.RecurringOrderItem {
+	item := &bitco.RecurringOrderItem{
+		Id:       o.ID,
+		Name:     o.Name,
+		Pair:     o.Pair,
+		Side:     o.Side,
+		Amount:   strconv.FormatFloat(o.Amount, 'f', -1, 64),
+		Schedule: o.Schedule,
+		State:    o.State,
+		Source:   o.Source,
+		Created:  uint64(o.Created.Unix()),
+		Updated:  uint64(o.Updated.Unix()),
+	}
+	if o.LimitRate != 0 {
+		item.LimitRate = strconv.FormatFloat(o.LimitRate, 'f', -1, 64)
+	}
+	if next := nextRun(o.ID); !next.IsZero() {
+		item.NextRun = uint64(next.Unix())
+	}
+	return item
+}
+
+func (s server) AddRecurringOrder(ctx context.Context, in *bitco.RecurringOrderParams) (*bitco.RecurringOrderItem, error) {
+	pair, err := parsePair(in.Pair)
+	if err != nil {
+		return &bitco.RecurringOrderItem{}, err
+	}
+	now := time.Now()
+	o := bitco.RecurringOrder{
+		ID:       xid.New().String(),
+		Name:     in.Name,
+		Pair:     pair.String(),
+		Side:     in.Side,
+		Schedule: in.Schedule,
+		State:    bitco.RecurringActive,
+		Source:   bitco.RecurringFromRPC,
+		Created:  now,
+		Updated:  now,
+	}
+	if o.Amount, err = strconv.ParseFloat(in.Amount, 64); err != nil {
+		return &bitco.RecurringOrderItem{}, status.Errorf(codes.InvalidArgument, "invalid amount %q", in.Amount)
+	}
+	if in.LimitRate != "" {
+		if o.LimitRate, err = strconv.ParseFloat(in.LimitRate, 64); err != nil {
+			return &bitco.RecurringOrderItem{}, status.Errorf(codes.InvalidArgument, "invalid limit rate %q", in.LimitRate)
+		}
+	}
+	if err := o.Validate(); err != nil {
+		return &bitco.RecurringOrderItem{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := cron.ParseStandard(o.Schedule); err != nil {
+		return &bitco.RecurringOrderItem{}, status.Errorf(codes.InvalidArgument, "schedule %q: %v", o.Schedule, err)
+	}
+	if err := store.SaveRecurringOrder(o); err != nil {
+		return &bitco.RecurringOrderItem{}, err
+	}
+	if err := scheduleRecurring(o); err != nil {
+		return &bitco.RecurringOrderItem{}, status.Errorf(codes.Internal, "recurring order %s saved but not scheduled: %v", o.ID, err)
+	}
+	log.Printf("recurring order %s: %s %v JPY of %s on %q\n", o.ID, o.Side, o.Amount, o.Pair, o.Schedule)
+	return recurringItem(o), nil
+}
+
+func (s server) CancelRecurringOrder(ctx context.Context, in *bitco.RecurringOrderParam) (*bitco.RecurringOrderItem, error) {
+	o, ok, err := store.RecurringOrder(in.Id)
+	if err != nil {
+		return &bitco.RecurringOrderItem{}, err
+	}
+	if !ok {
+		return &bitco.RecurringOrderItem{}, status.Errorf(codes.NotFound, "no recurring order %s", in.Id)
+	}
+	if o.Source == bitco.RecurringFromConfig {
+		return &bitco.RecurringOrderItem{}, status.Errorf(codes.FailedPrecondition, "recurring order %s is defined in the config, remove it there", in.Id)
+	}
+	if o.State != bitco.RecurringActive {
+		return &bitco.RecurringOrderItem{}, status.Errorf(codes.FailedPrecondition, "recurring order %s is %s", in.Id, o.State)
+	}
+	o.State, o.Updated = bitco.RecurringCancelled, time.Now()
+	if err := store.SaveRecurringOrder(o); err != nil {
+		return &bitco.RecurringOrderItem{}, err
+	}
+	unscheduleRecurring(o.ID)
+	return recurringItem(o), nil
+}
+
+func (s server) ListRecurringOrders(ctx context.Context, in *bitco.Empty) (*bitco.RecurringOrderList, error) {
+	orders, err := store.RecurringOrders()
+	if err != nil {
+		return &bitco.RecurringOrderList{}, err
+	}
+	list := &bitco.RecurringOrderList{}
+	for _, o := range orders {
+		list.Orders = append(list.Orders, recurringItem(o))
+	}
+	return list, nil
+}
+
+func (s server) RecurringOrderRuns(ctx context.Context, in *bitco.RecurringRunsParam) (*bitco.RecurringRunList, error) {
+	q := bitco.RecurringRunQuery{RecurringID: in.Id, Limit: int(in.Limit)}
+	if q.Limit == 0 {
+		q.Limit = 100
+	}
+	runs, err := store.RecurringRuns(q)
+	if err != nil {
+		return &bitco.RecurringRunList{}, err
+	}
+	list := &bitco.RecurringRunList{}
+	for _, r := range runs {
+		list.Runs = append(list.Runs, &bitco.RecurringRunItem{
+			Id:            r.ID,
+			RecurringId:   r.RecurringID,
+			Outcome:       r.Outcome,
+			Reason:        r.Reason,
+			OrderType:     r.OrderType,
+			Rate:          r.Rate,
+			Amount:        r.Amount,
+			ClientOrderId: r.ClientID,
+			OrderId:       r.OrderID,
+			Time:          uint64(r.Time.Unix()),
+		})
+	}
+	return list, nil
+}
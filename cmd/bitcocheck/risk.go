@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var risk *bitco.RiskEngine
+
+// limitOrder returns the risk order of a limit order, whose rate and amount
+// must be numbers.
+func limitOrder(pair bitco.Pair, orderType bitco.OrderType, rate, amount string) (bitco.RiskOrder, error) {
+	o := bitco.RiskOrder{Pair: pair.String(), Type: orderType}
+	var err error
+	if o.Rate, err = strconv.ParseFloat(rate, 64); err != nil || o.Rate <= 0 {
+		return o, status.Errorf(codes.InvalidArgument, "invalid rate %q", rate)
+	}
+	if o.Amount, err = strconv.ParseFloat(amount, 64); err != nil || o.Amount <= 0 {
+		return o, status.Errorf(codes.InvalidArgument, "invalid amount %q", amount)
+	}
+	return o, nil
+}
+
+// riskStatus maps a risk violation onto a gRPC status: ResourceExhausted for
+// the order rate, FailedPrecondition for the other rules.
+func riskStatus(err error) error {
+	v, ok := err.(*bitco.RiskViolation)
+	if !ok {
+		return err
+	}
+	if v.Rule == bitco.RuleOrdersPerMinute {
+		return status.Error(codes.ResourceExhausted, v.Error())
+	}
+	return status.Error(codes.FailedPrecondition, v.Error())
+}
+
+// placeOrder sends an order unless trading is halted or the order fails the
+// risk checks, and tracks it once placed. A refused order is added to the
+// order events as rejected. An order with a client order ID is sent once, the
+// retries of its submission get the outcome of the first one.
+func placeOrder(ctx context.Context, c bitco.ClientOrder, o bitco.RiskOrder, send func(bitco.Config) (bitco.MarketItem, error)) (bitco.MarketItem, error) {
+	if c.ClientID != "" {
+		if len(c.ClientID) > maxClientIDLen {
+			return bitco.MarketItem{}, status.Errorf(codes.InvalidArgument, "client order id longer than %d bytes", maxClientIDLen)
+		}
+		stored, ok, err := store.ClientOrder(c.ClientID)
+		if err != nil {
+			return bitco.MarketItem{}, status.Errorf(codes.Internal, "client order lookup error: %v", err)
+		}
+		if ok {
+			return answerClientOrder(c, stored)
+		}
+	}
+	if err := haltedError(); err != nil {
+		riskRejections.WithLabelValues("halted").Inc()
+		log.Printf("order rejected %s %s: trading is halted\n", o.Type, o.Pair)
+		rejectOrder(c, err)
+		return bitco.MarketItem{}, err
+	}
+	if err := risk.Allow(o, time.Now()); err != nil {
+		if v, ok := err.(*bitco.RiskViolation); ok {
+			riskRejections.WithLabelValues(v.Rule).Inc()
+			defer haltOnBreach(v)
+		}
+		log.Printf("order rejected %s %s: %v\n", o.Type, o.Pair, err)
+		rejectOrder(c, err)
+		return bitco.MarketItem{}, riskStatus(err)
+	}
+	if c.ClientID != "" {
+		return sendClientOrder(ctx, c, o, send)
+	}
+	item, err := send(callConf(ctx))
+	if err != nil {
+		risk.Release(o, time.Now())
+		rejectOrder(c, err)
+		return item, err
+	}
+	trackOrder(item.Id, c, time.Now())
+	return item, nil
+}
+
+// observeTrades passes the newest trade price of a pair to the risk checks.
+func observeTrades(pair string, trades []*bitco.TradeData) {
+	var newest *bitco.TradeData
+	for _, trade := range trades {
+		if newest == nil || trade.ID > newest.ID {
+			newest = trade
+		}
+	}
+	if newest == nil {
+		return
+	}
+	tm, err := bitco.TradeTime(newest)
+	if err != nil {
+		return
+	}
+	risk.UpdatePrice(pair, newest.RateV2, tm)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+)
+
+const tsLayout = "2006-01-02 15:04:05"
+
+// rollup folds tickhist into the 1m table, 1m into 5m and so on. The newest
+// bucket of each table is recomputed because it may have been partial.
+func rollup(store bitco.Store, conf bitco.Config) error {
+	pairs, err := conf.Ticker.PairList()
+	if err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		source := "raw"
+		for _, res := range bitco.Resolutions {
+			from, err := store.LastBar(pair.String(), res.Name)
+			if err != nil {
+				return err
+			}
+			bars, err := store.Bars(pair.String(), source, from)
+			if err != nil {
+				return err
+			}
+			if err := store.SaveBars(pair.String(), res.Name, bitco.RollupBars(bars, res.Interval)); err != nil {
+				return err
+			}
+			source = res.Name
+		}
+	}
+	return nil
+}
+
+// prune deletes the rows that are older than the configured retention.
+func prune(store bitco.Store, conf bitco.Config) error {
+	names := []string{"raw"}
+	for _, res := range bitco.Resolutions {
+		names = append(names, res.Name)
+	}
+	now := time.Now()
+	for _, name := range names {
+		retention, err := conf.Ticker.Retention.Lookup(name)
+		if err != nil {
+			return err
+		}
+		if retention == 0 {
+			continue
+		}
+		if err := store.Prune(name, now.Add(-retention)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rollupJob(store bitco.Store, conf bitco.Config) error {
+	if err := rollup(store, conf); err != nil {
+		return err
+	}
+	return prune(store, conf)
+}
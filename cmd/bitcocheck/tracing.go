@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/semconv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataSupplier reads the trace context a client sent in the metadata.
+type metadataSupplier struct {
+	md metadata.MD
+}
+
+func (s metadataSupplier) Get(key string) string {
+	if v := s.md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (s metadataSupplier) Set(key, value string) {
+	s.md.Set(key, value)
+}
+
+// startRPCSpan starts the server span of an RPC, continuing the trace of the
+// client when it sent one. The Coincheck requests of the RPC are its children.
+func startRPCSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = propagation.ExtractHTTP(ctx, global.Propagators(), metadataSupplier{md: md.Copy()})
+	}
+	service, method := coincheckService, strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		service, method = method[:i], method[i+1:]
+	}
+	return global.Tracer(bitco.TracerName).Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.RPCSystemGRPC, semconv.RPCServiceKey.String(service), semconv.RPCMethodKey.String(method)),
+	)
+}
+
+func endRPCSpan(span trace.Span, err error) {
+	if err != nil {
+		s := status.Convert(err)
+		span.SetStatus(codes.Code(s.Code()), s.Message())
+	}
+	span.End()
+}
+
+func tracingUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := startRPCSpan(ctx, info.FullMethod)
+	if r, ok := req.(interface{ GetPair() string }); ok && r.GetPair() != "" {
+		span.SetAttributes(bitco.PairKey.String(r.GetPair()))
+	}
+	resp, err := handler(ctx, req)
+	endRPCSpan(span, err)
+	return resp, err
+}
+
+func tracingStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := startRPCSpan(ss.Context(), info.FullMethod)
+	err := handler(srv, ctxStream{ServerStream: ss, ctx: ctx})
+	endRPCSpan(span, err)
+	return err
+}
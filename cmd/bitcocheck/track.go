@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// trackMu serializes the changes of the tracked orders.
+var trackMu sync.Mutex
+
+// settleTimeout How long an order missing from the open orders, without the
+// fills of its amount, is waited for before it is taken as cancelled.
+const settleTimeout = 2 * time.Minute
+
+// fillsPolled When the last poll of trackJob started. The fills before it,
+// less settleTimeout for the lag of the exchange, are already saved.
+var fillsPolled time.Time
+
+// trackOrder starts following an order placed through the server.
+func trackOrder(orderID uint64, c bitco.ClientOrder, created time.Time) {
+	if orderID == 0 {
+		return
+	}
+	trackMu.Lock()
+	defer trackMu.Unlock()
+	if _, ok, err := store.TrackedOrder(orderID); err != nil || ok {
+		return
+	}
+	o := bitco.TrackedOrder{
+		OrderID:   orderID,
+		ClientID:  c.ClientID,
+		Pair:      c.Pair,
+		OrderType: c.OrderType,
+		Rate:      c.Rate,
+		Amount:    c.Amount,
+		Status:    bitco.OrderNew,
+		Filled:    "0",
+		Created:   created,
+		Updated:   created,
+	}
+	tr := &bitco.OrderTransition{OrderID: orderID, ClientID: c.ClientID, To: bitco.OrderNew, Filled: "0", Time: created}
+	if err := store.SaveTrackedOrder(o, tr); err != nil {
+		log.Printf("order %d not tracked: %v\n", orderID, err)
+		return
+	}
+	orderEvents.notify()
+}
+
+// trackJob polls the open orders and the transactions of the account, keeps
+// the fills and moves the tracked orders through their statuses. The
+// transactions are paged back to the oldest active order, or to the last
+// poll.
+func trackJob(conf bitco.Config) error {
+	trackMu.Lock()
+	defer trackMu.Unlock()
+	active, err := store.TrackedOrders(bitco.TrackedOrderQuery{Statuses: bitco.ActiveOrderStatuses})
+	if err != nil || len(active) == 0 {
+		return err
+	}
+	now := time.Now()
+	since := now
+	for _, o := range active {
+		if o.Created.Before(since) {
+			since = o.Created
+		}
+	}
+	if polled := fillsPolled.Add(-settleTimeout); polled.After(since) {
+		since = polled
+	}
+	opens, err := exchange.OpenOrders(conf)
+	if err != nil {
+		return fmt.Errorf("open orders: %v", err)
+	}
+	txs, err := bitco.TransactionsSince(exchange, conf, since)
+	if err != nil {
+		return fmt.Errorf("transactions: %v", err)
+	}
+	fills, err := bitco.TransactionFills(txs)
+	if err != nil {
+		return err
+	}
+	if err := store.SaveFills(fills); err != nil {
+		return err
+	}
+	fillsPolled = now
+	open := map[uint64]bool{}
+	for _, o := range opens.Orders {
+		open[uint64(o.Id)] = true
+	}
+	for _, o := range active {
+		orderFills, err := store.Fills(o.OrderID)
+		if err != nil {
+			return err
+		}
+		next, tr, changed := o.Poll(open[o.OrderID], orderFills, now, settleTimeout)
+		if !changed {
+			continue
+		}
+		if err := store.SaveTrackedOrder(next, tr); err != nil {
+			return err
+		}
+		if tr != nil {
+			orderEvents.notify()
+			log.Printf("order %d %s -> %s, filled %s\n", o.OrderID, tr.From, tr.To, tr.Filled)
+		}
+	}
+	return nil
+}
+
+// orderItem returns the RPC view of a tracked order.
+func orderItem(o bitco.TrackedOrder, history []bitco.OrderTransition) *bitco.OrderItem {
+	item := &bitco.OrderItem{
+		Id:            o.OrderID,
+		ClientOrderId: o.ClientID,
+		Pair:          o.Pair,
+		OrderType:     o.OrderType,
+		Rate:          o.Rate,
+		Amount:        o.Amount,
+		Status:        o.Status,
+		Filled:        o.Filled,
+		Created:       uint64(o.Created.Unix()),
+		Updated:       uint64(o.Updated.Unix()),
+	}
+	for _, t := range history {
+		item.History = append(item.History, &bitco.OrderTransitionItem{From: t.From, To: t.To, Filled: t.Filled, Time: uint64(t.Time.Unix())})
+	}
+	return item
+}
+
+func (s server) GetOrder(ctx context.Context, in *bitco.GetOrderParam) (*bitco.OrderItem, error) {
+	id := in.Id
+	if id == 0 && in.ClientOrderId != "" {
+		c, ok, err := store.ClientOrder(in.ClientOrderId)
+		if err != nil {
+			return &bitco.OrderItem{}, err
+		}
+		if !ok {
+			return &bitco.OrderItem{}, status.Errorf(codes.NotFound, "no order of client order id %s", in.ClientOrderId)
+		}
+		if c.State != bitco.ClientOrderPlaced {
+			return &bitco.OrderItem{}, status.Errorf(codes.NotFound, "client order %s is %s", in.ClientOrderId, c.State)
+		}
+		id = c.OrderID
+	}
+	if id == 0 {
+		return &bitco.OrderItem{}, status.Error(codes.InvalidArgument, "an id or a client order id is needed")
+	}
+	o, ok, err := store.TrackedOrder(id)
+	if err != nil {
+		return &bitco.OrderItem{}, err
+	}
+	if !ok {
+		return &bitco.OrderItem{}, status.Errorf(codes.NotFound, "order %d is not tracked", id)
+	}
+	history, err := store.OrderTransitions(bitco.TransitionQuery{OrderID: id})
+	if err != nil {
+		return &bitco.OrderItem{}, err
+	}
+	return orderItem(o, history), nil
+}
+
+func (s server) ListOrders(ctx context.Context, in *bitco.ListOrdersParam) (*bitco.OrderList, error) {
+	q := bitco.TrackedOrderQuery{Statuses: in.Status, Limit: int(in.Limit)}
+	for _, st := range in.Status {
+		if !validOrderStatus(st) {
+			return &bitco.OrderList{}, status.Errorf(codes.InvalidArgument, "unknown order status %q", st)
+		}
+	}
+	if in.Pair != "" {
+		pair, err := parsePair(in.Pair)
+		if err != nil {
+			return &bitco.OrderList{}, err
+		}
+		q.Pair = pair.String()
+	}
+	if q.Limit == 0 {
+		q.Limit = 100
+	}
+	orders, err := store.TrackedOrders(q)
+	if err != nil {
+		return &bitco.OrderList{}, err
+	}
+	list := &bitco.OrderList{}
+	for _, o := range orders {
+		list.Orders = append(list.Orders, orderItem(o, nil))
+	}
+	return list, nil
+}
+
+func validOrderStatus(s string) bool {
+	for _, st := range bitco.OrderStatuses {
+		if s == st {
+			return true
+		}
+	}
+	return false
+}
@@ -15,6 +15,12 @@ import (
 
 var addr = flag.String("addr", "localhost:50051", "server address")
 var modeDebug = flag.Bool("debug", false, "debug mode")
+var useTLS = flag.Bool("tls", false, "connect with tls, verifying the server with the system roots unless -tls-ca is set")
+var tlsCA = flag.String("tls-ca", "", "CA file of the server certificate")
+var tlsCert = flag.String("tls-cert", "", "client certificate file for mutual tls")
+var tlsKey = flag.String("tls-key", "", "client key file for mutual tls")
+var tlsServerName = flag.String("tls-server-name", "", "server name expected in the server certificate")
+var token = flag.String("token", "", "API token or JWT, $BITCOCHECK_TOKEN when not set")
 
 // func CheckAll(c bitco.CoincheckClient, ctx context.Context) error {
 // 	log.Println("-- coin check ticker --")
@@ -33,13 +39,34 @@ var modeDebug = flag.Bool("debug", false, "debug mode")
 // 	return nil
 // }
 
+// dial connects to bitcocheck, with tls when one of the -tls flags is set,
+// sending the API token with every RPC.
+func dial(addr string) (*grpc.ClientConn, error) {
+	creds, err := bitco.DialCredentials(bitco.TLSConfig{
+		Enable:     *useTLS,
+		CA:         *tlsCA,
+		Cert:       *tlsCert,
+		Key:        *tlsKey,
+		ServerName: *tlsServerName,
+	}, addr)
+	if err != nil {
+		return nil, err
+	}
+	t := *token
+	if t == "" {
+		t = os.Getenv("BITCOCHECK_TOKEN")
+	}
+	opts := append(bitco.TokenCredentials(t), creds, grpc.WithBlock())
+	return grpc.Dial(addr, opts...)
+}
+
 func ticker(conn *grpc.ClientConn) {
 	c := bitco.NewCoincheckClient(conn)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
 	log.Println("-- coin check ticker --")
-	in := &bitco.Empty{}
+	in := &bitco.TickerParams{Pair: bitco.Btcjpy.String()}
 	item, err := c.Ticker(ctx, in)
 	if err != nil {
 		log.Fatalln(err)
@@ -79,8 +106,8 @@ func orderBooks(conn *grpc.ClientConn) {
 	defer cancel()
 
 	log.Println("-- coin check order books --")
-	in := &bitco.Empty{}
-	item, err := c.OrderBooks(ctx, in)
+	in := &bitco.OrderBooksParams{Pair: bitco.Btcjpy.String()}
+	item, err := c.OrderBooksV2(ctx, in)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -136,7 +163,7 @@ func TickHist(conn *grpc.ClientConn) {
 
 func main() {
 	flag.Parse()
-	conn, err := grpc.Dial(*addr, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := dial(*addr)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	bitco "github.com/hypoballad/bitcocheck"
+	"google.golang.org/grpc"
+)
+
+var addr = flag.String("addr", "localhost:50051", "server address")
+var dataset = flag.String("dataset", "ticks", "ticks, candles or transactions")
+var format = flag.String("format", "csv", "csv, jsonl or parquet")
+var pair = flag.String("pair", "btc_jpy", "trading pair")
+var interval = flag.String("interval", "1m", "candle interval")
+var from = flag.String("from", "", "first time to export, 2006-01-02 or RFC3339")
+var to = flag.String("to", "", "time the export ends before, 2006-01-02 or RFC3339")
+var output = flag.String("o", "-", "output file name, - for stdout")
+var timeout = flag.Duration("timeout", 5*time.Minute, "export timeout")
+var useTLS = flag.Bool("tls", false, "connect with tls, verifying the server with the system roots unless -tls-ca is set")
+var tlsCA = flag.String("tls-ca", "", "CA file of the server certificate")
+var tlsCert = flag.String("tls-cert", "", "client certificate file for mutual tls")
+var tlsKey = flag.String("tls-key", "", "client key file for mutual tls")
+var tlsServerName = flag.String("tls-server-name", "", "server name expected in the server certificate")
+var token = flag.String("token", "", "API token or JWT, $BITCOCHECK_TOKEN when not set")
+
+// dial connects to bitcocheck, with tls when one of the -tls flags is set,
+// sending the API token with every RPC.
+func dial(addr string) (*grpc.ClientConn, error) {
+	creds, err := bitco.DialCredentials(bitco.TLSConfig{
+		Enable:     *useTLS,
+		CA:         *tlsCA,
+		Cert:       *tlsCert,
+		Key:        *tlsKey,
+		ServerName: *tlsServerName,
+	}, addr)
+	if err != nil {
+		return nil, err
+	}
+	t := *token
+	if t == "" {
+		t = os.Getenv("BITCOCHECK_TOKEN")
+	}
+	opts := append(bitco.TokenCredentials(t), creds, grpc.WithBlock())
+	return grpc.Dial(addr, opts...)
+}
+
+// parseTime returns the unix time of a local date or an RFC3339 time, 0 for "".
+func parseTime(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	tm, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		tm, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return uint64(tm.Unix()), nil
+}
+
+func main() {
+	flag.Parse()
+	in := &bitco.ExportParam{Dataset: *dataset, Format: *format, Pair: *pair, Interval: *interval}
+	var err error
+	if in.From, err = parseTime(*from); err != nil {
+		log.Fatalf("invalid from: %v", err)
+	}
+	if in.To, err = parseTime(*to); err != nil {
+		log.Fatalf("invalid to: %v", err)
+	}
+	conn, err := dial(*addr)
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+	defer conn.Close()
+	c := bitco.NewCoincheckClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	stream, err := c.Export(ctx, in)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	w := os.Stdout
+	if *output != "-" {
+		w, err = os.Create(*output)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			log.Fatalln(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		log.Fatalln(err)
+	}
+}
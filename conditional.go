@@ -0,0 +1,222 @@
+package bitcocheck
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Kinds of a conditional order.
+const (
+	// StopLoss Sends its order once the price crosses the trigger against
+	// the position: falls to it for a sell, rises to it for a buy.
+	StopLoss = "stop_loss"
+	// TakeProfit Sends its order once the price crosses the trigger in favour
+	// of the position: rises to it for a sell, falls to it for a buy.
+	TakeProfit = "take_profit"
+	// TrailingStop A stop loss whose trigger follows the best price seen at
+	// the trail distance.
+	TrailingStop = "trailing_stop"
+)
+
+// States of a conditional order.
+const (
+	// ConditionalActive The order watches the price.
+	ConditionalActive = "active"
+	// ConditionalTriggered The order was triggered and its order sent, or is
+	// being sent when it has no OrderID yet.
+	ConditionalTriggered = "triggered"
+	// ConditionalCancelled The order was cancelled, by a client or by the
+	// trigger of the other order of its OCO pair.
+	ConditionalCancelled = "cancelled"
+	// ConditionalFailed The order was triggered but its order was refused.
+	ConditionalFailed = "failed"
+)
+
+// ConditionalOrder An order kept by the server and sent when the price of its
+// pair reaches the trigger. Side is buy or sell. The order sent is a limit
+// order at Rate, or a market order when Rate is empty, whose Amount is then in
+// JPY for a buy. Trigger is the current stop of a trailing stop, which
+// follows Extreme, the best price seen, at Trail. The two orders of an OCO pair
+// share their Group: the trigger of one cancels the other.
+type ConditionalOrder struct {
+	ID       string
+	Group    string
+	Kind     string
+	Pair     string
+	Side     string
+	Trigger  float64
+	Trail    float64
+	Extreme  float64
+	Amount   string
+	Rate     string
+	State    string
+	ClientID string
+	OrderID  uint64
+	Error    string
+	Created  time.Time
+	Updated  time.Time
+}
+
+// ConditionalOrderStore The conditional orders of the server, by ID.
+type ConditionalOrderStore interface {
+	// SaveConditionalOrders saves orders at once, replacing the orders of the
+	// same ID.
+	SaveConditionalOrders(orders ...ConditionalOrder) error
+	// ConditionalOrder returns an order, false when there is none.
+	ConditionalOrder(id string) (ConditionalOrder, bool, error)
+	// ConditionalOrders returns the orders in one of the states, of every
+	// state for none, oldest first.
+	ConditionalOrders(states ...string) ([]ConditionalOrder, error)
+}
+
+// OrderType returns the type of the order sent on the trigger.
+func (o ConditionalOrder) OrderType() OrderType {
+	switch {
+	case o.Side == Buy.String() && o.Rate == "":
+		return MarketBuy
+	case o.Side == Buy.String():
+		return Buy
+	case o.Rate == "":
+		return MarketSell
+	}
+	return Sell
+}
+
+// Validate checks an order before it is saved.
+func (o ConditionalOrder) Validate() error {
+	if o.Side != Buy.String() && o.Side != Sell.String() {
+		return fmt.Errorf("side %q is neither buy nor sell", o.Side)
+	}
+	switch o.Kind {
+	case StopLoss, TakeProfit:
+		if o.Trigger <= 0 {
+			return fmt.Errorf("a %s needs a trigger rate", o.Kind)
+		}
+	case TrailingStop:
+		if o.Trail <= 0 {
+			return fmt.Errorf("a %s needs a trail distance", o.Kind)
+		}
+	default:
+		return fmt.Errorf("unknown kind %q", o.Kind)
+	}
+	amount, err := strconv.ParseFloat(o.Amount, 64)
+	if err != nil || amount <= 0 {
+		return fmt.Errorf("invalid amount %q", o.Amount)
+	}
+	if o.Rate == "" {
+		// The market orders of the API take a whole amount.
+		if amount != math.Trunc(amount) || amount > math.MaxUint32 {
+			return fmt.Errorf("a market order takes a whole amount, not %q; set a rate", o.Amount)
+		}
+		return nil
+	}
+	if rate, err := strconv.ParseFloat(o.Rate, 64); err != nil || rate <= 0 {
+		return fmt.Errorf("invalid rate %q", o.Rate)
+	}
+	return nil
+}
+
+// Check returns the order after a price of its pair, and whether the price
+// triggers it. Only the trigger of a trailing stop moves.
+func (o ConditionalOrder) Check(last float64) (next ConditionalOrder, fire bool) {
+	next = o
+	if o.State != ConditionalActive || last <= 0 {
+		return next, false
+	}
+	sell := o.Side == Sell.String()
+	if o.Kind == TrailingStop {
+		if next.Extreme == 0 || (sell && last > next.Extreme) || (!sell && last < next.Extreme) {
+			next.Extreme = last
+			next.Trigger = last - o.Trail
+			if !sell {
+				next.Trigger = last + o.Trail
+			}
+		}
+	}
+	// A sell stop and a buy take profit fire at or below the trigger.
+	below := sell == (o.Kind != TakeProfit)
+	if below {
+		return next, last <= next.Trigger
+	}
+	return next, last >= next.Trigger
+}
+
+// OCO links two orders of the same pair and side into an OCO pair.
+func OCO(a, b ConditionalOrder) (ConditionalOrder, ConditionalOrder, error) {
+	if a.Pair != b.Pair || a.Side != b.Side {
+		return a, b, fmt.Errorf("the orders of an OCO pair have the same pair and side")
+	}
+	a.Group, b.Group = a.ID, a.ID
+	return a, b, nil
+}
+
+// TriggerConditionals checks the active orders of a pair against its last
+// price. fired are the orders triggered, now in the triggered state, and
+// changed every order to save, the fired ones, the other orders of their OCO
+// pairs, now cancelled, and the trailing stops that moved.
+func TriggerConditionals(orders []ConditionalOrder, pair string, last float64, now time.Time) (changed, fired []ConditionalOrder) {
+	next := make([]ConditionalOrder, len(orders))
+	dirty := make([]bool, len(orders))
+	firedGroups := map[string]string{}
+	for i, o := range orders {
+		next[i] = o
+		if o.Pair != pair || o.State != ConditionalActive {
+			continue
+		}
+		n, fire := o.Check(last)
+		if _, done := firedGroups[o.Group]; fire && o.Group != "" && done {
+			// The other order of the pair fired first.
+			fire = false
+		}
+		if fire {
+			n.State = ConditionalTriggered
+			if o.Group != "" {
+				firedGroups[o.Group] = o.ID
+			}
+		}
+		if n != o {
+			n.Updated = now
+			next[i], dirty[i] = n, true
+		}
+		if fire {
+			fired = append(fired, n)
+		}
+	}
+	for i, o := range next {
+		id, ok := firedGroups[o.Group]
+		if !ok || o.Group == "" || o.ID == id || o.State != ConditionalActive {
+			continue
+		}
+		o.State, o.Error, o.Updated = ConditionalCancelled, fmt.Sprintf("OCO order %s triggered", id), now
+		next[i], dirty[i] = o, true
+	}
+	for i, o := range next {
+		if dirty[i] {
+			changed = append(changed, o)
+		}
+	}
+	return changed, fired
+}
+
+// SettleConditional returns a triggered order without an OrderID after the
+// client order of its send, c, found false when there is none: placed when c
+// was placed, failed when c was rejected. resend tells that the order was
+// never sent, e.g. after a crash between the trigger and the send.
+func SettleConditional(o ConditionalOrder, c ClientOrder, found bool) (next ConditionalOrder, resend bool) {
+	next = o
+	if o.State != ConditionalTriggered || o.OrderID != 0 {
+		return next, false
+	}
+	if !found {
+		return next, true
+	}
+	switch c.State {
+	case ClientOrderPlaced:
+		next.ClientID, next.OrderID, next.Error = c.ClientID, c.OrderID, ""
+	case ClientOrderRejected:
+		next.ClientID, next.State, next.Error = c.ClientID, ConditionalFailed, c.Error
+	}
+	return next, false
+}
@@ -0,0 +1,194 @@
+package bitcocheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConditionalOrderCheck(t *testing.T) {
+	order := func(kind, side string, trigger float64) ConditionalOrder {
+		return ConditionalOrder{ID: "a", Kind: kind, Pair: "btc_jpy", Side: side, Trigger: trigger, Amount: "0.1", Rate: "1000000", State: ConditionalActive}
+	}
+	trail := func(side string, extreme float64) ConditionalOrder {
+		o := order(TrailingStop, side, 0)
+		o.Trail, o.Extreme = 1000, extreme
+		if extreme != 0 {
+			o.Trigger = extreme - 1000
+			if side == "buy" {
+				o.Trigger = extreme + 1000
+			}
+		}
+		return o
+	}
+	tests := []struct {
+		name        string
+		o           ConditionalOrder
+		last        float64
+		wantFire    bool
+		wantTrigger float64
+	}{
+		{name: "sell stop above", o: order(StopLoss, "sell", 900000), last: 950000, wantTrigger: 900000},
+		{name: "sell stop reached", o: order(StopLoss, "sell", 900000), last: 900000, wantFire: true, wantTrigger: 900000},
+		{name: "sell take profit below", o: order(TakeProfit, "sell", 1100000), last: 1000000, wantTrigger: 1100000},
+		{name: "sell take profit reached", o: order(TakeProfit, "sell", 1100000), last: 1100500, wantFire: true, wantTrigger: 1100000},
+		{name: "buy stop reached", o: order(StopLoss, "buy", 1100000), last: 1100000, wantFire: true, wantTrigger: 1100000},
+		{name: "buy take profit reached", o: order(TakeProfit, "buy", 900000), last: 890000, wantFire: true, wantTrigger: 900000},
+		{name: "buy take profit above", o: order(TakeProfit, "buy", 900000), last: 910000, wantTrigger: 900000},
+		{name: "trailing stop starts at the first price", o: trail("sell", 0), last: 30000, wantTrigger: 29000},
+		{name: "trailing stop follows a rise", o: trail("sell", 30000), last: 31000, wantTrigger: 30000},
+		{name: "trailing stop holds on a fall", o: trail("sell", 30000), last: 29500, wantTrigger: 29000},
+		{name: "trailing stop reached", o: trail("sell", 30000), last: 29000, wantFire: true, wantTrigger: 29000},
+		{name: "buy trailing stop follows a fall", o: trail("buy", 30000), last: 28000, wantTrigger: 29000},
+		{name: "buy trailing stop reached", o: trail("buy", 30000), last: 31000, wantFire: true, wantTrigger: 31000},
+		{
+			name: "inactive orders stay",
+			o: func() ConditionalOrder {
+				o := order(StopLoss, "sell", 900000)
+				o.State = ConditionalCancelled
+				return o
+			}(),
+			last:        800000,
+			wantTrigger: 900000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, fire := tt.o.Check(tt.last)
+			if fire != tt.wantFire || next.Trigger != tt.wantTrigger {
+				t.Errorf("Check() = trigger %v, fire %v, want %v, %v", next.Trigger, fire, tt.wantTrigger, tt.wantFire)
+			}
+		})
+	}
+}
+
+func TestConditionalOrderValidate(t *testing.T) {
+	valid := ConditionalOrder{Kind: StopLoss, Pair: "btc_jpy", Side: "sell", Trigger: 900000, Amount: "0.1", Rate: "890000"}
+	tests := []struct {
+		name    string
+		change  func(o *ConditionalOrder)
+		wantErr bool
+	}{
+		{name: "limit stop loss", change: func(o *ConditionalOrder) {}},
+		{name: "market buy of whole JPY", change: func(o *ConditionalOrder) { o.Side, o.Amount, o.Rate = "buy", "10000", "" }},
+		{name: "trailing stop", change: func(o *ConditionalOrder) { o.Kind, o.Trigger, o.Trail = TrailingStop, 0, 1000 }},
+		{name: "unknown side", change: func(o *ConditionalOrder) { o.Side = "short" }, wantErr: true},
+		{name: "unknown kind", change: func(o *ConditionalOrder) { o.Kind = "stop" }, wantErr: true},
+		{name: "no trigger", change: func(o *ConditionalOrder) { o.Trigger = 0 }, wantErr: true},
+		{name: "trailing stop without trail", change: func(o *ConditionalOrder) { o.Kind = TrailingStop }, wantErr: true},
+		{name: "bad amount", change: func(o *ConditionalOrder) { o.Amount = "x" }, wantErr: true},
+		{name: "bad rate", change: func(o *ConditionalOrder) { o.Rate = "-1" }, wantErr: true},
+		{name: "market order of a fraction", change: func(o *ConditionalOrder) { o.Rate = "" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := valid
+			tt.change(&o)
+			if err := o.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTriggerConditionals(t *testing.T) {
+	now := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	stop := ConditionalOrder{ID: "a", Kind: StopLoss, Pair: "btc_jpy", Side: "sell", Trigger: 900000, Amount: "0.1", Rate: "890000", State: ConditionalActive}
+	profit := ConditionalOrder{ID: "b", Kind: TakeProfit, Pair: "btc_jpy", Side: "sell", Trigger: 1100000, Amount: "0.1", Rate: "1100000", State: ConditionalActive}
+	stop, profit, err := OCO(stop, profit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trail := ConditionalOrder{ID: "c", Kind: TrailingStop, Pair: "btc_jpy", Side: "sell", Trail: 50000, Extreme: 1000000, Trigger: 950000, Amount: "1", State: ConditionalActive}
+	other := ConditionalOrder{ID: "d", Kind: StopLoss, Pair: "eth_jpy", Side: "sell", Trigger: 30000, Amount: "1", State: ConditionalActive}
+	orders := []ConditionalOrder{stop, profit, trail, other}
+
+	tests := []struct {
+		name        string
+		last        float64
+		wantChanged map[string]string
+		wantFired   []string
+	}{
+		{name: "nothing moves", last: 1000000, wantChanged: map[string]string{}},
+		{name: "the trailing stop moves", last: 1050000, wantChanged: map[string]string{"c": ConditionalActive}},
+		{
+			name:        "the take profit cancels its stop",
+			last:        1100000,
+			wantChanged: map[string]string{"b": ConditionalTriggered, "a": ConditionalCancelled, "c": ConditionalActive},
+			wantFired:   []string{"b"},
+		},
+		{
+			name:        "the stop cancels its take profit",
+			last:        890000,
+			wantChanged: map[string]string{"a": ConditionalTriggered, "b": ConditionalCancelled, "c": ConditionalTriggered},
+			wantFired:   []string{"a", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, fired := TriggerConditionals(orders, "btc_jpy", tt.last, now)
+			if len(changed) != len(tt.wantChanged) {
+				t.Fatalf("TriggerConditionals() changed %+v, want %v", changed, tt.wantChanged)
+			}
+			for _, o := range changed {
+				if state, ok := tt.wantChanged[o.ID]; !ok || o.State != state || !o.Updated.Equal(now) {
+					t.Errorf("TriggerConditionals() changed %+v, want %v", o, tt.wantChanged)
+				}
+			}
+			if len(fired) != len(tt.wantFired) {
+				t.Fatalf("TriggerConditionals() fired %+v, want %v", fired, tt.wantFired)
+			}
+			for i, o := range fired {
+				if o.ID != tt.wantFired[i] || o.State != ConditionalTriggered {
+					t.Errorf("TriggerConditionals() fired %+v, want %v", o, tt.wantFired)
+				}
+			}
+		})
+	}
+}
+
+func TestSettleConditional(t *testing.T) {
+	triggered := ConditionalOrder{ID: "a", Kind: StopLoss, Pair: "btc_jpy", Side: "sell", Trigger: 900000, Amount: "0.1", State: ConditionalTriggered}
+	tests := []struct {
+		name       string
+		o          ConditionalOrder
+		c          ClientOrder
+		found      bool
+		wantState  string
+		wantID     uint64
+		wantError  string
+		wantResend bool
+	}{
+		{name: "never sent", o: triggered, wantState: ConditionalTriggered, wantResend: true},
+		{name: "being sent", o: triggered, c: ClientOrder{ClientID: "cond-a", State: ClientOrderPending}, found: true, wantState: ConditionalTriggered},
+		{name: "answer lost", o: triggered, c: ClientOrder{ClientID: "cond-a", State: ClientOrderUnknown}, found: true, wantState: ConditionalTriggered},
+		{name: "reconciled", o: triggered, c: ClientOrder{ClientID: "cond-a", State: ClientOrderPlaced, OrderID: 7}, found: true, wantState: ConditionalTriggered, wantID: 7},
+		{name: "rejected", o: triggered, c: ClientOrder{ClientID: "cond-a", State: ClientOrderRejected, Error: "no match"}, found: true, wantState: ConditionalFailed, wantError: "no match"},
+		{
+			name: "settled",
+			o: func() ConditionalOrder {
+				o := triggered
+				o.OrderID = 3
+				return o
+			}(),
+			wantState: ConditionalTriggered,
+			wantID:    3,
+		},
+		{
+			name: "active",
+			o: func() ConditionalOrder {
+				o := triggered
+				o.State = ConditionalActive
+				return o
+			}(),
+			wantState: ConditionalActive,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, resend := SettleConditional(tt.o, tt.c, tt.found)
+			if next.State != tt.wantState || next.OrderID != tt.wantID || next.Error != tt.wantError || resend != tt.wantResend {
+				t.Errorf("SettleConditional() = %s %d %q %v, want %s %d %q %v", next.State, next.OrderID, next.Error, resend, tt.wantState, tt.wantID, tt.wantError, tt.wantResend)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package bitcocheck
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Exchange Where the orders go and where the account is read from: Coincheck
+// itself, or the paper trading engine. conf carries the keys and the trace
+// context of the requests.
+type Exchange interface {
+	MarketBuy(conf Config, pair Pair, amount uint32) (MarketItem, error)
+	MarketSell(conf Config, pair Pair, amount uint32) (MarketItem, error)
+	LimitOrder(conf Config, pair Pair, orderType OrderType, rate, amount, stopLossRate string) (MarketItem, error)
+	OpenOrders(conf Config) (OrdersOpensItem, error)
+	CancelOrder(conf Config, id uint32) (DeleteOrderItem, error)
+	Transactions(conf Config) (OrdersTransactionsItem, error)
+	TransactionsPage(conf Config, page Pagenation) (OrdersTransactionsItem, error)
+	Balance(conf Config) (AccountsBalanceItem, error)
+}
+
+// LiveExchange The Coincheck API.
+type LiveExchange struct{}
+
+func (LiveExchange) MarketBuy(conf Config, pair Pair, amount uint32) (MarketItem, error) {
+	return MarketBuycc(conf, pair, amount)
+}
+
+func (LiveExchange) MarketSell(conf Config, pair Pair, amount uint32) (MarketItem, error) {
+	return MarketSellcc(conf, pair, amount)
+}
+
+func (LiveExchange) LimitOrder(conf Config, pair Pair, orderType OrderType, rate, amount, stopLossRate string) (MarketItem, error) {
+	return LimitOrdercc(conf, pair, orderType, rate, amount, stopLossRate)
+}
+
+func (LiveExchange) OpenOrders(conf Config) (OrdersOpensItem, error) {
+	return ExchangeOrdersOpenscc(conf)
+}
+
+func (LiveExchange) CancelOrder(conf Config, id uint32) (DeleteOrderItem, error) {
+	return DeleteExchangeOrdercc(conf, id)
+}
+
+func (LiveExchange) Transactions(conf Config) (OrdersTransactionsItem, error) {
+	return ExchangeOrdersTransactionscc(conf)
+}
+
+func (LiveExchange) TransactionsPage(conf Config, page Pagenation) (OrdersTransactionsItem, error) {
+	return ExchangeOrdersTransactionsPagecc(conf, page)
+}
+
+func (LiveExchange) Balance(conf Config) (AccountsBalanceItem, error) {
+	return AccountsBalancecc(conf)
+}
+
+// maxTransactionPages bounds how far back TransactionsSince pages.
+const maxTransactionPages = 100
+
+// TransactionsSince pages the transactions of the account, newest first,
+// back to the first one before since. It fails when they reach further back
+// than maxTransactionPages pages.
+func TransactionsSince(ex Exchange, conf Config, since time.Time) (OrdersTransactionsItem, error) {
+	item := OrdersTransactionsItem{Success: true}
+	page := Pagenation{Limit: 100, Order: "desc"}
+	for i := 0; i < maxTransactionPages; i++ {
+		txs, err := ex.TransactionsPage(conf, page)
+		if err != nil {
+			return item, err
+		}
+		for _, t := range txs.Transactions {
+			item.Transactions = append(item.Transactions, t)
+			tm, err := time.Parse(time.RFC3339, t.CreatedAt)
+			if err != nil {
+				return item, err
+			}
+			if tm.Before(since) {
+				return item, nil
+			}
+		}
+		if len(txs.Transactions) < int(page.Limit) {
+			return item, nil
+		}
+		page.StartingAfter = strconv.FormatUint(uint64(txs.Transactions[len(txs.Transactions)-1].Id), 10)
+	}
+	return item, fmt.Errorf("the transactions since %s take more than %d pages", since.Format(time.RFC3339), maxTransactionPages)
+}
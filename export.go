@@ -0,0 +1,176 @@
+package bitcocheck
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// ExportFormat File format of a market data export.
+type ExportFormat int
+
+const (
+	// CSV Comma separated values with a header line.
+	CSV ExportFormat = iota
+	// JSONLines One JSON object per line.
+	JSONLines
+	// Parquet Apache Parquet, snappy compressed.
+	Parquet
+)
+
+func (f ExportFormat) String() string {
+	return [...]string{"csv", "jsonl", "parquet"}[f]
+}
+
+// ParseExportFormat converts a format name into an ExportFormat. An empty name means csv.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch s {
+	case "", "csv":
+		return CSV, nil
+	case "jsonl":
+		return JSONLines, nil
+	case "parquet":
+		return Parquet, nil
+	}
+	return CSV, fmt.Errorf("unknown export format: %s", s)
+}
+
+// TickRecord One row of the ticker history export.
+type TickRecord struct {
+	Pair      string  `json:"pair" parquet:"name=pair, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Timestamp int64   `json:"timestamp" parquet:"name=timestamp, type=INT64"`
+	Last      float64 `json:"last" parquet:"name=last, type=DOUBLE"`
+	Bid       float64 `json:"bid" parquet:"name=bid, type=DOUBLE"`
+	Ask       float64 `json:"ask" parquet:"name=ask, type=DOUBLE"`
+	High      float64 `json:"high" parquet:"name=high, type=DOUBLE"`
+	Low       float64 `json:"low" parquet:"name=low, type=DOUBLE"`
+	Volume    float64 `json:"volume" parquet:"name=volume, type=DOUBLE"`
+}
+
+// CandleRecord One row of the candles export.
+type CandleRecord struct {
+	Pair      string  `json:"pair" parquet:"name=pair, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Interval  string  `json:"interval" parquet:"name=interval, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Timestamp int64   `json:"timestamp" parquet:"name=timestamp, type=INT64"`
+	Open      float64 `json:"open" parquet:"name=open, type=DOUBLE"`
+	High      float64 `json:"high" parquet:"name=high, type=DOUBLE"`
+	Low       float64 `json:"low" parquet:"name=low, type=DOUBLE"`
+	Close     float64 `json:"close" parquet:"name=close, type=DOUBLE"`
+	Volume    float64 `json:"volume" parquet:"name=volume, type=DOUBLE"`
+	Trades    int64   `json:"trades" parquet:"name=trades, type=INT64"`
+}
+
+// TransactionRecord One row of the transactions export. Amounts are kept as
+// the decimal strings returned by the exchange.
+type TransactionRecord struct {
+	ID          int64  `json:"id" parquet:"name=id, type=INT64"`
+	OrderID     int64  `json:"order_id" parquet:"name=order_id, type=INT64"`
+	CreatedAt   string `json:"created_at" parquet:"name=created_at, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Pair        string `json:"pair" parquet:"name=pair, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Side        string `json:"side" parquet:"name=side, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Rate        string `json:"rate" parquet:"name=rate, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Btc         string `json:"btc" parquet:"name=btc, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Jpy         string `json:"jpy" parquet:"name=jpy, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Fee         string `json:"fee" parquet:"name=fee, type=BYTE_ARRAY, convertedtype=UTF8"`
+	FeeCurrency string `json:"fee_currency" parquet:"name=fee_currency, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Liquidity   string `json:"liquidity" parquet:"name=liquidity, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// Exporter Writes records of one of the record types above.
+type Exporter interface {
+	Write(record interface{}) error
+	Close() error
+}
+
+// NewExporter returns an Exporter writing to w. record is a value of the
+// record type, used for the CSV header and the Parquet schema.
+func NewExporter(w io.Writer, format ExportFormat, record interface{}) (Exporter, error) {
+	switch format {
+	case CSV:
+		return newCSVExporter(w, record)
+	case JSONLines:
+		return jsonLinesExporter{enc: json.NewEncoder(w)}, nil
+	case Parquet:
+		schema := reflect.New(reflect.TypeOf(record)).Interface()
+		pw, err := writer.NewParquetWriterFromWriter(w, schema, 1)
+		if err != nil {
+			return nil, err
+		}
+		return parquetExporter{pw: pw}, nil
+	}
+	return nil, fmt.Errorf("unknown export format: %d", format)
+}
+
+type csvExporter struct {
+	w *csv.Writer
+}
+
+func newCSVExporter(w io.Writer, record interface{}) (Exporter, error) {
+	t := reflect.TypeOf(record)
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("record is not a struct: %T", record)
+	}
+	header := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		header = append(header, strings.Split(t.Field(i).Tag.Get("json"), ",")[0])
+	}
+	e := csvExporter{w: csv.NewWriter(w)}
+	if err := e.w.Write(header); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e csvExporter) Write(record interface{}) error {
+	v := reflect.ValueOf(record)
+	row := []string{}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.String:
+			row = append(row, f.String())
+		case reflect.Int64:
+			row = append(row, strconv.FormatInt(f.Int(), 10))
+		case reflect.Float64:
+			row = append(row, strconv.FormatFloat(f.Float(), 'f', -1, 64))
+		default:
+			return fmt.Errorf("unsupported field type: %s", f.Kind())
+		}
+	}
+	return e.w.Write(row)
+}
+
+func (e csvExporter) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonLinesExporter struct {
+	enc *json.Encoder
+}
+
+func (e jsonLinesExporter) Write(record interface{}) error {
+	return e.enc.Encode(record)
+}
+
+func (e jsonLinesExporter) Close() error {
+	return nil
+}
+
+type parquetExporter struct {
+	pw *writer.ParquetWriter
+}
+
+func (e parquetExporter) Write(record interface{}) error {
+	return e.pw.Write(record)
+}
+
+func (e parquetExporter) Close() error {
+	return e.pw.WriteStop()
+}
@@ -0,0 +1,79 @@
+package bitcocheck
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewExporter(t *testing.T) {
+	records := []TickRecord{
+		{Pair: "btc_jpy", Timestamp: 1584266400, Last: 1000000, Bid: 999990.5, Ask: 1000010, High: 1010000, Low: 990000, Volume: 1234.5},
+		{Pair: "btc_jpy", Timestamp: 1584266410, Last: 1000005, Bid: 1000000, Ask: 1000015, High: 1010000, Low: 990000, Volume: 1234.6},
+	}
+	type args struct {
+		format ExportFormat
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "csv export test",
+			args: args{format: CSV},
+			want: "pair,timestamp,last,bid,ask,high,low,volume\n" +
+				"btc_jpy,1584266400,1000000,999990.5,1000010,1010000,990000,1234.5\n" +
+				"btc_jpy,1584266410,1000005,1000000,1000015,1010000,990000,1234.6\n",
+			wantErr: false,
+		},
+		{
+			name: "json lines export test",
+			args: args{format: JSONLines},
+			want: `{"pair":"btc_jpy","timestamp":1584266400,"last":1000000,"bid":999990.5,"ask":1000010,"high":1010000,"low":990000,"volume":1234.5}` + "\n" +
+				`{"pair":"btc_jpy","timestamp":1584266410,"last":1000005,"bid":1000000,"ask":1000015,"high":1010000,"low":990000,"volume":1234.6}` + "\n",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			e, err := NewExporter(&buf, tt.args.format, TickRecord{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewExporter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, r := range records {
+				if err := e.Write(r); err != nil {
+					t.Errorf("Write() error = %v", err)
+					return
+				}
+			}
+			if err := e.Close(); err != nil {
+				t.Errorf("Close() error = %v", err)
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("NewExporter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewExporterParquet(t *testing.T) {
+	var buf bytes.Buffer
+	e, err := NewExporter(&buf, Parquet, CandleRecord{})
+	if err != nil {
+		t.Fatalf("NewExporter() error = %v", err)
+	}
+	if err := e.Write(CandleRecord{Pair: "btc_jpy", Interval: "1m", Timestamp: 1584266400, Open: 1, High: 2, Low: 1, Close: 2, Volume: 0.5, Trades: 3}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	b := buf.Bytes()
+	if len(b) < 8 || string(b[:4]) != "PAR1" || string(b[len(b)-4:]) != "PAR1" {
+		t.Errorf("NewExporter() wrote no parquet file: %q", b)
+	}
+}
@@ -7,8 +7,14 @@ require (
 	github.com/bvinc/go-sqlite-lite v0.6.1
 	github.com/golang/protobuf v1.4.2
 	github.com/hypoballad/toecutter v0.0.0-20200315031103-c02957d9af65
+	github.com/lib/pq v1.8.0
+	github.com/prometheus/client_golang v1.7.1
 	github.com/robfig/cron/v3 v3.0.1
 	github.com/rs/xid v1.2.1
-	golang.org/x/text v0.3.0
-	google.golang.org/grpc v1.29.1
+	github.com/xitongsys/parquet-go v1.6.2
+	go.opentelemetry.io/otel v0.11.0
+	go.opentelemetry.io/otel/exporters/otlp v0.11.0
+	go.opentelemetry.io/otel/sdk v0.11.0
+	golang.org/x/text v0.3.2
+	google.golang.org/grpc v1.31.0
 )
@@ -0,0 +1,109 @@
+package bitcocheck
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// HaltState Whether trading is halted, why, by whom and since when.
+type HaltState struct {
+	Halted bool      `json:"halted"`
+	Reason string    `json:"reason,omitempty"`
+	By     string    `json:"by,omitempty"`
+	Since  time.Time `json:"since,omitempty"`
+}
+
+// KillSwitch The trading halt, kept in a file so that it survives restarts.
+// It is safe for concurrent use.
+type KillSwitch struct {
+	path string
+
+	mu    sync.Mutex
+	state HaltState
+}
+
+// OpenKillSwitch reads the halt state of the file, not halted when there is
+// no file yet.
+func OpenKillSwitch(path string) (*KillSwitch, error) {
+	k := &KillSwitch{path: path}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return k, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &k.state); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// State returns the halt state.
+func (k *KillSwitch) State() HaltState {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.state
+}
+
+// Halt halts trading. halted is false when trading was already halted, in
+// which case the first reason is kept.
+func (k *KillSwitch) Halt(reason, by string, now time.Time) (state HaltState, halted bool, err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.state.Halted {
+		return k.state, false, nil
+	}
+	next := HaltState{Halted: true, Reason: reason, By: by, Since: now}
+	if err := k.save(next); err != nil {
+		return k.state, false, err
+	}
+	k.state = next
+	return k.state, true, nil
+}
+
+// Resume lets trading go on.
+func (k *KillSwitch) Resume() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err := k.save(HaltState{}); err != nil {
+		return err
+	}
+	k.state = HaltState{}
+	return nil
+}
+
+// save replaces the file with the state.
+func (k *KillSwitch) save(state HaltState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(k.path, b)
+}
+
+// writeFileAtomic replaces a file through a temporary file in the same
+// directory, so that a crash leaves either the old or the new content.
+func writeFileAtomic(path string, b []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,78 @@
+package bitcocheck
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestKillSwitch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "halt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "halt.json")
+	since := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	k, err := OpenKillSwitch(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.State().Halted {
+		t.Fatal("halted without a file")
+	}
+	steps := []struct {
+		name       string
+		do         func() (HaltState, bool, error)
+		wantHalted bool
+		wantReason string
+		wantChange bool
+	}{
+		{name: "halt", do: func() (HaltState, bool, error) { return k.Halt("runaway bot", "ops", since) }, wantHalted: true, wantReason: "runaway bot", wantChange: true},
+		{name: "halt again keeps the reason", do: func() (HaltState, bool, error) { return k.Halt("loss", "risk", since.Add(time.Hour)) }, wantHalted: true, wantReason: "runaway bot"},
+		{name: "resume", do: func() (HaltState, bool, error) {
+			err := k.Resume()
+			return k.State(), true, err
+		}, wantChange: true},
+		{name: "halt after resume", do: func() (HaltState, bool, error) { return k.Halt("loss", "risk", since) }, wantHalted: true, wantReason: "loss", wantChange: true},
+	}
+	for _, s := range steps {
+		state, changed, err := s.do()
+		if err != nil {
+			t.Fatalf("%s: %v", s.name, err)
+		}
+		if state.Halted != s.wantHalted || state.Reason != s.wantReason || changed != s.wantChange {
+			t.Errorf("%s: state %+v changed %v, want halted %v reason %q changed %v", s.name, state, changed, s.wantHalted, s.wantReason, s.wantChange)
+		}
+		reopened, err := OpenKillSwitch(name)
+		if err != nil {
+			t.Fatalf("%s: reopen: %v", s.name, err)
+		}
+		if got := reopened.State(); got.Halted != s.wantHalted || got.Reason != s.wantReason {
+			t.Errorf("%s: reopened state %+v, want halted %v reason %q", s.name, got, s.wantHalted, s.wantReason)
+		}
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("%d files left in the directory, want only the halt file", len(files))
+	}
+}
+
+func TestOpenKillSwitchCorrupt(t *testing.T) {
+	f, err := ioutil.TempFile("", "halt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("{halted")
+	f.Close()
+	if _, err := OpenKillSwitch(f.Name()); err == nil {
+		t.Error("OpenKillSwitch() of a corrupt file succeeded")
+	}
+}
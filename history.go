@@ -0,0 +1,104 @@
+package bitcocheck
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Resolution A rolled up ticker history table.
+type Resolution struct {
+	Name     string
+	Interval time.Duration
+}
+
+// Resolutions The rolled up ticker histories, from the finest to the coarsest.
+var Resolutions = []Resolution{
+	{Name: "1m", Interval: time.Minute},
+	{Name: "5m", Interval: 5 * time.Minute},
+	{Name: "1h", Interval: time.Hour},
+	{Name: "1d", Interval: 24 * time.Hour},
+}
+
+// TickBar One bucket of ticker history. Open, High, Low and Close follow the
+// last trade price, Bid, Ask and Volume are the values at the end of the bucket.
+type TickBar struct {
+	Time    time.Time
+	Open    float64
+	High    float64
+	Low     float64
+	Close   float64
+	Bid     float64
+	Ask     float64
+	Volume  float64
+	Samples int
+}
+
+// RollupBars merges bars sorted by time into buckets of the interval.
+func RollupBars(bars []TickBar, interval time.Duration) []TickBar {
+	result := []TickBar{}
+	for _, bar := range bars {
+		bucket := bar.Time.Truncate(interval)
+		n := len(result)
+		if n == 0 || !result[n-1].Time.Equal(bucket) {
+			bar.Time = bucket
+			result = append(result, bar)
+			continue
+		}
+		last := &result[n-1]
+		if bar.High > last.High {
+			last.High = bar.High
+		}
+		if bar.Low < last.Low {
+			last.Low = bar.Low
+		}
+		last.Close = bar.Close
+		last.Bid = bar.Bid
+		last.Ask = bar.Ask
+		last.Volume = bar.Volume
+		last.Samples += bar.Samples
+	}
+	return result
+}
+
+// parseDuration accepts whole days such as "30d" besides the
+// time.ParseDuration units.
+func parseDuration(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// ParseRetention parses a retention period such as "48h" or "30d". An empty
+// string means forever and returns zero.
+func ParseRetention(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := parseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid retention: %s", s)
+	}
+	return d, nil
+}
+
+// ParseInterval parses a candle interval such as "1m", "4h" or "1d".
+func ParseInterval(s string) (time.Duration, error) {
+	d, err := parseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < time.Second {
+		return 0, fmt.Errorf("invalid interval: %s", s)
+	}
+	return d, nil
+}
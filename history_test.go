@@ -0,0 +1,96 @@
+package bitcocheck
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRollupBars(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.UTC)
+	tick := func(offset time.Duration, last float64) TickBar {
+		return TickBar{Time: base.Add(offset), Open: last, High: last, Low: last, Close: last, Bid: last - 1, Ask: last + 1, Volume: 10, Samples: 1}
+	}
+	type args struct {
+		bars     []TickBar
+		interval time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want []TickBar
+	}{
+		{
+			name: "empty rollup test",
+			args: args{bars: []TickBar{}, interval: time.Minute},
+			want: []TickBar{},
+		},
+		{
+			name: "minute rollup test",
+			args: args{
+				bars: []TickBar{
+					tick(0, 100),
+					tick(10*time.Second, 120),
+					tick(20*time.Second, 90),
+					tick(50*time.Second, 110),
+					tick(70*time.Second, 130),
+				},
+				interval: time.Minute,
+			},
+			want: []TickBar{
+				{Time: base, Open: 100, High: 120, Low: 90, Close: 110, Bid: 109, Ask: 111, Volume: 10, Samples: 4},
+				{Time: base.Add(time.Minute), Open: 130, High: 130, Low: 130, Close: 130, Bid: 129, Ask: 131, Volume: 10, Samples: 1},
+			},
+		},
+		{
+			name: "bars rollup test",
+			args: args{
+				bars: []TickBar{
+					{Time: base, Open: 100, High: 150, Low: 90, Close: 120, Samples: 6},
+					{Time: base.Add(time.Minute), Open: 120, High: 125, Low: 80, Close: 85, Samples: 6},
+				},
+				interval: 5 * time.Minute,
+			},
+			want: []TickBar{
+				{Time: base, Open: 100, High: 150, Low: 80, Close: 85, Samples: 12},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RollupBars(tt.args.bars, tt.args.interval); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RollupBars() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetention(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "forever test", args: args{s: ""}, want: 0, wantErr: false},
+		{name: "days test", args: args{s: "30d"}, want: 30 * 24 * time.Hour, wantErr: false},
+		{name: "hours test", args: args{s: "48h"}, want: 48 * time.Hour, wantErr: false},
+		{name: "invalid days test", args: args{s: "xd"}, want: 0, wantErr: true},
+		{name: "negative test", args: args{s: "-1h"}, want: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRetention(tt.args.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRetention() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseRetention() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package bitcocheck
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Gap A hole in a collected series, between the last point before it and the
+// first point after it.
+type Gap struct {
+	From time.Time
+	To   time.Time
+}
+
+// FindGaps returns the holes between consecutive times, sorted in ascending
+// order, that are longer than tolerance.
+func FindGaps(times []time.Time, tolerance time.Duration) []Gap {
+	gaps := []Gap{}
+	for i := 1; i < len(times); i++ {
+		if times[i].Sub(times[i-1]) > tolerance {
+			gaps = append(gaps, Gap{From: times[i-1], To: times[i]})
+		}
+	}
+	return gaps
+}
+
+// csvRows reads a CSV with a header line and returns each row keyed by column name.
+func csvRows(r io.Reader, columns []string) ([]map[string]string, error) {
+	rows := []map[string]string{}
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return rows, err
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[name] = i
+	}
+	for _, name := range columns {
+		if _, ok := index[name]; !ok {
+			return rows, fmt.Errorf("missing column: %s", name)
+		}
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rows, err
+		}
+		row := map[string]string{}
+		for _, name := range columns {
+			row[name] = record[index[name]]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ReadTradesCSV reads a trade dump with the columns of /api/trades:
+// id, pair, rate, amount, order_type and created_at.
+func ReadTradesCSV(r io.Reader) ([]*TradeData, error) {
+	trades := []*TradeData{}
+	rows, err := csvRows(r, []string{"id", "pair", "rate", "amount", "order_type", "created_at"})
+	if err != nil {
+		return trades, err
+	}
+	for i, row := range rows {
+		id, err := strconv.ParseUint(row["id"], 10, 32)
+		if err != nil {
+			return trades, fmt.Errorf("line %d: %v", i+2, err)
+		}
+		rate, err := strconv.ParseFloat(row["rate"], 64)
+		if err != nil {
+			return trades, fmt.Errorf("line %d: %v", i+2, err)
+		}
+		if _, err := strconv.ParseFloat(row["amount"], 64); err != nil {
+			return trades, fmt.Errorf("line %d: %v", i+2, err)
+		}
+		trade := &TradeData{
+			ID:        uint32(id),
+			Pair:      row["pair"],
+			Amount:    row["amount"],
+			OrderType: row["order_type"],
+			CreatedAt: row["created_at"],
+		}
+		trade.setRate(rate)
+		if _, err := TradeTime(trade); err != nil {
+			return trades, fmt.Errorf("line %d: %v", i+2, err)
+		}
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+// ReadTicksCSV reads a ticker dump with the columns of TickRecord, as written
+// by the ticks export.
+func ReadTicksCSV(r io.Reader) ([]TickRecord, error) {
+	ticks := []TickRecord{}
+	rows, err := csvRows(r, []string{"pair", "timestamp", "last", "bid", "ask", "high", "low", "volume"})
+	if err != nil {
+		return ticks, err
+	}
+	for i, row := range rows {
+		tick := TickRecord{Pair: row["pair"]}
+		if tick.Timestamp, err = strconv.ParseInt(row["timestamp"], 10, 64); err != nil {
+			return ticks, fmt.Errorf("line %d: %v", i+2, err)
+		}
+		for name, v := range map[string]*float64{
+			"last":   &tick.Last,
+			"bid":    &tick.Bid,
+			"ask":    &tick.Ask,
+			"high":   &tick.High,
+			"low":    &tick.Low,
+			"volume": &tick.Volume,
+		} {
+			if *v, err = strconv.ParseFloat(row[name], 64); err != nil {
+				return ticks, fmt.Errorf("line %d: %v", i+2, err)
+			}
+		}
+		ticks = append(ticks, tick)
+	}
+	return ticks, nil
+}
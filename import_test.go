@@ -0,0 +1,97 @@
+package bitcocheck
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindGaps(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.UTC)
+	type args struct {
+		times     []time.Time
+		tolerance time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want []Gap
+	}{
+		{
+			name: "no gap test",
+			args: args{times: []time.Time{base, base.Add(time.Hour), base.Add(2 * time.Hour)}, tolerance: 2 * time.Hour},
+			want: []Gap{},
+		},
+		{
+			name: "gap test",
+			args: args{times: []time.Time{base, base.Add(time.Hour), base.Add(5 * time.Hour), base.Add(6 * time.Hour)}, tolerance: 2 * time.Hour},
+			want: []Gap{{From: base.Add(time.Hour), To: base.Add(5 * time.Hour)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindGaps(tt.args.times, tt.args.tolerance); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindGaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadTradesCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    []*TradeData
+		wantErr bool
+	}{
+		{
+			name: "trades csv test",
+			csv: "id,created_at,rate,amount,order_type,pair\n" +
+				"82,2015-01-10T05:55:38.000Z,30000.0,1.0,sell,btc_jpy\n",
+			want: []*TradeData{
+				{ID: 82, Amount: "1.0", Rate: 30000, RateV2: 30000, Pair: "btc_jpy", OrderType: "sell", CreatedAt: "2015-01-10T05:55:38.000Z"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "missing column test",
+			csv:     "id,rate,amount\n82,30000.0,1.0\n",
+			want:    []*TradeData{},
+			wantErr: true,
+		},
+		{
+			name:    "bad time test",
+			csv:     "id,created_at,rate,amount,order_type,pair\n82,yesterday,30000.0,1.0,sell,btc_jpy\n",
+			want:    []*TradeData{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadTradesCSV(strings.NewReader(tt.csv))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadTradesCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadTradesCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadTicksCSV(t *testing.T) {
+	in := "pair,timestamp,last,bid,ask,high,low,volume\n" +
+		"btc_jpy,1584266400,1000000,999990.5,1000010,1010000,990000,1234.5\n"
+	want := []TickRecord{
+		{Pair: "btc_jpy", Timestamp: 1584266400, Last: 1000000, Bid: 999990.5, Ask: 1000010, High: 1010000, Low: 990000, Volume: 1234.5},
+	}
+	got, err := ReadTicksCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadTicksCSV() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTicksCSV() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package bitcocheck
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Endpoint returns the path of a Coincheck API URL with its numeric IDs
+// replaced by ":id", so every order shares one endpoint.
+func Endpoint(rawurl string) string {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "invalid"
+	}
+	parts := strings.Split(u.Path, "/")
+	for i, p := range parts {
+		if _, err := strconv.ParseUint(p, 10, 64); err == nil {
+			parts[i] = ":id"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// RequestMetrics Prometheus metrics of the requests to the Coincheck API,
+// fed by a request hook:
+//
+//	m := NewRequestMetrics()
+//	registry.MustRegister(m)
+//	AddRequestHook(m.Observe)
+type RequestMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewRequestMetrics returns the metrics, which count the requests by
+// endpoint, method and status ("error" when no response was received).
+func NewRequestMetrics() *RequestMetrics {
+	return &RequestMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "coincheck_requests_total",
+			Help: "Requests to the Coincheck API by endpoint, method and HTTP status.",
+		}, []string{"endpoint", "method", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "coincheck_request_duration_seconds",
+			Help:    "Latency of the requests to the Coincheck API.",
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"endpoint", "method"}),
+	}
+}
+
+// Observe counts a finished request.
+func (m *RequestMetrics) Observe(e RequestEvent) {
+	endpoint := Endpoint(e.URL)
+	status := "error"
+	if e.Status != 0 {
+		status = strconv.Itoa(e.Status)
+	}
+	m.requests.WithLabelValues(endpoint, e.Method, status).Inc()
+	m.duration.WithLabelValues(endpoint, e.Method).Observe(e.Elapsed.Seconds())
+}
+
+func (m *RequestMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.duration.Describe(ch)
+}
+
+func (m *RequestMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.duration.Collect(ch)
+}
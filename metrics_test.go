@@ -0,0 +1,55 @@
+package bitcocheck
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://coincheck.com/api/ticker?pair=btc_jpy", want: "/api/ticker"},
+		{url: "https://coincheck.com/api/exchange/orders/12345", want: "/api/exchange/orders/:id"},
+		{url: "https://coincheck.com/api/exchange/orders/opens", want: "/api/exchange/orders/opens"},
+		{url: "://bad", want: "invalid"},
+	}
+	for _, tt := range tests {
+		if got := Endpoint(tt.url); got != tt.want {
+			t.Errorf("Endpoint(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestRequestMetrics(t *testing.T) {
+	m := NewRequestMetrics()
+	events := []RequestEvent{
+		{Method: "GET", URL: "https://coincheck.com/api/ticker?pair=btc_jpy", Status: 200, Elapsed: 100 * time.Millisecond},
+		{Method: "GET", URL: "https://coincheck.com/api/ticker?pair=etc_jpy", Status: 200, Elapsed: 300 * time.Millisecond},
+		{Method: "DELETE", URL: "https://coincheck.com/api/exchange/orders/1", Status: 503},
+		{Method: "DELETE", URL: "https://coincheck.com/api/exchange/orders/2", Err: errors.New("timeout")},
+	}
+	for _, e := range events {
+		m.Observe(e)
+	}
+	tests := []struct {
+		labels []string
+		want   float64
+	}{
+		{labels: []string{"/api/ticker", "GET", "200"}, want: 2},
+		{labels: []string{"/api/exchange/orders/:id", "DELETE", "503"}, want: 1},
+		{labels: []string{"/api/exchange/orders/:id", "DELETE", "error"}, want: 1},
+	}
+	for _, tt := range tests {
+		if got := testutil.ToFloat64(m.requests.WithLabelValues(tt.labels...)); got != tt.want {
+			t.Errorf("coincheck_requests_total%v = %v, want %v", tt.labels, got, tt.want)
+		}
+	}
+	if got := testutil.CollectAndCount(m); got != 5 {
+		t.Errorf("CollectAndCount() = %d, want 5", got)
+	}
+}
@@ -0,0 +1,77 @@
+package bitcocheck
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bvinc/go-sqlite-lite/sqlite3"
+)
+
+// SchemaVersionTable Records the migrations applied to a sqlite database.
+const SchemaVersionTable = `create table if not exists schema_version (
+	version integer PRIMARY KEY,
+	description text NOT NULL,
+	applied_at timestamp NOT NULL)`
+
+// Migration One forward-only schema change. SQL runs first, then Up when set,
+// in the same transaction.
+type Migration struct {
+	Version     int
+	Description string
+	SQL         []string
+	Up          func(conn *sqlite3.Conn) error
+}
+
+// SchemaVersion returns the newest migration applied to the database, 0 for none.
+func SchemaVersion(conn *sqlite3.Conn) (int, error) {
+	if err := conn.Exec(SchemaVersionTable); err != nil {
+		return 0, err
+	}
+	stmt, err := conn.Prepare(`select coalesce(max(version), 0) from schema_version`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	if _, err := stmt.Step(); err != nil {
+		return 0, err
+	}
+	version, _, err := stmt.ColumnInt64(0)
+	return int(version), err
+}
+
+// Migrate applies the migrations newer than the schema version in order, each
+// in its own transaction. migrations must be sorted by ascending version.
+func Migrate(conn *sqlite3.Conn, migrations []Migration) error {
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			return fmt.Errorf("migration %d is out of order", migrations[i].Version)
+		}
+	}
+	current, err := SchemaVersion(conn)
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		err := conn.WithTx(func() error {
+			for _, stmt := range m.SQL {
+				if err := conn.Exec(stmt); err != nil {
+					return fmt.Errorf("%v, %s", err, stmt)
+				}
+			}
+			if m.Up != nil {
+				if err := m.Up(conn); err != nil {
+					return err
+				}
+			}
+			return conn.Exec(`insert into schema_version values (?,?,?)`,
+				m.Version, m.Description, time.Now().Format("2006-01-02 15:04:05"))
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d (%s): %v", m.Version, m.Description, err)
+		}
+	}
+	return nil
+}
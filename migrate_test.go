@@ -0,0 +1,75 @@
+package bitcocheck
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bvinc/go-sqlite-lite/sqlite3"
+)
+
+func TestMigrate(t *testing.T) {
+	conn, err := sqlite3.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	migrations := []Migration{
+		{Version: 1, Description: "baseline", SQL: []string{`create table if not exists hist (id text PRIMARY_KEY, ts timestamp NOT NULL)`}},
+		{Version: 2, Description: "add pair", SQL: []string{`alter table hist add column pair text NOT NULL DEFAULT 'btc_jpy'`}},
+	}
+	tests := []struct {
+		name        string
+		migrations  []Migration
+		wantVersion int
+		wantErr     bool
+	}{
+		{
+			name:        "migrate test",
+			migrations:  migrations,
+			wantVersion: 2,
+			wantErr:     false,
+		},
+		{
+			name:        "already migrated test",
+			migrations:  migrations,
+			wantVersion: 2,
+			wantErr:     false,
+		},
+		{
+			name: "failed migration test",
+			migrations: append(migrations, Migration{Version: 3, Description: "broken", Up: func(conn *sqlite3.Conn) error {
+				if err := conn.Exec(`alter table hist add column fee text`); err != nil {
+					return err
+				}
+				return errors.New("broken")
+			}}),
+			wantVersion: 2,
+			wantErr:     true,
+		},
+		{
+			name:        "out of order test",
+			migrations:  []Migration{migrations[1], migrations[0]},
+			wantVersion: 2,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Migrate(conn, tt.migrations); (err != nil) != tt.wantErr {
+				t.Errorf("Migrate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got, err := SchemaVersion(conn)
+			if err != nil {
+				t.Errorf("SchemaVersion() error = %v", err)
+				return
+			}
+			if got != tt.wantVersion {
+				t.Errorf("SchemaVersion() = %v, want %v", got, tt.wantVersion)
+			}
+		})
+	}
+	// the failed migration was rolled back
+	if err := conn.Exec(`alter table hist add column fee text`); err != nil {
+		t.Errorf("rolled back column still exists: %v", err)
+	}
+}
@@ -0,0 +1,304 @@
+package bitcocheck
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Statuses of a tracked order. An order goes from new to open, partially
+// filled and then filled or cancelled; filled and cancelled are final.
+const (
+	OrderNew             = "new"
+	OrderOpen            = "open"
+	OrderPartiallyFilled = "partially_filled"
+	OrderFilled          = "filled"
+	OrderCancelled       = "cancelled"
+)
+
+// OrderRejected The To of the transition of a client order that was not
+// placed. Such an order is not tracked.
+const OrderRejected = "rejected"
+
+// OrderStatuses The statuses of a tracked order.
+var OrderStatuses = []string{OrderNew, OrderOpen, OrderPartiallyFilled, OrderFilled, OrderCancelled}
+
+// ActiveOrderStatuses The statuses of the orders still followed.
+var ActiveOrderStatuses = []string{OrderNew, OrderOpen, OrderPartiallyFilled}
+
+// TrackedOrder An order placed through the server and followed until it is
+// filled or cancelled. Amount and Filled are in the base currency, in JPY for
+// a market_buy. Seen is when the order was last among the open orders.
+type TrackedOrder struct {
+	OrderID   uint64
+	ClientID  string
+	Pair      string
+	OrderType string
+	Rate      string
+	Amount    string
+	Status    string
+	Filled    string
+	Created   time.Time
+	Updated   time.Time
+	Seen      time.Time
+}
+
+// Final tells whether the order is filled or cancelled.
+func (o TrackedOrder) Final() bool {
+	return o.Status == OrderFilled || o.Status == OrderCancelled
+}
+
+// OrderTransition One change of status or new fill of a tracked order, or
+// the rejection of an order, whose OrderID is 0. ID orders the transitions of
+// every order. Pair, OrderType and Amount tell the order of a rejection,
+// which is not tracked.
+type OrderTransition struct {
+	ID        uint64
+	OrderID   uint64
+	ClientID  string
+	From      string
+	To        string
+	Filled    string
+	Reason    string
+	Time      time.Time
+	Pair      string
+	OrderType string
+	Amount    string
+}
+
+// TrackedOrderQuery Selects tracked orders. Empty fields and a zero Limit are
+// unbounded.
+type TrackedOrderQuery struct {
+	Statuses []string
+	Pair     string
+	Limit    int
+}
+
+// TransitionQuery Selects status transitions, of every order for a zero
+// OrderID. A zero Limit is unbounded.
+type TransitionQuery struct {
+	OrderID uint64
+	After   uint64 // only the transitions of a greater ID
+	Limit   int
+}
+
+// OrderTrackStore The orders placed by the server and their status history.
+type OrderTrackStore interface {
+	// SaveTrackedOrder saves an order, replacing the order of the same ID,
+	// and appends tr to the history when it is not nil.
+	SaveTrackedOrder(o TrackedOrder, tr *OrderTransition) error
+	// TrackedOrder returns an order, false when it is not tracked.
+	TrackedOrder(orderID uint64) (TrackedOrder, bool, error)
+	// TrackedOrders returns the orders of the query, newest first.
+	TrackedOrders(q TrackedOrderQuery) ([]TrackedOrder, error)
+	// AddOrderTransition appends a transition of no tracked order, e.g. a
+	// rejection, to the history.
+	AddOrderTransition(tr OrderTransition) error
+	// OrderTransitions returns the transitions of the query ordered by ID.
+	OrderTransitions(q TransitionQuery) ([]OrderTransition, error)
+}
+
+// filledEpsilon Below this, an amount left to fill is rounding.
+const filledEpsilon = 1e-8
+
+// Poll returns the order after a poll of the exchange: whether it is among
+// the open orders, and its fills. An order missing from the open orders is
+// filled once the fills reach its amount, a market order with any fill;
+// otherwise it is cancelled after settle, since the open orders and the fills
+// of the exchange may lag behind each other. tr is the change of status or of
+// the filled amount, nil when neither changed, and changed tells whether next
+// differs from o.
+func (o TrackedOrder) Poll(open bool, fills []Fill, now time.Time, settle time.Duration) (next TrackedOrder, tr *OrderTransition, changed bool) {
+	next = o
+	if o.Final() {
+		return next, nil, false
+	}
+	filled := o.filledAmount(fills)
+	next.Filled = formatAmount(filled)
+	next.Status = o.nextStatus(open, filled, now, settle)
+	if open {
+		next.Seen = now
+	}
+	if next.Status != o.Status || next.Filled != o.Filled {
+		tr = &OrderTransition{OrderID: o.OrderID, ClientID: o.ClientID, From: o.Status, To: next.Status, Filled: next.Filled, Time: now}
+	}
+	changed = tr != nil || open
+	if changed {
+		next.Updated = now
+	}
+	return next, tr, changed
+}
+
+// nextStatus returns the status of the order with filled of it filled.
+func (o TrackedOrder) nextStatus(open bool, filled float64, now time.Time, settle time.Duration) string {
+	if open {
+		if filled > 0 {
+			return OrderPartiallyFilled
+		}
+		return OrderOpen
+	}
+	amount, _ := strconv.ParseFloat(o.Amount, 64)
+	market := o.OrderType == MarketBuy.String() || o.OrderType == MarketSell.String()
+	if (market && filled > 0) || (filled > 0 && filled >= amount-filledEpsilon) {
+		return OrderFilled
+	}
+	last := o.Created
+	if o.Seen.After(last) {
+		last = o.Seen
+	}
+	if now.Sub(last) < settle {
+		return o.Status
+	}
+	return OrderCancelled
+}
+
+// filledAmount returns how much of the order the fills cover, in the base
+// currency of its pair, in JPY for a market_buy.
+func (o TrackedOrder) filledAmount(fills []Fill) float64 {
+	filled := 0.0
+	for _, f := range fills {
+		if f.OrderID != o.OrderID {
+			continue
+		}
+		x, err := fundsAmount(f.Base, f.Btc, f.Jpy, o.OrderType == MarketBuy.String())
+		if err != nil {
+			continue
+		}
+		filled += x
+	}
+	return filled
+}
+
+// fundsAmount returns the amount of a fill from its funds: the base currency
+// of its pair, btc when the fill does not tell it, JPY for a market_buy.
+func fundsAmount(base, btc, jpy string, marketBuy bool) (float64, error) {
+	v := base
+	if v == "" {
+		v = btc
+	}
+	if marketBuy {
+		v = jpy
+	}
+	x, err := strconv.ParseFloat(v, 64)
+	return math.Abs(x), err
+}
+
+// OrderEventType returns the event of a transition: accepted for a new
+// order, else the status it went to.
+func OrderEventType(tr OrderTransition) string {
+	if tr.To == OrderNew {
+		return "accepted"
+	}
+	return tr.To
+}
+
+// OrderEventReader Reads the order events from the transitions of a store,
+// caching the orders they belong to. Its cursor is the Seq of the last event
+// read.
+type OrderEventReader struct {
+	store interface {
+		OrderTrackStore
+		ClientOrderStore
+	}
+	pair    string
+	orders  map[uint64]TrackedOrder
+	clients map[string]ClientOrder
+}
+
+// NewOrderEventReader returns a reader of the events of a pair, of every
+// pair for "".
+func NewOrderEventReader(s interface {
+	OrderTrackStore
+	ClientOrderStore
+}, pair string) *OrderEventReader {
+	return &OrderEventReader{store: s, pair: pair, orders: map[uint64]TrackedOrder{}, clients: map[string]ClientOrder{}}
+}
+
+// Read returns the events of up to limit transitions after the cursor, and
+// the cursor to resume from. The transitions of the other pairs move the
+// cursor too. more tells whether limit transitions were read, so that more may
+// follow at once.
+func (r *OrderEventReader) Read(after uint64, limit int) (events []*OrderEvent, next uint64, more bool, err error) {
+	transitions, err := r.store.OrderTransitions(TransitionQuery{After: after, Limit: limit})
+	if err != nil {
+		return nil, after, false, err
+	}
+	next = after
+	for _, t := range transitions {
+		event, err := r.event(t)
+		if err != nil {
+			return events, next, false, err
+		}
+		next = t.ID
+		if r.pair != "" && event.Pair != r.pair {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, next, limit > 0 && len(transitions) == limit, nil
+}
+
+func (r *OrderEventReader) event(t OrderTransition) (*OrderEvent, error) {
+	event := &OrderEvent{
+		Seq:           t.ID,
+		Type:          OrderEventType(t),
+		OrderId:       t.OrderID,
+		ClientOrderId: t.ClientID,
+		Filled:        t.Filled,
+		Reason:        t.Reason,
+		Time:          uint64(t.Time.Unix()),
+	}
+	switch {
+	case t.Pair != "":
+		event.Pair, event.OrderType, event.Amount = t.Pair, t.OrderType, t.Amount
+	case t.OrderID == 0:
+		// A rejection saved before the transitions told their order.
+		c, ok := r.clients[t.ClientID]
+		if !ok {
+			var err error
+			if c, _, err = r.store.ClientOrder(t.ClientID); err != nil {
+				return nil, err
+			}
+			r.clients[t.ClientID] = c
+		}
+		event.Pair, event.OrderType, event.Amount = c.Pair, c.OrderType, c.Amount
+	default:
+		o, ok := r.orders[t.OrderID]
+		if !ok {
+			var err error
+			if o, _, err = r.store.TrackedOrder(t.OrderID); err != nil {
+				return nil, err
+			}
+			r.orders[t.OrderID] = o
+		}
+		event.Pair, event.OrderType, event.Amount = o.Pair, o.OrderType, o.Amount
+	}
+	return event, nil
+}
+
+// TransactionFills returns the transactions of the account as fills.
+func TransactionFills(txs OrdersTransactionsItem) ([]Fill, error) {
+	fills := []Fill{}
+	for _, t := range txs.Transactions {
+		tm, err := time.Parse(time.RFC3339, t.CreatedAt)
+		if err != nil {
+			return fills, err
+		}
+		f := Fill{
+			ID:          uint64(t.Id),
+			OrderID:     uint64(t.OrderId),
+			Time:        tm,
+			Pair:        t.Pair,
+			Side:        t.Side,
+			Rate:        t.Rate,
+			Fee:         t.Fee,
+			FeeCurrency: t.FeeCurrency,
+			Liquidity:   t.Liquidity,
+		}
+		if t.Funds != nil {
+			f.Btc, f.Jpy, f.Base = t.Funds.Btc, t.Funds.Jpy, t.Funds.Base
+		}
+		fills = append(fills, f)
+	}
+	return fills, nil
+}
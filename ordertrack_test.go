@@ -0,0 +1,173 @@
+package bitcocheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrackedOrderPoll(t *testing.T) {
+	created := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	settle := 2 * time.Minute
+	limit := TrackedOrder{OrderID: 7, Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.3", Status: OrderNew, Filled: "0", Created: created}
+	fill := func(btc string) Fill {
+		return Fill{OrderID: 7, Btc: btc, Jpy: "-10000"}
+	}
+	tests := []struct {
+		name        string
+		o           TrackedOrder
+		open        bool
+		fills       []Fill
+		now         time.Time
+		wantStatus  string
+		wantFilled  string
+		wantTr      bool
+		wantChanged bool
+	}{
+		{
+			name:       "seen open",
+			o:          limit,
+			open:       true,
+			now:        created.Add(time.Minute),
+			wantStatus: OrderOpen, wantFilled: "0", wantTr: true, wantChanged: true,
+		},
+		{
+			name:       "open with a fill",
+			o:          limit,
+			open:       true,
+			fills:      []Fill{fill("0.1"), {OrderID: 8, Btc: "1"}},
+			now:        created.Add(time.Minute),
+			wantStatus: OrderPartiallyFilled, wantFilled: "0.1", wantTr: true, wantChanged: true,
+		},
+		{
+			name: "a new fill of a partially filled order",
+			o: func() TrackedOrder {
+				o := limit
+				o.Status, o.Filled = OrderPartiallyFilled, "0.1"
+				return o
+			}(),
+			open:       true,
+			fills:      []Fill{fill("0.1"), fill("0.1")},
+			now:        created.Add(time.Minute),
+			wantStatus: OrderPartiallyFilled, wantFilled: "0.2", wantTr: true, wantChanged: true,
+		},
+		{
+			name:       "gone without fills waits to settle",
+			o:          limit,
+			now:        created.Add(time.Minute),
+			wantStatus: OrderNew, wantFilled: "0",
+		},
+		{
+			name:       "gone with every fill",
+			o:          limit,
+			fills:      []Fill{fill("0.1"), fill("0.2")},
+			now:        created.Add(time.Minute),
+			wantStatus: OrderFilled, wantFilled: "0.3", wantTr: true, wantChanged: true,
+		},
+		{
+			name:       "gone without fills is cancelled",
+			o:          limit,
+			now:        created.Add(3 * time.Minute),
+			wantStatus: OrderCancelled, wantFilled: "0", wantTr: true, wantChanged: true,
+		},
+		{
+			name: "partially filled then cancelled",
+			o: func() TrackedOrder {
+				o := limit
+				o.Status, o.Filled, o.Seen = OrderPartiallyFilled, "0.1", created.Add(5*time.Minute)
+				return o
+			}(),
+			fills:      []Fill{fill("0.1")},
+			now:        created.Add(8 * time.Minute),
+			wantStatus: OrderCancelled, wantFilled: "0.1", wantTr: true, wantChanged: true,
+		},
+		{
+			name:       "gone with every fill of another pair",
+			o:          TrackedOrder{OrderID: 7, Pair: "fct_jpy", OrderType: "sell", Rate: "5", Amount: "300", Status: OrderOpen, Filled: "0", Created: created},
+			fills:      []Fill{{OrderID: 7, Pair: "fct_jpy", Jpy: "500", Base: "-100"}, {OrderID: 7, Pair: "fct_jpy", Jpy: "1000", Base: "-200"}},
+			now:        created.Add(3 * time.Minute),
+			wantStatus: OrderFilled, wantFilled: "300", wantTr: true, wantChanged: true,
+		},
+		{
+			name:       "market sell fills with any fill",
+			o:          TrackedOrder{OrderID: 7, Pair: "btc_jpy", OrderType: "market_sell", Amount: "1", Status: OrderNew, Filled: "0", Created: created},
+			fills:      []Fill{fill("-0.5")},
+			now:        created.Add(time.Second),
+			wantStatus: OrderFilled, wantFilled: "0.5", wantTr: true, wantChanged: true,
+		},
+		{
+			name:       "market buy counts JPY",
+			o:          TrackedOrder{OrderID: 7, Pair: "btc_jpy", OrderType: "market_buy", Amount: "10000", Status: OrderNew, Filled: "0", Created: created},
+			fills:      []Fill{fill("0.01")},
+			now:        created.Add(time.Second),
+			wantStatus: OrderFilled, wantFilled: "10000", wantTr: true, wantChanged: true,
+		},
+		{
+			name: "final orders stay",
+			o: func() TrackedOrder {
+				o := limit
+				o.Status = OrderCancelled
+				return o
+			}(),
+			open:       true,
+			now:        created.Add(time.Minute),
+			wantStatus: OrderCancelled, wantFilled: "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, tr, changed := tt.o.Poll(tt.open, tt.fills, tt.now, settle)
+			if next.Status != tt.wantStatus || next.Filled != tt.wantFilled {
+				t.Errorf("Poll() = %s filled %s, want %s filled %s", next.Status, next.Filled, tt.wantStatus, tt.wantFilled)
+			}
+			if (tr != nil) != tt.wantTr || changed != tt.wantChanged {
+				t.Fatalf("Poll() transition %v, changed %v, want %v, %v", tr, changed, tt.wantTr, tt.wantChanged)
+			}
+			if tr != nil && (tr.From != tt.o.Status || tr.To != tt.wantStatus || !tr.Time.Equal(tt.now)) {
+				t.Errorf("Poll() transition %+v", tr)
+			}
+		})
+	}
+}
+
+func TestOrderEventReaderResume(t *testing.T) {
+	s := NewMemoryStore()
+	base := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	btc := TrackedOrder{OrderID: 1, Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1", Status: OrderNew, Filled: "0", Created: base}
+	if err := s.SaveTrackedOrder(btc, &OrderTransition{OrderID: 1, To: OrderNew, Filled: "0", Time: base}); err != nil {
+		t.Fatal(err)
+	}
+	fct := TrackedOrder{OrderID: 2, Pair: "fct_jpy", OrderType: "sell", Rate: "100", Amount: "10", Status: OrderNew, Filled: "0", Created: base}
+	if err := s.SaveTrackedOrder(fct, &OrderTransition{OrderID: 2, To: OrderNew, Filled: "0", Time: base}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddOrderTransition(OrderTransition{To: OrderRejected, Reason: "trading is halted", Time: base, Pair: "btc_jpy", OrderType: "market_buy", Amount: "5000"}); err != nil {
+		t.Fatal(err)
+	}
+	btc.Status = OrderOpen
+	if err := s.SaveTrackedOrder(btc, &OrderTransition{OrderID: 1, From: OrderNew, To: OrderOpen, Filled: "0", Time: base.Add(time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewOrderEventReader(s, "btc_jpy")
+	events, cursor, more, err := r.Read(0, 2)
+	if err != nil || !more || len(events) != 1 || events[0].OrderId != 1 || events[0].Type != "accepted" {
+		t.Fatalf("Read(0, 2) = %v, %d, %v, %v", events, cursor, more, err)
+	}
+	if cursor != 2 {
+		t.Errorf("cursor after an event of another pair = %d, want 2", cursor)
+	}
+	// A new reader resumes after the cursor, as a resubscription does.
+	events, cursor, more, err = NewOrderEventReader(s, "btc_jpy").Read(cursor, 10)
+	if err != nil || more || len(events) != 2 {
+		t.Fatalf("Read(2, 10) = %v, %v, %v", events, more, err)
+	}
+	if e := events[0]; e.Type != OrderRejected || e.OrderId != 0 || e.Pair != "btc_jpy" || e.OrderType != "market_buy" || e.Amount != "5000" || e.Reason != "trading is halted" {
+		t.Errorf("rejection event = %+v", e)
+	}
+	if e := events[1]; e.Type != OrderOpen || e.OrderId != 1 || e.Amount != "0.1" || e.Seq != cursor {
+		t.Errorf("open event = %+v, cursor %d", e, cursor)
+	}
+	if events, next, _, err := r.Read(cursor, 2); err != nil || len(events) != 0 || next != cursor {
+		t.Errorf("Read() at the end = %v, %d, %v", events, next, err)
+	}
+}
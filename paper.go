@@ -0,0 +1,480 @@
+package bitcocheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// paperFillsKept is how many fills the paper account keeps for Transactions.
+const paperFillsKept = 1000
+
+// PaperConfig Paper trading: the orders are filled by a simulated engine
+// against the market trades instead of being sent to Coincheck.
+type PaperConfig struct {
+	Enable bool `toml:"enable"`
+	// JPY and BTC are the balances a new paper account starts with.
+	JPY float64 `toml:"jpy"`
+	BTC float64 `toml:"btc"`
+	// StateFile keeps the paper account across restarts.
+	StateFile string `toml:"state_file"`
+}
+
+// StateFilePath returns the file of the paper account,
+// bitcocheck-paper.json when unset.
+func (c PaperConfig) StateFilePath() string {
+	if c.StateFile == "" {
+		return "bitcocheck-paper.json"
+	}
+	return c.StateFile
+}
+
+type paperOrder struct {
+	ID        uint32    `json:"id"`
+	Pair      string    `json:"pair"`
+	Side      string    `json:"side"`
+	Rate      float64   `json:"rate"`
+	Pending   float64   `json:"pending"`
+	StopLoss  float64   `json:"stop_loss_rate,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type paperFill struct {
+	ID        uint32    `json:"id"`
+	OrderID   uint32    `json:"order_id"`
+	Time      time.Time `json:"time"`
+	Pair      string    `json:"pair"`
+	Side      string    `json:"side"`
+	Rate      float64   `json:"rate"`
+	Amount    float64   `json:"amount"`
+	Liquidity string    `json:"liquidity"`
+}
+
+type paperAccount struct {
+	NextOrderID uint32             `json:"next_order_id"`
+	NextFillID  uint32             `json:"next_fill_id"`
+	Balances    map[string]float64 `json:"balances"`
+	Reserved    map[string]float64 `json:"reserved"`
+	Orders      []*paperOrder      `json:"orders"`
+	Fills       []*paperFill       `json:"fills"`
+}
+
+// PaperExchange An Exchange filling the orders against the trades it is fed
+// with Trade, the live trades in the server or recorded ones in a backtest.
+// Market orders and limit orders crossing the last price fill at once at the
+// last price; resting limit orders fill at their rate as trades reach it, up
+// to the traded amount. There are no fees. It is safe for concurrent use.
+type PaperExchange struct {
+	path string
+
+	mu      sync.Mutex
+	account paperAccount
+	prices  map[string]float64
+	now     func() time.Time
+}
+
+// NewPaperExchange opens the paper account of the state file, or starts a
+// new one with the configured balances.
+func NewPaperExchange(conf PaperConfig) (*PaperExchange, error) {
+	p := &PaperExchange{
+		path: conf.StateFilePath(),
+		account: paperAccount{
+			NextOrderID: 1,
+			NextFillID:  1,
+			Balances:    map[string]float64{"jpy": conf.JPY, "btc": conf.BTC},
+			Reserved:    map[string]float64{},
+		},
+		prices: map[string]float64{},
+		now:    time.Now,
+	}
+	b, err := ioutil.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		return p, p.save()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &p.account); err != nil {
+		return nil, fmt.Errorf("paper state %s: %v", p.path, err)
+	}
+	return p, nil
+}
+
+func (p *PaperExchange) save() error {
+	b, err := json.Marshal(p.account)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(p.path, b); err != nil {
+		return fmt.Errorf("paper account not saved: %v", err)
+	}
+	return nil
+}
+
+// currencies returns the base and the quote currency of a pair.
+func currencies(pair string) (base, quote string) {
+	parts := strings.SplitN(pair, "_", 2)
+	if len(parts) != 2 {
+		return pair, "jpy"
+	}
+	return parts[0], parts[1]
+}
+
+// formatAmount formats an amount rounded to a satoshi, which also drops the
+// float dust of the fills.
+func formatAmount(v float64) string {
+	r := math.Round(v*1e8) / 1e8
+	if r == 0 {
+		r = 0 // not -0
+	}
+	return strconv.FormatFloat(r, 'f', -1, 64)
+}
+
+// fill executes amount of an order at rate, moving the reserved funds.
+func (p *PaperExchange) fill(o *paperOrder, rate, amount float64, liquidity string, at time.Time) {
+	base, quote := currencies(o.Pair)
+	a := &p.account
+	if o.Side == Buy.String() {
+		a.Reserved[quote] -= o.Rate * amount
+		a.Balances[quote] += o.Rate*amount - rate*amount
+		a.Balances[base] += amount
+	} else {
+		a.Reserved[base] -= amount
+		a.Balances[quote] += rate * amount
+	}
+	o.Pending -= amount
+	p.record(o.ID, o.Pair, o.Side, rate, amount, liquidity, at)
+}
+
+func (p *PaperExchange) record(orderID uint32, pair, side string, rate, amount float64, liquidity string, at time.Time) {
+	a := &p.account
+	a.Fills = append(a.Fills, &paperFill{
+		ID:        a.NextFillID,
+		OrderID:   orderID,
+		Time:      at,
+		Pair:      pair,
+		Side:      side,
+		Rate:      rate,
+		Amount:    amount,
+		Liquidity: liquidity,
+	})
+	a.NextFillID++
+	if len(a.Fills) > paperFillsKept {
+		a.Fills = a.Fills[len(a.Fills)-paperFillsKept:]
+	}
+}
+
+// dropFilled removes the orders with nothing left to fill.
+func (p *PaperExchange) dropFilled() {
+	open := p.account.Orders[:0]
+	for _, o := range p.account.Orders {
+		if o.Pending > 1e-12 {
+			open = append(open, o)
+		}
+	}
+	p.account.Orders = open
+}
+
+// Trade feeds a market trade: it is the new last price of the pair, triggers
+// the stop orders it reaches and fills the limit orders it crosses. A trade
+// older than an order, e.g. of a poll that started before the order was
+// placed, leaves the order alone.
+func (p *PaperExchange) Trade(pair string, rate, amount float64, at time.Time) error {
+	if rate <= 0 {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prices[pair] = rate
+	changed := false
+	for _, o := range p.account.Orders {
+		if o.Pair != pair || at.Before(o.CreatedAt) {
+			continue
+		}
+		buy := o.Side == Buy.String()
+		if !o.Active && ((buy && rate >= o.StopLoss) || (!buy && rate <= o.StopLoss)) {
+			o.Active, changed = true, true
+		}
+		if !o.Active || amount <= 0 || (buy && rate > o.Rate) || (!buy && rate < o.Rate) {
+			continue
+		}
+		q := math.Min(o.Pending, amount)
+		p.fill(o, o.Rate, q, "M", at)
+		amount -= q
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	p.dropFilled()
+	return p.save()
+}
+
+func (p *PaperExchange) lastPrice(pair string) (float64, error) {
+	price, ok := p.prices[pair]
+	if !ok {
+		return 0, fmt.Errorf("paper: no %s trade seen yet to fill a market order", pair)
+	}
+	return price, nil
+}
+
+func (p *PaperExchange) newID() (uint32, time.Time) {
+	id := p.account.NextOrderID
+	p.account.NextOrderID++
+	return id, p.now()
+}
+
+func (p *PaperExchange) MarketBuy(conf Config, pair Pair, amount uint32) (MarketItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	price, err := p.lastPrice(pair.String())
+	if err != nil {
+		return MarketItem{}, err
+	}
+	base, quote := currencies(pair.String())
+	funds := float64(amount)
+	if funds <= 0 {
+		return MarketItem{}, errors.New("paper: market_buy_amount must be positive")
+	}
+	if funds > p.account.Balances[quote] {
+		return MarketItem{}, fmt.Errorf("paper: insufficient %s balance", quote)
+	}
+	id, now := p.newID()
+	p.account.Balances[quote] -= funds
+	p.account.Balances[base] += funds / price
+	p.record(id, pair.String(), Buy.String(), price, funds/price, "T", now)
+	if err := p.save(); err != nil {
+		return MarketItem{}, err
+	}
+	return MarketItem{Success: "true", Id: uint64(id), OrderType: MarketBuy.String(), Pair: pair.String(), CreatedAt: now.Format(time.RFC3339)}, nil
+}
+
+func (p *PaperExchange) MarketSell(conf Config, pair Pair, amount uint32) (MarketItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	price, err := p.lastPrice(pair.String())
+	if err != nil {
+		return MarketItem{}, err
+	}
+	base, quote := currencies(pair.String())
+	size := float64(amount)
+	if size <= 0 {
+		return MarketItem{}, errors.New("paper: amount must be positive")
+	}
+	if size > p.account.Balances[base] {
+		return MarketItem{}, fmt.Errorf("paper: insufficient %s balance", base)
+	}
+	id, now := p.newID()
+	p.account.Balances[base] -= size
+	p.account.Balances[quote] += size * price
+	p.record(id, pair.String(), Sell.String(), price, size, "T", now)
+	if err := p.save(); err != nil {
+		return MarketItem{}, err
+	}
+	return MarketItem{Success: "true", Id: uint64(id), Amount: formatAmount(size), OrderType: MarketSell.String(), Pair: pair.String(), CreatedAt: now.Format(time.RFC3339)}, nil
+}
+
+func (p *PaperExchange) LimitOrder(conf Config, pair Pair, orderType OrderType, rate, amount, stopLossRate string) (MarketItem, error) {
+	if orderType != Buy && orderType != Sell {
+		return MarketItem{}, fmt.Errorf("paper: %s is not a limit order", orderType)
+	}
+	o := &paperOrder{Pair: pair.String(), Side: orderType.String(), Active: true}
+	var err error
+	if o.Rate, err = strconv.ParseFloat(rate, 64); err != nil || o.Rate <= 0 {
+		return MarketItem{}, fmt.Errorf("paper: invalid rate %q", rate)
+	}
+	if o.Pending, err = strconv.ParseFloat(amount, 64); err != nil || o.Pending <= 0 {
+		return MarketItem{}, fmt.Errorf("paper: invalid amount %q", amount)
+	}
+	if stopLossRate != "" {
+		if o.StopLoss, err = strconv.ParseFloat(stopLossRate, 64); err != nil || o.StopLoss <= 0 {
+			return MarketItem{}, fmt.Errorf("paper: invalid stop_loss_rate %q", stopLossRate)
+		}
+		o.Active = false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	base, quote := currencies(o.Pair)
+	cur, need := base, o.Pending
+	if orderType == Buy {
+		cur, need = quote, o.Rate*o.Pending
+	}
+	if need > p.account.Balances[cur] {
+		return MarketItem{}, fmt.Errorf("paper: insufficient %s balance", cur)
+	}
+	p.account.Balances[cur] -= need
+	p.account.Reserved[cur] += need
+	o.ID, o.CreatedAt = p.newID()
+	p.account.Orders = append(p.account.Orders, o)
+	if price, ok := p.prices[o.Pair]; ok {
+		if !o.Active && ((orderType == Buy && price >= o.StopLoss) || (orderType == Sell && price <= o.StopLoss)) {
+			o.Active = true
+		}
+		if o.Active && ((orderType == Buy && price <= o.Rate) || (orderType == Sell && price >= o.Rate)) {
+			p.fill(o, price, o.Pending, "T", o.CreatedAt)
+			p.dropFilled()
+		}
+	}
+	if err := p.save(); err != nil {
+		return MarketItem{}, err
+	}
+	return MarketItem{
+		Success:      "true",
+		Id:           uint64(o.ID),
+		Rate:         rate,
+		Amount:       amount,
+		OrderType:    orderType.String(),
+		StopLossRate: stopLossRate,
+		Pair:         o.Pair,
+		CreatedAt:    o.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (p *PaperExchange) OpenOrders(conf Config) (OrdersOpensItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	item := OrdersOpensItem{Success: true}
+	for _, o := range p.account.Orders {
+		open := &OpenItem{
+			Id:            o.ID,
+			OrderType:     o.Side,
+			Rate:          uint32(o.Rate),
+			PendingAmount: formatAmount(o.Pending),
+			CreatedAt:     o.CreatedAt.Format(time.RFC3339),
+			Pair:          o.Pair,
+		}
+		if o.StopLoss > 0 {
+			open.StopLossRate = formatAmount(o.StopLoss)
+		}
+		item.Orders = append(item.Orders, open)
+	}
+	return item, nil
+}
+
+func (p *PaperExchange) CancelOrder(conf Config, id uint32) (DeleteOrderItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, o := range p.account.Orders {
+		if o.ID != id {
+			continue
+		}
+		base, quote := currencies(o.Pair)
+		cur, held := base, o.Pending
+		if o.Side == Buy.String() {
+			cur, held = quote, o.Rate*o.Pending
+		}
+		p.account.Reserved[cur] -= held
+		p.account.Balances[cur] += held
+		p.account.Orders = append(p.account.Orders[:i], p.account.Orders[i+1:]...)
+		if err := p.save(); err != nil {
+			return DeleteOrderItem{}, err
+		}
+		return DeleteOrderItem{Success: true, Id: id}, nil
+	}
+	return DeleteOrderItem{}, fmt.Errorf("paper: order %d is not open", id)
+}
+
+// Transactions returns the fills, newest first as Coincheck does.
+func (p *PaperExchange) Transactions(conf Config) (OrdersTransactionsItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	item := OrdersTransactionsItem{Success: true}
+	for i := len(p.account.Fills) - 1; i >= 0; i-- {
+		item.Transactions = append(item.Transactions, paperTransaction(p.account.Fills[i]))
+	}
+	return item, nil
+}
+
+// TransactionsPage returns a page of the fills, by ID as Coincheck does.
+func (p *PaperExchange) TransactionsPage(conf Config, page Pagenation) (OrdersTransactionsItem, error) {
+	after, before := uint64(0), uint64(math.MaxUint64)
+	var err error
+	if page.StartingAfter != "" {
+		if after, err = strconv.ParseUint(page.StartingAfter, 10, 32); err != nil {
+			return OrdersTransactionsItem{}, fmt.Errorf("paper: starting_after %q: %v", page.StartingAfter, err)
+		}
+	}
+	if page.EndingBefore != "" {
+		if before, err = strconv.ParseUint(page.EndingBefore, 10, 32); err != nil {
+			return OrdersTransactionsItem{}, fmt.Errorf("paper: ending_before %q: %v", page.EndingBefore, err)
+		}
+	}
+	limit := int(page.Limit)
+	if limit == 0 {
+		limit = 10
+	}
+	asc := page.Order == "asc"
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	item := OrdersTransactionsItem{Success: true}
+	n := len(p.account.Fills)
+	for i := 0; i < n && len(item.Transactions) < limit; i++ {
+		f := p.account.Fills[n-1-i]
+		if asc {
+			f = p.account.Fills[i]
+		}
+		id := uint64(f.ID)
+		// starting_after skips the fills up to the ID in the order of the
+		// page, ending_before those from it.
+		if page.StartingAfter != "" && ((!asc && id >= after) || (asc && id <= after)) {
+			continue
+		}
+		if page.EndingBefore != "" && ((!asc && id <= before) || (asc && id >= before)) {
+			continue
+		}
+		item.Transactions = append(item.Transactions, paperTransaction(f))
+	}
+	return item, nil
+}
+
+// paperTransaction returns a fill as a transaction of Coincheck.
+func paperTransaction(f *paperFill) *TransactionsItem {
+	base, _ := currencies(f.Pair)
+	amount, cost := f.Amount, -f.Amount*f.Rate
+	if f.Side == Sell.String() {
+		amount, cost = -amount, -cost
+	}
+	funds := &Funds{Jpy: formatAmount(cost), Base: formatAmount(amount)}
+	if base == "btc" {
+		funds.Btc = funds.Base
+	}
+	return &TransactionsItem{
+		Id:          f.ID,
+		OrderId:     f.OrderID,
+		CreatedAt:   f.Time.Format(time.RFC3339),
+		Funds:       funds,
+		Pair:        f.Pair,
+		Rate:        formatAmount(f.Rate),
+		FeeCurrency: "",
+		Fee:         "0",
+		Liquidity:   f.Liquidity,
+		Side:        f.Side,
+	}
+}
+
+func (p *PaperExchange) Balance(conf Config) (AccountsBalanceItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	a := p.account
+	return AccountsBalanceItem{
+		Success:      true,
+		Jpy:          formatAmount(a.Balances["jpy"]),
+		Btc:          formatAmount(a.Balances["btc"]),
+		JpyReserved:  formatAmount(a.Reserved["jpy"]),
+		BtcReserved:  formatAmount(a.Reserved["btc"]),
+		JpyLendInUse: "0",
+		BtcLendInUse: "0",
+		JpyLent:      "0",
+		BtcLent:      "0",
+		JpyDebt:      "0",
+		BtcDebt:      "0",
+	}, nil
+}
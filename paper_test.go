@@ -0,0 +1,215 @@
+package bitcocheck
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestPaper(t *testing.T, jpy, btc float64) (*PaperExchange, string) {
+	dir, err := ioutil.TempDir("", "paper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(dir, "paper.json")
+	p, err := NewPaperExchange(PaperConfig{JPY: jpy, BTC: btc, StateFile: name})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return p, dir
+}
+
+func TestPaperExchange(t *testing.T) {
+	at := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	var ex Exchange
+	p, dir := newTestPaper(t, 1000000, 0)
+	defer os.RemoveAll(dir)
+	p.now = func() time.Time { return at }
+	ex = p
+	conf := Config{}
+
+	type balance struct{ jpy, btc, jpyReserved, btcReserved string }
+	steps := []struct {
+		name        string
+		do          func() error
+		wantErr     bool
+		wantBalance balance
+		wantOpens   int
+		wantFills   int
+	}{
+		{
+			name:        "market buy needs a price",
+			do:          func() error { _, err := ex.MarketBuy(conf, Btcjpy, 100000); return err },
+			wantErr:     true,
+			wantBalance: balance{"1000000", "0", "0", "0"},
+		},
+		{
+			name: "market buy at the last price",
+			do: func() error {
+				if err := p.Trade("btc_jpy", 1000000, 1, at); err != nil {
+					return err
+				}
+				_, err := ex.MarketBuy(conf, Btcjpy, 100000)
+				return err
+			},
+			wantBalance: balance{"900000", "0.1", "0", "0"},
+			wantFills:   1,
+		},
+		{
+			name:        "limit buy below the price rests",
+			do:          func() error { _, err := ex.LimitOrder(conf, Btcjpy, Buy, "900000", "0.2", ""); return err },
+			wantBalance: balance{"720000", "0.1", "180000", "0"},
+			wantOpens:   1,
+			wantFills:   1,
+		},
+		{
+			name:        "a trade older than the order does not fill",
+			do:          func() error { return p.Trade("btc_jpy", 890000, 1, at.Add(-time.Minute)) },
+			wantBalance: balance{"720000", "0.1", "180000", "0"},
+			wantOpens:   1,
+			wantFills:   1,
+		},
+		{
+			name:        "a trade above the rate does not fill",
+			do:          func() error { return p.Trade("btc_jpy", 950000, 1, at.Add(time.Minute)) },
+			wantBalance: balance{"720000", "0.1", "180000", "0"},
+			wantOpens:   1,
+			wantFills:   1,
+		},
+		{
+			name:        "a small trade fills part",
+			do:          func() error { return p.Trade("btc_jpy", 900000, 0.05, at.Add(2*time.Minute)) },
+			wantBalance: balance{"720000", "0.15", "135000", "0"},
+			wantOpens:   1,
+			wantFills:   2,
+		},
+		{
+			name:        "a lower trade fills the rest at the rate",
+			do:          func() error { return p.Trade("btc_jpy", 890000, 1, at.Add(3*time.Minute)) },
+			wantBalance: balance{"720000", "0.3", "0", "0"},
+			wantFills:   3,
+		},
+		{
+			name:        "limit sell crossing the price fills at once",
+			do:          func() error { _, err := ex.LimitOrder(conf, Btcjpy, Sell, "880000", "0.1", ""); return err },
+			wantBalance: balance{"809000", "0.2", "0", "0"},
+			wantFills:   4,
+		},
+		{
+			name:        "stop sell waits for its trigger",
+			do:          func() error { _, err := ex.LimitOrder(conf, Btcjpy, Sell, "800000", "0.1", "850000"); return err },
+			wantBalance: balance{"809000", "0.1", "0", "0.1"},
+			wantOpens:   1,
+			wantFills:   4,
+		},
+		{
+			name:        "the trigger fills the stop",
+			do:          func() error { return p.Trade("btc_jpy", 840000, 1, at.Add(4*time.Minute)) },
+			wantBalance: balance{"889000", "0.1", "0", "0"},
+			wantFills:   5,
+		},
+		{
+			name:        "insufficient balance",
+			do:          func() error { _, err := ex.LimitOrder(conf, Btcjpy, Sell, "900000", "1", ""); return err },
+			wantErr:     true,
+			wantBalance: balance{"889000", "0.1", "0", "0"},
+			wantFills:   5,
+		},
+		{
+			name:        "invalid rate",
+			do:          func() error { _, err := ex.LimitOrder(conf, Btcjpy, Buy, "x", "1", ""); return err },
+			wantErr:     true,
+			wantBalance: balance{"889000", "0.1", "0", "0"},
+			wantFills:   5,
+		},
+		{
+			name: "cancel releases the reserve",
+			do: func() error {
+				item, err := ex.LimitOrder(conf, Btcjpy, Buy, "500000", "0.1", "")
+				if err != nil {
+					return err
+				}
+				_, err = ex.CancelOrder(conf, uint32(item.Id))
+				return err
+			},
+			wantBalance: balance{"889000", "0.1", "0", "0"},
+			wantFills:   5,
+		},
+		{
+			name:        "cancel of an unknown order",
+			do:          func() error { _, err := ex.CancelOrder(conf, 999); return err },
+			wantErr:     true,
+			wantBalance: balance{"889000", "0.1", "0", "0"},
+			wantFills:   5,
+		},
+		{
+			name:        "market sell over the balance",
+			do:          func() error { _, err := ex.MarketSell(conf, Btcjpy, 1); return err },
+			wantErr:     true,
+			wantBalance: balance{"889000", "0.1", "0", "0"},
+			wantFills:   5,
+		},
+	}
+	for _, s := range steps {
+		err := s.do()
+		if (err != nil) != s.wantErr {
+			t.Fatalf("%s: error = %v, wantErr %v", s.name, err, s.wantErr)
+		}
+		b, _ := ex.Balance(conf)
+		if got := (balance{b.Jpy, b.Btc, b.JpyReserved, b.BtcReserved}); got != s.wantBalance {
+			t.Errorf("%s: balance %+v, want %+v", s.name, got, s.wantBalance)
+		}
+		opens, _ := ex.OpenOrders(conf)
+		if len(opens.Orders) != s.wantOpens {
+			t.Errorf("%s: %d open orders, want %d", s.name, len(opens.Orders), s.wantOpens)
+		}
+		txs, _ := ex.Transactions(conf)
+		if len(txs.Transactions) != s.wantFills {
+			t.Errorf("%s: %d transactions, want %d", s.name, len(txs.Transactions), s.wantFills)
+		}
+	}
+
+	txs, _ := ex.Transactions(conf)
+	last := txs.Transactions[0]
+	if last.Side != "sell" || last.Rate != "800000" || last.Funds.Btc != "-0.1" || last.Funds.Jpy != "80000" || last.Liquidity != "M" {
+		t.Errorf("newest transaction %+v, want the stop sell of 0.1 at 800000", last)
+	}
+	if last.Funds.Base != "-0.1" {
+		t.Errorf("newest transaction base amount %s, want -0.1", last.Funds.Base)
+	}
+	paged := []uint32{}
+	page := Pagenation{Limit: 2, Order: "desc"}
+	for i := 0; i < 5; i++ {
+		item, err := ex.TransactionsPage(conf, page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(item.Transactions) == 0 {
+			break
+		}
+		for _, tx := range item.Transactions {
+			paged = append(paged, tx.Id)
+		}
+		page.StartingAfter = strconv.FormatUint(uint64(item.Transactions[len(item.Transactions)-1].Id), 10)
+	}
+	if len(paged) != len(txs.Transactions) || paged[0] != last.Id || paged[len(paged)-1] != txs.Transactions[len(txs.Transactions)-1].Id {
+		t.Errorf("pages of 2 = %v, want the %d transactions newest first", paged, len(txs.Transactions))
+	}
+	since, err := TransactionsSince(ex, conf, time.Time{})
+	if err != nil || len(since.Transactions) != len(txs.Transactions) {
+		t.Errorf("TransactionsSince() = %d transactions, %v, want %d", len(since.Transactions), err, len(txs.Transactions))
+	}
+
+	reopened, err := NewPaperExchange(PaperConfig{JPY: 1, StateFile: filepath.Join(dir, "paper.json")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := reopened.Balance(conf)
+	if b.Jpy != "889000" || b.Btc != "0.1" {
+		t.Errorf("reopened balance jpy %s btc %s, want the saved 889000 and 0.1", b.Jpy, b.Btc)
+	}
+}
@@ -0,0 +1,182 @@
+package bitcocheck
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// States of a recurring order.
+const (
+	RecurringActive    = "active"
+	RecurringCancelled = "cancelled"
+)
+
+// Sources of a recurring order.
+const (
+	RecurringFromConfig = "config"
+	RecurringFromRPC    = "rpc"
+)
+
+// Outcomes of a run of a recurring order.
+const (
+	// RunPlaced The order was placed.
+	RunPlaced = "placed"
+	// RunSkipped No order was sent, e.g. the rate was past the limit rate.
+	RunSkipped = "skipped"
+	// RunFailed The order was refused, or the rate could not be read.
+	RunFailed = "failed"
+	// RunUnknown The order was sent but its answer was lost. It is settled
+	// by its client order ID.
+	RunUnknown = "unknown"
+)
+
+// maxRecurringName The longest name of a recurring order, which keeps the
+// client order IDs of its runs short.
+const maxRecurringName = 32
+
+// RecurringConfig A recurring order of the [[recurring]] config: buy or sell
+// amount JPY of the pair on the cron schedule.
+type RecurringConfig struct {
+	Name      string  `toml:"name"`
+	Pair      string  `toml:"pair"`
+	Side      string  `toml:"side"`
+	Amount    uint32  `toml:"amount"`
+	Schedule  string  `toml:"schedule"`
+	LimitRate float64 `toml:"limit_rate"`
+}
+
+// Order returns the recurring order of the config entry.
+func (c RecurringConfig) Order(now time.Time) RecurringOrder {
+	return RecurringOrder{
+		ID:        "config-" + c.Name,
+		Name:      c.Name,
+		Pair:      c.Pair,
+		Side:      c.Side,
+		Amount:    float64(c.Amount),
+		Schedule:  c.Schedule,
+		LimitRate: c.LimitRate,
+		State:     RecurringActive,
+		Source:    RecurringFromConfig,
+		Created:   now,
+		Updated:   now,
+	}
+}
+
+// RecurringOrder An order of Amount JPY sent on a cron schedule, e.g. to buy
+// a fixed amount every week. LimitRate, 0 for none, is the highest rate of a
+// buy and the lowest of a sell: a run past it is skipped.
+type RecurringOrder struct {
+	ID        string
+	Name      string
+	Pair      string
+	Side      string
+	Amount    float64
+	Schedule  string
+	LimitRate float64
+	State     string
+	Source    string
+	Created   time.Time
+	Updated   time.Time
+}
+
+// RecurringRun One run of a recurring order and its outcome. Amount is in JPY
+// for a market_buy.
+type RecurringRun struct {
+	ID          uint64
+	RecurringID string
+	Time        time.Time
+	Outcome     string
+	Reason      string
+	OrderType   string
+	Rate        string
+	Amount      string
+	ClientID    string
+	OrderID     uint64
+}
+
+// RecurringRunQuery Selects runs, of every order for an empty RecurringID. A
+// zero Limit is unbounded.
+type RecurringRunQuery struct {
+	RecurringID string
+	Limit       int
+}
+
+// RecurringOrderStore The recurring orders of the server and their runs.
+type RecurringOrderStore interface {
+	// SaveRecurringOrder saves an order, replacing the order of the same ID.
+	SaveRecurringOrder(o RecurringOrder) error
+	// RecurringOrder returns an order, false when there is none.
+	RecurringOrder(id string) (RecurringOrder, bool, error)
+	// RecurringOrders returns every order, oldest first.
+	RecurringOrders() ([]RecurringOrder, error)
+	// AddRecurringRun appends a run to the history.
+	AddRecurringRun(r RecurringRun) error
+	// RecurringRuns returns the runs of the query, newest first.
+	RecurringRuns(q RecurringRunQuery) ([]RecurringRun, error)
+}
+
+// Validate checks an order before it is saved. The schedule is checked by
+// the scheduler.
+func (o RecurringOrder) Validate() error {
+	if len(o.Name) > maxRecurringName {
+		return fmt.Errorf("name longer than %d bytes", maxRecurringName)
+	}
+	if _, err := ParsePair(o.Pair); err != nil {
+		return err
+	}
+	if o.Side != Buy.String() && o.Side != Sell.String() {
+		return fmt.Errorf("side %q is neither buy nor sell", o.Side)
+	}
+	if o.Amount < 1 || o.Amount != math.Trunc(o.Amount) || o.Amount > math.MaxUint32 {
+		return fmt.Errorf("the amount is a whole number of JPY, not %s", formatAmount(o.Amount))
+	}
+	if o.Schedule == "" {
+		return fmt.Errorf("no schedule")
+	}
+	if o.LimitRate < 0 {
+		return fmt.Errorf("invalid limit rate %s", formatAmount(o.LimitRate))
+	}
+	return nil
+}
+
+// NeedsRate tells whether a run reads the rate of the pair: a market buy
+// of JPY without a limit rate does not.
+func (o RecurringOrder) NeedsRate() bool {
+	return o.Side == Sell.String() || o.LimitRate > 0
+}
+
+// Plan returns the order of a run at the ask and bid of the pair, or why the
+// run is skipped. A buy without a limit rate is a market buy of the JPY
+// amount; the other runs are limit orders at the ask for a buy, the bid for a
+// sell, of the base amount worth the JPY amount.
+func (o RecurringOrder) Plan(ask, bid float64) (orderType OrderType, rate, amount, skip string) {
+	if o.Side == Buy.String() && o.LimitRate == 0 {
+		return MarketBuy, "", formatAmount(o.Amount), ""
+	}
+	orderType, price := Buy, ask
+	if o.Side == Sell.String() {
+		orderType, price = Sell, bid
+	}
+	if price <= 0 {
+		return orderType, "", "", "no rate"
+	}
+	if o.LimitRate > 0 && orderType == Buy && price > o.LimitRate {
+		return orderType, "", "", fmt.Sprintf("ask %s above the limit rate %s", formatAmount(price), formatAmount(o.LimitRate))
+	}
+	if o.LimitRate > 0 && orderType == Sell && price < o.LimitRate {
+		return orderType, "", "", fmt.Sprintf("bid %s below the limit rate %s", formatAmount(price), formatAmount(o.LimitRate))
+	}
+	base := math.Floor(o.Amount/price*1e8) / 1e8
+	if base <= 0 {
+		return orderType, "", "", fmt.Sprintf("%s JPY is less than the smallest amount at %s", formatAmount(o.Amount), formatAmount(price))
+	}
+	return orderType, formatAmount(price), formatAmount(base), ""
+}
+
+// RecurringClientID returns the client order ID of the run of an order at a
+// time. Runs in the same minute share it, so that the servers sharing a store
+// send the order of a run once.
+func RecurringClientID(o RecurringOrder, at time.Time) string {
+	return fmt.Sprintf("dca-%s-%d", o.ID, at.Truncate(time.Minute).Unix())
+}
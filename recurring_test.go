@@ -0,0 +1,81 @@
+package bitcocheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurringOrderPlan(t *testing.T) {
+	buy := RecurringOrder{ID: "a", Pair: "btc_jpy", Side: "buy", Amount: 10000, Schedule: "@weekly", State: RecurringActive}
+	with := func(o RecurringOrder, side string, limit float64) RecurringOrder {
+		o.Side, o.LimitRate = side, limit
+		return o
+	}
+	tests := []struct {
+		name       string
+		o          RecurringOrder
+		ask, bid   float64
+		wantType   OrderType
+		wantRate   string
+		wantAmount string
+		wantSkip   bool
+	}{
+		{name: "market buy of JPY", o: buy, wantType: MarketBuy, wantAmount: "10000"},
+		{name: "limit buy at the ask", o: with(buy, "buy", 1200000), ask: 1000000, bid: 999000, wantType: Buy, wantRate: "1000000", wantAmount: "0.01"},
+		{name: "amount rounded down", o: with(buy, "buy", 4000000), ask: 3000000, bid: 2999000, wantType: Buy, wantRate: "3000000", wantAmount: "0.00333333"},
+		{name: "buy above the limit rate", o: with(buy, "buy", 900000), ask: 1000000, bid: 999000, wantType: Buy, wantSkip: true},
+		{name: "sell at the bid", o: with(buy, "sell", 0), ask: 1001000, bid: 1000000, wantType: Sell, wantRate: "1000000", wantAmount: "0.01"},
+		{name: "sell below the limit rate", o: with(buy, "sell", 1100000), ask: 1001000, bid: 1000000, wantType: Sell, wantSkip: true},
+		{name: "no rate", o: with(buy, "sell", 0), wantType: Sell, wantSkip: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderType, rate, amount, skip := tt.o.Plan(tt.ask, tt.bid)
+			if (skip != "") != tt.wantSkip {
+				t.Fatalf("Plan() skip = %q, want %v", skip, tt.wantSkip)
+			}
+			if orderType != tt.wantType || rate != tt.wantRate || amount != tt.wantAmount {
+				t.Errorf("Plan() = %v %q %q, want %v %q %q", orderType, rate, amount, tt.wantType, tt.wantRate, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestRecurringOrderValidate(t *testing.T) {
+	valid := RecurringOrder{Name: "weekly", Pair: "btc_jpy", Side: "buy", Amount: 10000, Schedule: "0 9 * * 1"}
+	tests := []struct {
+		name    string
+		change  func(o *RecurringOrder)
+		wantErr bool
+	}{
+		{name: "weekly buy", change: func(o *RecurringOrder) {}},
+		{name: "sell with a limit rate", change: func(o *RecurringOrder) { o.Side, o.LimitRate = "sell", 1000000 }},
+		{name: "long name", change: func(o *RecurringOrder) { o.Name = "a-name-much-longer-than-thirty-two-bytes" }, wantErr: true},
+		{name: "unknown pair", change: func(o *RecurringOrder) { o.Pair = "doge_jpy" }, wantErr: true},
+		{name: "unknown side", change: func(o *RecurringOrder) { o.Side = "hold" }, wantErr: true},
+		{name: "fraction of a yen", change: func(o *RecurringOrder) { o.Amount = 100.5 }, wantErr: true},
+		{name: "no amount", change: func(o *RecurringOrder) { o.Amount = 0 }, wantErr: true},
+		{name: "no schedule", change: func(o *RecurringOrder) { o.Schedule = "" }, wantErr: true},
+		{name: "negative limit rate", change: func(o *RecurringOrder) { o.LimitRate = -1 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := valid
+			tt.change(&o)
+			if err := o.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecurringClientID(t *testing.T) {
+	o := RecurringOrder{ID: "config-weekly"}
+	at := time.Date(2020, 9, 7, 9, 0, 0, 0, time.UTC)
+	if a, b := RecurringClientID(o, at), RecurringClientID(o, at.Add(40*time.Second)); a != b || a != "dca-config-weekly-1599469200" {
+		t.Errorf("RecurringClientID() = %s, %s", a, b)
+	}
+	if a, b := RecurringClientID(o, at), RecurringClientID(o, at.Add(time.Minute)); a == b {
+		t.Errorf("RecurringClientID() of the next minute = %s", b)
+	}
+}
@@ -2,6 +2,7 @@ package bitcocheck
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -9,6 +10,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -19,6 +21,10 @@ type APIInfo struct {
 	Url    string
 	Body   string
 	Debug  bool
+	// Recorder, when set, gets the request like the request hooks.
+	Recorder func(RequestEvent)
+	// Ctx, when set, holds the parent span of the request's trace span.
+	Ctx context.Context
 }
 
 func NewAPIInfo(access, secret, url, body string, debug bool) APIInfo {
@@ -41,92 +47,102 @@ func (a APIInfo) Signature() string {
 	return hex.EncodeToString(mac.Sum(nil))
 }
 
-func (a APIInfo) Request() ([]byte, error) {
+// RequestEvent One finished request to the Coincheck API. Status is 0 when
+// no response was received. Body is the request body and Response the
+// response body; neither contains the credentials, which are sent in headers.
+type RequestEvent struct {
+	Method   string
+	URL      string
+	Body     string
+	Status   int
+	Response []byte
+	Elapsed  time.Duration
+	Err      error
+}
 
-	// Generated by curl-to-Go: https://mholt.github.io/curl-to-go
-	var buf []byte
-	req, err := http.NewRequest("GET", a.Url, nil)
-	if err != nil {
-		// handle err
-		return buf, err
-	}
-	signature := a.Signature()
-	req.Header.Set("Access-Key", a.Access)
-	req.Header.Set("Access-Nonce", a.Nonce)
-	req.Header.Set("Access-Signature", signature)
-	if a.Debug {
-		log.Println(a.Url)
-	}
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		// handle err
-		return buf, err
-	}
-	defer resp.Body.Close()
+// Failed tells whether the request failed on the side of the exchange or the
+// network, as opposed to an API error reported in the response.
+func (e RequestEvent) Failed() bool {
+	return e.Err != nil || e.Status >= 500
+}
 
-	buf, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		log.Fatal(err)
-	}
+var (
+	hookMu       sync.RWMutex
+	requestHooks []func(RequestEvent)
+)
 
-	return buf, nil
+// AddRequestHook registers fn to be called after every request to the
+// Coincheck API. fn must be safe for concurrent use.
+func AddRequestHook(fn func(RequestEvent)) {
+	hookMu.Lock()
+	defer hookMu.Unlock()
+	requestHooks = append(requestHooks, fn)
 }
 
-func (a APIInfo) PostRequest() ([]byte, error) {
-	var buf []byte
-	payload := bytes.NewReader([]byte(a.Body))
-	req, err := http.NewRequest("POST", a.Url, payload)
-	if err != nil {
-		return buf, err
+func notifyRequest(event RequestEvent) {
+	hookMu.RLock()
+	defer hookMu.RUnlock()
+	for _, fn := range requestHooks {
+		fn(event)
 	}
-	signature := a.Signature()
-	req.Header.Set("Content-Type", "application/json")
+}
+
+// do signs and sends the request and reads the response body.
+func (a APIInfo) do(req *http.Request) ([]byte, error) {
 	req.Header.Set("Access-Key", a.Access)
 	req.Header.Set("Access-Nonce", a.Nonce)
-	req.Header.Set("Access-Signature", signature)
+	req.Header.Set("Access-Signature", a.Signature())
 	if a.Debug {
 		log.Println(a.Url)
 	}
+	event := RequestEvent{Method: req.Method, URL: a.Url, Body: a.Body}
+	span := a.startSpan(req.Method)
+	start := time.Now()
+	defer func() {
+		event.Elapsed = time.Since(start)
+		endSpan(span, event)
+		notifyRequest(event)
+		if a.Recorder != nil {
+			a.Recorder(event)
+		}
+	}()
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return buf, err
+		event.Err = err
+		return nil, err
 	}
 	defer resp.Body.Close()
-	buf, err = ioutil.ReadAll(resp.Body)
+	event.Status = resp.StatusCode
+	buf, err := ioutil.ReadAll(resp.Body)
+	event.Response = buf
 	if err != nil {
-		log.Fatal(err)
+		event.Err = err
+		return buf, err
 	}
-
 	return buf, nil
 }
 
-func (a APIInfo) Delete() ([]byte, error) {
-
-	// Generated by curl-to-Go: https://mholt.github.io/curl-to-go
-	var buf []byte
-	req, err := http.NewRequest("DELETE", a.Url, nil)
+func (a APIInfo) Request() ([]byte, error) {
+	req, err := http.NewRequest("GET", a.Url, nil)
 	if err != nil {
-		// handle err
-		return buf, err
-	}
-	signature := a.Signature()
-	req.Header.Set("Access-Key", a.Access)
-	req.Header.Set("Access-Nonce", a.Nonce)
-	req.Header.Set("Access-Signature", signature)
-	if a.Debug {
-		log.Println(a.Url)
+		return nil, err
 	}
-	resp, err := http.DefaultClient.Do(req)
+	return a.do(req)
+}
+
+func (a APIInfo) PostRequest() ([]byte, error) {
+	req, err := http.NewRequest("POST", a.Url, bytes.NewReader([]byte(a.Body)))
 	if err != nil {
-		// handle err
-		return buf, err
+		return nil, err
 	}
-	defer resp.Body.Close()
+	req.Header.Set("Content-Type", "application/json")
+	return a.do(req)
+}
 
-	buf, err = ioutil.ReadAll(resp.Body)
+func (a APIInfo) Delete() ([]byte, error) {
+	req, err := http.NewRequest("DELETE", a.Url, nil)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-
-	return buf, nil
+	return a.do(req)
 }
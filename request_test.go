@@ -0,0 +1,60 @@
+package bitcocheck
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestAddRequestHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Access-Key") != "key" || r.Header.Get("Access-Signature") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer ts.Close()
+	var mu sync.Mutex
+	events := []RequestEvent{}
+	AddRequestHook(func(e RequestEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	})
+	tests := []struct {
+		name       string
+		send       func(a APIInfo) ([]byte, error)
+		url        string
+		wantMethod string
+		wantStatus int
+		wantFailed bool
+	}{
+		{name: "get", send: APIInfo.Request, url: ts.URL + "/ok", wantMethod: "GET", wantStatus: 200},
+		{name: "post", send: APIInfo.PostRequest, url: ts.URL + "/ok", wantMethod: "POST", wantStatus: 200},
+		{name: "delete down", send: APIInfo.Delete, url: ts.URL + "/down", wantMethod: "DELETE", wantStatus: 503, wantFailed: true},
+		{name: "unreachable", send: APIInfo.Request, url: "http://127.0.0.1:1/", wantMethod: "GET", wantFailed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			events = events[:0]
+			mu.Unlock()
+			tt.send(NewAPIInfo("key", "secret", tt.url, "{}", false))
+			mu.Lock()
+			defer mu.Unlock()
+			if len(events) != 1 {
+				t.Fatalf("hook called %d times, want 1", len(events))
+			}
+			e := events[0]
+			if e.Method != tt.wantMethod || e.URL != tt.url || e.Status != tt.wantStatus || e.Failed() != tt.wantFailed {
+				t.Errorf("event = %+v, want %s %d failed %v", e, tt.wantMethod, tt.wantStatus, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package bitcocheck
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Risk rules, the Rule of a RiskViolation.
+const (
+	RuleOrderNotional   = "max_order_notional"
+	RulePosition        = "max_position"
+	RuleDailyLoss       = "daily_loss_limit"
+	RuleOrdersPerMinute = "max_orders_per_minute"
+	RulePriceBand       = "price_band"
+	RulePrice           = "max_price_age"
+)
+
+// RiskConfig Pre-trade limits of the orders. A zero limit is not checked.
+type RiskConfig struct {
+	// MaxOrderNotional is the largest order value in JPY.
+	MaxOrderNotional float64 `toml:"max_order_notional"`
+	// MaxPosition is the largest holding of the base currency by pair, only
+	// btc_jpy = 0.5 for now.
+	MaxPosition map[string]float64 `toml:"max_position"`
+	// DailyLossLimit stops the orders once the account value in JPY has
+	// dropped this much since its first valuation of the day.
+	DailyLossLimit float64 `toml:"daily_loss_limit"`
+	// MaxOrdersPerMinute is the most orders accepted in any minute.
+	MaxOrdersPerMinute int `toml:"max_orders_per_minute"`
+	// PriceBand is how far the rate of a limit order may be from the last
+	// price, as a fraction: 0.05 is 5%.
+	PriceBand float64 `toml:"price_band"`
+	// MaxPriceAge is how old the last price may be for the checks needing
+	// it, "5m" when unset.
+	MaxPriceAge string `toml:"max_price_age"`
+	// HaltOn are the rules whose breach halts trading.
+	HaltOn []string `toml:"halt_on"`
+	// HaltCancelOrders also cancels the open orders on such a halt.
+	HaltCancelOrders bool `toml:"halt_cancel_orders"`
+}
+
+// Halts reports whether a breach of the rule halts trading.
+func (c RiskConfig) Halts(rule string) bool {
+	for _, r := range c.HaltOn {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// PriceAge returns how old the last price may be.
+func (c RiskConfig) PriceAge() (time.Duration, error) {
+	if c.MaxPriceAge == "" {
+		return 5 * time.Minute, nil
+	}
+	d, err := time.ParseDuration(c.MaxPriceAge)
+	if err != nil {
+		return 0, fmt.Errorf("max_price_age: %v", err)
+	}
+	return d, nil
+}
+
+// RiskOrder An order to check.
+type RiskOrder struct {
+	Pair string
+	Type OrderType
+	// Rate is the price of a limit order.
+	Rate float64
+	// Amount is the base currency bought or sold, unknown for a market buy.
+	Amount float64
+	// Funds is the JPY spent by a market buy.
+	Funds float64
+}
+
+// RiskViolation An order rejected by a risk rule.
+type RiskViolation struct {
+	Rule   string
+	Reason string
+}
+
+func (v *RiskViolation) Error() string {
+	return fmt.Sprintf("risk check %s: %s", v.Rule, v.Reason)
+}
+
+type lastPrice struct {
+	rate float64
+	time time.Time
+}
+
+// reservation The position moved by an allowed order.
+type reservation struct {
+	order  RiskOrder
+	amount float64
+	at     time.Time
+}
+
+// RiskEngine Checks the orders against a RiskConfig. It learns the prices and
+// the account from the ticker, the trades and the balance refreshes. It is
+// safe for concurrent use.
+type RiskEngine struct {
+	conf     RiskConfig
+	priceAge time.Duration
+
+	mu        sync.Mutex
+	prices    map[string]lastPrice
+	positions map[string]float64
+	reserved  []reservation
+	orders    []time.Time
+	day       string
+	dayStart  float64
+	equity    float64
+}
+
+// NewRiskEngine returns the engine of the config.
+func NewRiskEngine(conf RiskConfig) (*RiskEngine, error) {
+	age, err := conf.PriceAge()
+	if err != nil {
+		return nil, err
+	}
+	for _, limit := range []float64{conf.MaxOrderNotional, conf.DailyLossLimit, conf.PriceBand} {
+		if limit < 0 {
+			return nil, fmt.Errorf("negative risk limit %v", limit)
+		}
+	}
+	for _, rule := range conf.HaltOn {
+		switch rule {
+		case RuleOrderNotional, RulePosition, RuleDailyLoss, RuleOrdersPerMinute, RulePriceBand, RulePrice:
+		default:
+			return nil, fmt.Errorf("halt_on: unknown rule %q", rule)
+		}
+	}
+	for pair, limit := range conf.MaxPosition {
+		p, err := ParsePair(pair)
+		if err != nil {
+			return nil, fmt.Errorf("max_position: %v", err)
+		}
+		// The balance only tells the btc holding, the position of the other
+		// pairs would drift from the account.
+		if p != Btcjpy {
+			return nil, fmt.Errorf("max_position: only %s is supported, not %s", Btcjpy, pair)
+		}
+		if limit < 0 {
+			return nil, fmt.Errorf("max_position %s: negative limit %v", pair, limit)
+		}
+	}
+	return &RiskEngine{
+		conf:      conf,
+		priceAge:  age,
+		prices:    map[string]lastPrice{},
+		positions: map[string]float64{},
+	}, nil
+}
+
+// UpdatePrice records a price of the pair. Older prices than the known one
+// are ignored.
+func (r *RiskEngine) UpdatePrice(pair string, rate float64, at time.Time) {
+	if rate <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if last, ok := r.prices[pair]; ok && at.Before(last.time) {
+		return
+	}
+	r.prices[pair] = lastPrice{rate: rate, time: at}
+}
+
+// UpdateBalance takes the holdings and the account value from a balance
+// fetched at at, and the open orders fetched after it. A limit buy only
+// reserves JPY until it fills, so the btc_jpy position is the balance plus
+// the pending amount of the open btc_jpy buys and of the orders allowed since
+// at, which neither may show yet. The value is only known once there is a
+// btc_jpy price.
+func (r *RiskEngine) UpdateBalance(item AccountsBalanceItem, opens OrdersOpensItem, at time.Time) {
+	sum := func(values ...string) float64 {
+		total := 0.0
+		for _, v := range values {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				total += f
+			}
+		}
+		return total
+	}
+	jpy := sum(item.Jpy, item.JpyReserved) - sum(item.JpyDebt)
+	btc := sum(item.Btc, item.BtcReserved) - sum(item.BtcDebt)
+	position := btc
+	for _, open := range opens.Orders {
+		// An order without a pair is counted, the limit errs on the safe side.
+		if open.OrderType == Buy.String() && (open.Pair == "" || open.Pair == Btcjpy.String()) {
+			position += sum(open.PendingAmount)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	recent := r.reserved[:0]
+	for _, res := range r.reserved {
+		if res.at.Before(at) {
+			continue
+		}
+		recent = append(recent, res)
+		if res.order.Pair == Btcjpy.String() {
+			position += res.amount
+		}
+	}
+	r.reserved = recent
+	r.positions[Btcjpy.String()] = position
+	price, ok := r.prices[Btcjpy.String()]
+	if !ok {
+		return
+	}
+	r.equity = jpy + btc*price.rate
+	if day := at.Format("2006-01-02"); day != r.day {
+		r.day = day
+		r.dayStart = r.equity
+	}
+}
+
+// DailyLoss returns how much the account value dropped today.
+func (r *RiskEngine) DailyLoss() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return math.Max(0, r.dayStart-r.equity)
+}
+
+// Breach returns the violation of the daily loss limit, nil while the
+// account is within it.
+func (r *RiskEngine) Breach() *RiskViolation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.breach()
+}
+
+func (r *RiskEngine) breach() *RiskViolation {
+	if limit := r.conf.DailyLossLimit; limit > 0 && r.dayStart-r.equity >= limit {
+		return &RiskViolation{Rule: RuleDailyLoss, Reason: fmt.Sprintf("the account lost %.0f JPY today, the limit is %.0f", r.dayStart-r.equity, limit)}
+	}
+	return nil
+}
+
+// price returns the last price of the pair, unless it is too old.
+func (r *RiskEngine) price(pair string, now time.Time) (float64, error) {
+	last, ok := r.prices[pair]
+	if !ok || now.Sub(last.time) > r.priceAge {
+		return 0, &RiskViolation{Rule: RulePrice, Reason: fmt.Sprintf("no %s price newer than %v", pair, r.priceAge)}
+	}
+	return last.rate, nil
+}
+
+// size returns the base amount and the JPY value of an order.
+func (r *RiskEngine) size(o RiskOrder, now time.Time) (amount, notional float64, err error) {
+	switch o.Type {
+	case Buy, Sell:
+		return o.Amount, o.Amount * o.Rate, nil
+	case MarketBuy:
+		price, err := r.price(o.Pair, now)
+		if err != nil {
+			return 0, 0, err
+		}
+		return o.Funds / price, o.Funds, nil
+	default:
+		price, err := r.price(o.Pair, now)
+		if err != nil {
+			return 0, 0, err
+		}
+		return o.Amount, o.Amount * price, nil
+	}
+}
+
+// Allow checks an order and, when it passes, counts it against the order
+// rate and the position. An order that then fails is handed to Release.
+func (r *RiskEngine) Allow(o RiskOrder, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v := r.breach(); v != nil {
+		return v
+	}
+	if limit := r.conf.MaxOrdersPerMinute; limit > 0 {
+		recent := r.orders[:0]
+		for _, t := range r.orders {
+			if now.Sub(t) < time.Minute {
+				recent = append(recent, t)
+			}
+		}
+		r.orders = recent
+		if len(recent) >= limit {
+			return &RiskViolation{Rule: RuleOrdersPerMinute, Reason: fmt.Sprintf("%d orders in the last minute", len(recent))}
+		}
+	}
+	if band := r.conf.PriceBand; band > 0 && (o.Type == Buy || o.Type == Sell) {
+		price, err := r.price(o.Pair, now)
+		if err != nil {
+			return err
+		}
+		if math.Abs(o.Rate-price) > band*price {
+			return &RiskViolation{Rule: RulePriceBand, Reason: fmt.Sprintf("rate %v is more than %v%% from the last price %v", o.Rate, band*100, price)}
+		}
+	}
+	limit, hasLimit := r.conf.MaxPosition[o.Pair]
+	var amount float64
+	if r.conf.MaxOrderNotional > 0 || hasLimit {
+		var notional float64
+		var err error
+		amount, notional, err = r.size(o, now)
+		if err != nil {
+			return err
+		}
+		if max := r.conf.MaxOrderNotional; max > 0 && notional > max {
+			return &RiskViolation{Rule: RuleOrderNotional, Reason: fmt.Sprintf("order value %.0f JPY is over %.0f", notional, max)}
+		}
+	}
+	if hasLimit && (o.Type == Buy || o.Type == MarketBuy) {
+		if pos := r.positions[o.Pair] + amount; pos > limit {
+			return &RiskViolation{Rule: RulePosition, Reason: fmt.Sprintf("position %v of %s would be over %v", pos, o.Pair, limit)}
+		}
+	}
+	if r.conf.MaxOrdersPerMinute > 0 {
+		r.orders = append(r.orders, now)
+	}
+	if hasLimit {
+		r.reserve(o, amount, now)
+	}
+	return nil
+}
+
+// reserve moves the position of the pair by a buy or a sell, until a balance
+// fetched after it tells the order.
+func (r *RiskEngine) reserve(o RiskOrder, amount float64, at time.Time) {
+	if o.Type == Sell || o.Type == MarketSell {
+		amount = -amount
+	}
+	r.positions[o.Pair] += amount
+	r.reserved = append(r.reserved, reservation{order: o, amount: amount, at: at})
+}
+
+// Release takes back the position of an order that was allowed but not
+// placed. It still counts against the order rate. An order already left out
+// by a balance refresh has nothing to take back.
+func (r *RiskEngine) Release(o RiskOrder, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.reserved) - 1; i >= 0; i-- {
+		if res := r.reserved[i]; res.order == o {
+			r.positions[o.Pair] -= res.amount
+			r.reserved = append(r.reserved[:i], r.reserved[i+1:]...)
+			return
+		}
+	}
+}
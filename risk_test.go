@@ -0,0 +1,254 @@
+package bitcocheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRiskEngineAllow(t *testing.T) {
+	now := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		conf     RiskConfig
+		price    float64
+		priceAt  time.Time
+		balance  AccountsBalanceItem
+		orders   []RiskOrder
+		wantRule string
+	}{
+		{
+			name:   "no limits",
+			orders: []RiskOrder{{Pair: "btc_jpy", Type: MarketBuy, Funds: 1e9}},
+		},
+		{
+			name:   "notional under",
+			conf:   RiskConfig{MaxOrderNotional: 100000},
+			orders: []RiskOrder{{Pair: "btc_jpy", Type: Buy, Rate: 1000000, Amount: 0.1}},
+		},
+		{
+			name:     "notional over",
+			conf:     RiskConfig{MaxOrderNotional: 100000},
+			orders:   []RiskOrder{{Pair: "btc_jpy", Type: Sell, Rate: 1000000, Amount: 0.2}},
+			wantRule: RuleOrderNotional,
+		},
+		{
+			name:     "market sell valued at the last price",
+			conf:     RiskConfig{MaxOrderNotional: 100000},
+			price:    1000000,
+			priceAt:  now,
+			orders:   []RiskOrder{{Pair: "btc_jpy", Type: MarketSell, Amount: 1}},
+			wantRule: RuleOrderNotional,
+		},
+		{
+			name:     "market sell without a price",
+			conf:     RiskConfig{MaxOrderNotional: 100000},
+			orders:   []RiskOrder{{Pair: "btc_jpy", Type: MarketSell, Amount: 1}},
+			wantRule: RulePrice,
+		},
+		{
+			name:     "stale price",
+			conf:     RiskConfig{MaxOrderNotional: 100000, MaxPriceAge: "1m"},
+			price:    1000000,
+			priceAt:  now.Add(-2 * time.Minute),
+			orders:   []RiskOrder{{Pair: "btc_jpy", Type: MarketSell, Amount: 0.01}},
+			wantRule: RulePrice,
+		},
+		{
+			name:     "position adds up",
+			conf:     RiskConfig{MaxPosition: map[string]float64{"btc_jpy": 0.5}},
+			price:    1000000,
+			priceAt:  now,
+			balance:  AccountsBalanceItem{Btc: "0.2", BtcReserved: "0.1"},
+			orders:   []RiskOrder{{Pair: "btc_jpy", Type: Buy, Rate: 1000000, Amount: 0.1}, {Pair: "btc_jpy", Type: MarketBuy, Funds: 150000}},
+			wantRule: RulePosition,
+		},
+		{
+			name:    "sell lowers the position",
+			conf:    RiskConfig{MaxPosition: map[string]float64{"btc_jpy": 0.5}},
+			price:   1000000,
+			priceAt: now,
+			balance: AccountsBalanceItem{Btc: "0.5"},
+			orders:  []RiskOrder{{Pair: "btc_jpy", Type: MarketSell, Amount: 0.2}, {Pair: "btc_jpy", Type: Buy, Rate: 1000000, Amount: 0.2}},
+		},
+		{
+			name:     "orders per minute",
+			conf:     RiskConfig{MaxOrdersPerMinute: 2},
+			orders:   []RiskOrder{{Pair: "btc_jpy", Type: Buy}, {Pair: "btc_jpy", Type: Buy}, {Pair: "btc_jpy", Type: Buy}},
+			wantRule: RuleOrdersPerMinute,
+		},
+		{
+			name:    "in the price band",
+			conf:    RiskConfig{PriceBand: 0.05},
+			price:   1000000,
+			priceAt: now,
+			orders:  []RiskOrder{{Pair: "btc_jpy", Type: Buy, Rate: 960000, Amount: 0.1}},
+		},
+		{
+			name:     "out of the price band",
+			conf:     RiskConfig{PriceBand: 0.05},
+			price:    1000000,
+			priceAt:  now,
+			orders:   []RiskOrder{{Pair: "btc_jpy", Type: Sell, Rate: 1100000, Amount: 0.1}},
+			wantRule: RulePriceBand,
+		},
+		{
+			name:    "price band skips market orders",
+			conf:    RiskConfig{PriceBand: 0.05},
+			orders:  []RiskOrder{{Pair: "btc_jpy", Type: MarketBuy, Funds: 1000}},
+			priceAt: now,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRiskEngine(tt.conf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.price > 0 {
+				r.UpdatePrice("btc_jpy", tt.price, tt.priceAt)
+			}
+			r.UpdateBalance(tt.balance, OrdersOpensItem{}, now)
+			var gotRule string
+			for _, o := range tt.orders {
+				if err := r.Allow(o, now); err != nil {
+					v, ok := err.(*RiskViolation)
+					if !ok {
+						t.Fatalf("Allow() error = %v, want a RiskViolation", err)
+					}
+					gotRule = v.Rule
+					break
+				}
+			}
+			if gotRule != tt.wantRule {
+				t.Errorf("rejected by %q, want %q", gotRule, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestRiskEngineDailyLoss(t *testing.T) {
+	r, err := NewRiskEngine(RiskConfig{DailyLossLimit: 50000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2020, 9, 1, 9, 0, 0, 0, time.Local)
+	buy := RiskOrder{Pair: "btc_jpy", Type: Buy, Rate: 1000000, Amount: 0.01}
+	steps := []struct {
+		at       time.Time
+		price    float64
+		btc      string
+		wantLoss float64
+		wantOK   bool
+	}{
+		{at: day, price: 1000000, btc: "1", wantLoss: 0, wantOK: true},
+		{at: day.Add(time.Hour), price: 960000, btc: "1", wantLoss: 40000, wantOK: true},
+		{at: day.Add(2 * time.Hour), price: 950000, btc: "1", wantLoss: 50000, wantOK: false},
+		// A new day starts from the value of its first balance.
+		{at: day.Add(24 * time.Hour), price: 900000, btc: "1", wantLoss: 0, wantOK: true},
+	}
+	for i, s := range steps {
+		r.UpdatePrice("btc_jpy", s.price, s.at)
+		r.UpdateBalance(AccountsBalanceItem{Jpy: "0", Btc: s.btc}, OrdersOpensItem{}, s.at)
+		if got := r.DailyLoss(); got != s.wantLoss {
+			t.Errorf("step %d: DailyLoss() = %v, want %v", i, got, s.wantLoss)
+		}
+		if got := r.Breach(); (got == nil) != s.wantOK {
+			t.Errorf("step %d: Breach() = %v, want ok %v", i, got, s.wantOK)
+		}
+		if err := r.Allow(buy, s.at); (err == nil) != s.wantOK {
+			t.Errorf("step %d: Allow() error = %v, want ok %v", i, err, s.wantOK)
+		}
+	}
+}
+
+func TestRiskEngineRelease(t *testing.T) {
+	now := time.Now()
+	r, err := NewRiskEngine(RiskConfig{MaxPosition: map[string]float64{"btc_jpy": 0.1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buy := RiskOrder{Pair: "btc_jpy", Type: Buy, Rate: 1000000, Amount: 0.1}
+	if err := r.Allow(buy, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Allow(buy, now); err == nil {
+		t.Fatal("second buy allowed over the position limit")
+	}
+	r.Release(buy, now)
+	if err := r.Allow(buy, now); err != nil {
+		t.Errorf("buy after Release() error = %v", err)
+	}
+}
+
+func TestRiskEngineBalanceKeepsOpenBuys(t *testing.T) {
+	now := time.Now()
+	buy := RiskOrder{Pair: "btc_jpy", Type: Buy, Rate: 1000000, Amount: 0.1}
+	tests := []struct {
+		name    string
+		opens   OrdersOpensItem
+		balance time.Time
+	}{
+		{
+			name:    "open buy",
+			opens:   OrdersOpensItem{Orders: []*OpenItem{{OrderType: "buy", Pair: "btc_jpy", PendingAmount: "0.1"}}},
+			balance: now.Add(time.Minute),
+		},
+		{
+			name:    "buy after the balance",
+			balance: now.Add(-time.Second),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRiskEngine(RiskConfig{MaxPosition: map[string]float64{"btc_jpy": 0.15}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := r.Allow(buy, now); err != nil {
+				t.Fatal(err)
+			}
+			r.UpdateBalance(AccountsBalanceItem{Btc: "0"}, tt.opens, tt.balance)
+			if err := r.Allow(buy, now.Add(time.Minute)); err == nil {
+				t.Error("second buy allowed over the position limit after a balance refresh")
+			}
+		})
+	}
+	// Once the balance leaves out an order that was never placed, its release
+	// takes nothing back.
+	r, err := NewRiskEngine(RiskConfig{MaxPosition: map[string]float64{"btc_jpy": 0.15}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Allow(buy, now); err != nil {
+		t.Fatal(err)
+	}
+	r.UpdateBalance(AccountsBalanceItem{Btc: "0.1"}, OrdersOpensItem{}, now.Add(time.Minute))
+	r.Release(buy, now.Add(time.Minute))
+	if err := r.Allow(buy, now.Add(time.Minute)); err == nil {
+		t.Error("buy allowed over the position limit after the release of a refreshed order")
+	}
+}
+
+func TestNewRiskEngineConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    RiskConfig
+		wantErr bool
+	}{
+		{name: "empty", conf: RiskConfig{}},
+		{name: "price age", conf: RiskConfig{MaxPriceAge: "30s"}},
+		{name: "bad price age", conf: RiskConfig{MaxPriceAge: "soon"}, wantErr: true},
+		{name: "negative", conf: RiskConfig{MaxOrderNotional: -1}, wantErr: true},
+		{name: "unknown pair", conf: RiskConfig{MaxPosition: map[string]float64{"doge_jpy": 1}}, wantErr: true},
+		{name: "pair without a balance", conf: RiskConfig{MaxPosition: map[string]float64{"fct_jpy": 1}}, wantErr: true},
+		{name: "halt on", conf: RiskConfig{HaltOn: []string{RuleDailyLoss, RulePosition}}},
+		{name: "halt on unknown rule", conf: RiskConfig{HaltOn: []string{"max_loss"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRiskEngine(tt.conf); (err != nil) != tt.wantErr {
+				t.Errorf("NewRiskEngine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package bitcocheck
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tick One collected ticker of a pair.
+type Tick struct {
+	ID     string
+	Pair   string
+	Time   time.Time
+	Last   float64
+	Bid    float64
+	Ask    float64
+	High   float64
+	Low    float64
+	Volume float64
+}
+
+// TickKey Position of a tick in the ticker history, ordered by time then ID.
+type TickKey struct {
+	Time time.Time
+	ID   string
+}
+
+// TickQuery Selects ticks of a pair. Zero times and a zero Limit are unbounded.
+type TickQuery struct {
+	Pair  string
+	From  time.Time // inclusive
+	To    time.Time // exclusive
+	After *TickKey  // only the ticks after this key in the order of the query
+	Desc  bool
+	Limit int
+}
+
+// GapRecord A hole of the ticker history and how it was backfilled.
+type GapRecord struct {
+	Pair   string
+	From   time.Time
+	To     time.Time
+	Status string
+	Trades int
+}
+
+// StoredOrder An order placed by bitcobuy. Item is the order as JSON.
+type StoredOrder struct {
+	ID        string
+	OrderID   uint64
+	OrderType string
+	Time      time.Time
+	Btc       string
+	Yen       string
+	Item      string
+	Pair      string
+	Status    string
+	Fee       string
+}
+
+// Fill One execution of our own order, as in /api/exchange/orders/transactions.
+type Fill struct {
+	ID          uint64
+	OrderID     uint64
+	Time        time.Time
+	Pair        string
+	Side        string
+	Rate        string
+	Btc         string
+	Jpy         string
+	Fee         string
+	FeeCurrency string
+	Liquidity   string
+	// Base is the amount of the base currency of the pair, empty for the
+	// fills saved before it was kept.
+	Base string
+}
+
+// TradeHist One settled position of bitcobuy.
+type TradeHist struct {
+	ID   string
+	Time time.Time
+	Btc  string
+	Yen  string
+	Pair string
+	Fee  string
+}
+
+// TickStore Ticker history, its roll-ups and its gaps. Roll-ups are named by
+// resolution, "raw" being the ticker history itself.
+type TickStore interface {
+	SaveTicks(ticks []Tick) error
+	// AddTicks saves the ticks that have no tick of the same pair and time yet
+	// and returns how many were saved.
+	AddTicks(ticks []Tick) (int, error)
+	// ScanTicks calls fn for the ticks of the query until fn returns false.
+	ScanTicks(q TickQuery, fn func(Tick) bool) error
+	// LastBar returns the start of the newest bar of a roll-up, zero when it is empty.
+	LastBar(pair, res string) (time.Time, error)
+	// Bars returns the bars of a roll-up, or the ticks as bars for "raw", from the given time on.
+	Bars(pair, res string, from time.Time) ([]TickBar, error)
+	// SaveBars saves bars of a roll-up, replacing the bars of the same time.
+	SaveBars(pair, res string, bars []TickBar) error
+	// Prune deletes the rows of a roll-up, or ticks for "raw", before the given time.
+	Prune(res string, before time.Time) error
+	SaveGap(gap GapRecord) error
+	HasGap(pair string, from time.Time) (bool, error)
+	// Gaps returns the gaps overlapping [from, to), ordered by time.
+	Gaps(pair string, from, to time.Time) ([]GapRecord, error)
+	// ForgetGaps deletes the gaps that contain the given time.
+	ForgetGaps(pair string, t time.Time) error
+}
+
+// MarketStore Public trades and the candles built from them.
+type MarketStore interface {
+	MaxTradeID(pair string) (uint32, error)
+	// SaveTrades saves the trades whose ID is not stored yet.
+	SaveTrades(pair string, trades []*TradeData) error
+	// Trades returns the trades in [from, to), ordered by ID.
+	Trades(pair string, from, to time.Time) ([]*TradeData, error)
+	// SaveCandles saves candles, replacing the candles of the same time.
+	SaveCandles(pair, interval string, candles []*Candle) error
+	// Candles returns the candles in [from, to), ordered by time.
+	Candles(pair, interval string, from, to time.Time) ([]*Candle, error)
+}
+
+// OrderStore Our own orders, their fills and the settled positions.
+type OrderStore interface {
+	// SaveOrder saves an order, replacing the order of the same ID.
+	SaveOrder(order StoredOrder) error
+	// Orders returns the orders ordered by time.
+	Orders() ([]StoredOrder, error)
+	DeleteOrder(id string) error
+	// SaveFills saves the fills whose ID is not stored yet.
+	SaveFills(fills []Fill) error
+	// Fills returns the fills of an order, of every order for 0, ordered by ID.
+	Fills(orderID uint64) ([]Fill, error)
+	SaveTradeHist(hist TradeHist) error
+	// TradeHists returns the settled positions ordered by time.
+	TradeHists() ([]TradeHist, error)
+}
+
+// Store Persistence of the collected data and our own orders.
+type Store interface {
+	TickStore
+	MarketStore
+	OrderStore
+	ClientOrderStore
+	OrderTrackStore
+	ConditionalOrderStore
+	RecurringOrderStore
+	Close() error
+}
+
+// StoreConfig Storage backend of the server.
+type StoreConfig struct {
+	// Driver is "sqlite" (the default), "memory" or "postgres".
+	Driver string `toml:"driver"`
+	// DSN is the sqlite file name or the PostgreSQL connection string.
+	DSN string `toml:"dsn"`
+	// ReadConns is the number of sqlite read connections. Defaults to 4.
+	ReadConns int `toml:"read_conns"`
+	// BusyTimeout is how long sqlite waits for a lock, e.g. "5s". Defaults to 5s.
+	BusyTimeout string `toml:"busy_timeout"`
+}
+
+// ReadConnCount returns the number of sqlite read connections.
+func (c StoreConfig) ReadConnCount() int {
+	if c.ReadConns > 0 {
+		return c.ReadConns
+	}
+	return 4
+}
+
+// BusyTimeoutDuration returns how long sqlite waits for a lock.
+func (c StoreConfig) BusyTimeoutDuration() (time.Duration, error) {
+	if c.BusyTimeout == "" {
+		return 5 * time.Second, nil
+	}
+	return time.ParseDuration(c.BusyTimeout)
+}
+
+// OpenStore opens a storage backend. migrations are the sqlite schema of the
+// database; the other backends have a fixed schema.
+func OpenStore(conf StoreConfig, migrations []Migration) (Store, error) {
+	switch conf.Driver {
+	case "", "sqlite":
+		return OpenSQLiteStore(conf, migrations)
+	case "memory":
+		return NewMemoryStore(), nil
+	case "postgres":
+		return OpenPostgresStore(conf.DSN)
+	}
+	return nil, fmt.Errorf("unknown store driver: %s", conf.Driver)
+}
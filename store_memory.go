@@ -0,0 +1,617 @@
+package bitcocheck
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore A Store kept in memory, for tests and throwaway runs.
+type MemoryStore struct {
+	mu      sync.Mutex
+	ticks   []Tick
+	bars    map[string]map[string][]TickBar // resolution, pair
+	gaps    []GapRecord
+	trades  map[string]map[uint32]*TradeData
+	candles map[string]map[string]map[int64]*Candle // pair, interval, start
+	orders  map[string]StoredOrder
+	fills   map[uint64]Fill
+	hists   []TradeHist
+	clients map[string]ClientOrder
+	tracked map[uint64]TrackedOrder
+	history []OrderTransition
+	conds   map[string]ConditionalOrder
+	recurs  map[string]RecurringOrder
+	runs    []RecurringRun
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		bars:    map[string]map[string][]TickBar{},
+		trades:  map[string]map[uint32]*TradeData{},
+		candles: map[string]map[string]map[int64]*Candle{},
+		orders:  map[string]StoredOrder{},
+		fills:   map[uint64]Fill{},
+		clients: map[string]ClientOrder{},
+		tracked: map[uint64]TrackedOrder{},
+		conds:   map[string]ConditionalOrder{},
+		recurs:  map[string]RecurringOrder{},
+	}
+}
+
+func tickLess(a, b Tick) bool {
+	if a.Time.Equal(b.Time) {
+		return a.ID < b.ID
+	}
+	return a.Time.Before(b.Time)
+}
+
+func (m *MemoryStore) insertTick(tick Tick) {
+	i := sort.Search(len(m.ticks), func(i int) bool { return tickLess(tick, m.ticks[i]) })
+	m.ticks = append(m.ticks, Tick{})
+	copy(m.ticks[i+1:], m.ticks[i:])
+	m.ticks[i] = tick
+}
+
+func (m *MemoryStore) SaveTicks(ticks []Tick) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, tick := range ticks {
+		m.insertTick(tick)
+	}
+	return nil
+}
+
+func (m *MemoryStore) AddTicks(ticks []Tick) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, tick := range ticks {
+		exists := false
+		for _, t := range m.ticks {
+			if t.Pair == tick.Pair && t.Time.Equal(tick.Time) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			m.insertTick(tick)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *MemoryStore) ScanTicks(q TickQuery, fn func(Tick) bool) error {
+	m.mu.Lock()
+	ticks := []Tick{}
+	for _, t := range m.ticks {
+		if t.Pair != q.Pair {
+			continue
+		}
+		if !q.From.IsZero() && t.Time.Before(q.From) {
+			continue
+		}
+		if !q.To.IsZero() && !t.Time.Before(q.To) {
+			continue
+		}
+		if q.After != nil {
+			key := Tick{Time: q.After.Time, ID: q.After.ID}
+			if q.Desc && !tickLess(t, key) || !q.Desc && !tickLess(key, t) {
+				continue
+			}
+		}
+		ticks = append(ticks, t)
+	}
+	m.mu.Unlock()
+	if q.Desc {
+		for i, j := 0, len(ticks)-1; i < j; i, j = i+1, j-1 {
+			ticks[i], ticks[j] = ticks[j], ticks[i]
+		}
+	}
+	for i, t := range ticks {
+		if q.Limit > 0 && i == q.Limit {
+			break
+		}
+		if !fn(t) {
+			break
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) LastBar(pair, res string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	bars := m.bars[res][pair]
+	if len(bars) == 0 {
+		return time.Time{}, nil
+	}
+	return bars[len(bars)-1].Time, nil
+}
+
+func (m *MemoryStore) Bars(pair, res string, from time.Time) ([]TickBar, error) {
+	bars := []TickBar{}
+	if res == "raw" {
+		err := m.ScanTicks(TickQuery{Pair: pair, From: from}, func(t Tick) bool {
+			bars = append(bars, TickBar{Time: t.Time, Open: t.Last, High: t.Last, Low: t.Last, Close: t.Last, Bid: t.Bid, Ask: t.Ask, Volume: t.Volume, Samples: 1})
+			return true
+		})
+		return bars, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, bar := range m.bars[res][pair] {
+		if !bar.Time.Before(from) {
+			bars = append(bars, bar)
+		}
+	}
+	return bars, nil
+}
+
+func (m *MemoryStore) SaveBars(pair, res string, bars []TickBar) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.bars[res] == nil {
+		m.bars[res] = map[string][]TickBar{}
+	}
+	byTime := map[int64]TickBar{}
+	for _, bar := range m.bars[res][pair] {
+		byTime[bar.Time.Unix()] = bar
+	}
+	for _, bar := range bars {
+		byTime[bar.Time.Unix()] = bar
+	}
+	merged := []TickBar{}
+	for _, bar := range byTime {
+		merged = append(merged, bar)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Time.Before(merged[j].Time) })
+	m.bars[res][pair] = merged
+	return nil
+}
+
+func (m *MemoryStore) Prune(res string, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if res == "raw" {
+		ticks := []Tick{}
+		for _, t := range m.ticks {
+			if !t.Time.Before(before) {
+				ticks = append(ticks, t)
+			}
+		}
+		m.ticks = ticks
+		return nil
+	}
+	for pair, bars := range m.bars[res] {
+		kept := []TickBar{}
+		for _, bar := range bars {
+			if !bar.Time.Before(before) {
+				kept = append(kept, bar)
+			}
+		}
+		m.bars[res][pair] = kept
+	}
+	return nil
+}
+
+func (m *MemoryStore) SaveGap(gap GapRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, g := range m.gaps {
+		if g.Pair == gap.Pair && g.From.Equal(gap.From) {
+			m.gaps[i] = gap
+			return nil
+		}
+	}
+	m.gaps = append(m.gaps, gap)
+	sort.Slice(m.gaps, func(i, j int) bool { return m.gaps[i].From.Before(m.gaps[j].From) })
+	return nil
+}
+
+func (m *MemoryStore) HasGap(pair string, from time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, g := range m.gaps {
+		if g.Pair == pair && g.From.Equal(from) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MemoryStore) Gaps(pair string, from, to time.Time) ([]GapRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	gaps := []GapRecord{}
+	for _, g := range m.gaps {
+		if g.Pair != pair {
+			continue
+		}
+		if !from.IsZero() && !g.To.After(from) {
+			continue
+		}
+		if !to.IsZero() && !g.From.Before(to) {
+			continue
+		}
+		gaps = append(gaps, g)
+	}
+	return gaps, nil
+}
+
+func (m *MemoryStore) ForgetGaps(pair string, t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	gaps := []GapRecord{}
+	for _, g := range m.gaps {
+		if g.Pair == pair && g.From.Before(t) && g.To.After(t) {
+			continue
+		}
+		gaps = append(gaps, g)
+	}
+	m.gaps = gaps
+	return nil
+}
+
+func (m *MemoryStore) MaxTradeID(pair string) (uint32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var max uint32
+	for id := range m.trades[pair] {
+		if id > max {
+			max = id
+		}
+	}
+	return max, nil
+}
+
+func (m *MemoryStore) SaveTrades(pair string, trades []*TradeData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.trades[pair] == nil {
+		m.trades[pair] = map[uint32]*TradeData{}
+	}
+	for _, trade := range trades {
+		if _, err := TradeTime(trade); err != nil {
+			return err
+		}
+		if _, ok := m.trades[pair][trade.ID]; !ok {
+			t := *trade
+			t.Pair = pair
+			m.trades[pair][trade.ID] = &t
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) Trades(pair string, from, to time.Time) ([]*TradeData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	trades := []*TradeData{}
+	for _, trade := range m.trades[pair] {
+		tm, err := TradeTime(trade)
+		if err != nil {
+			return trades, err
+		}
+		if tm.Before(from) || !tm.Before(to) {
+			continue
+		}
+		t := *trade
+		trades = append(trades, &t)
+	}
+	sort.Slice(trades, func(i, j int) bool { return trades[i].ID < trades[j].ID })
+	return trades, nil
+}
+
+func (m *MemoryStore) SaveCandles(pair, interval string, candles []*Candle) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.candles[pair] == nil {
+		m.candles[pair] = map[string]map[int64]*Candle{}
+	}
+	if m.candles[pair][interval] == nil {
+		m.candles[pair][interval] = map[int64]*Candle{}
+	}
+	for _, c := range candles {
+		m.candles[pair][interval][int64(c.Timestamp)] = &Candle{
+			Timestamp: c.Timestamp, Open: c.Open, High: c.High, Low: c.Low, Close: c.Close, Volume: c.Volume, Trades: c.Trades,
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) Candles(pair, interval string, from, to time.Time) ([]*Candle, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	candles := []*Candle{}
+	for start, c := range m.candles[pair][interval] {
+		if start < from.Unix() || start >= to.Unix() {
+			continue
+		}
+		candles = append(candles, &Candle{
+			Timestamp: c.Timestamp, Open: c.Open, High: c.High, Low: c.Low, Close: c.Close, Volume: c.Volume, Trades: c.Trades,
+		})
+	}
+	sort.Slice(candles, func(i, j int) bool { return candles[i].Timestamp < candles[j].Timestamp })
+	return candles, nil
+}
+
+func (m *MemoryStore) SaveOrder(order StoredOrder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.orders[order.ID] = order
+	return nil
+}
+
+func (m *MemoryStore) Orders() ([]StoredOrder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	orders := []StoredOrder{}
+	for _, o := range m.orders {
+		orders = append(orders, o)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].Time.Equal(orders[j].Time) {
+			return orders[i].ID < orders[j].ID
+		}
+		return orders[i].Time.Before(orders[j].Time)
+	})
+	return orders, nil
+}
+
+func (m *MemoryStore) DeleteOrder(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.orders, id)
+	return nil
+}
+
+func (m *MemoryStore) SaveFills(fills []Fill) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, f := range fills {
+		if _, ok := m.fills[f.ID]; !ok {
+			m.fills[f.ID] = f
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) Fills(orderID uint64) ([]Fill, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fills := []Fill{}
+	for _, f := range m.fills {
+		if orderID == 0 || f.OrderID == orderID {
+			fills = append(fills, f)
+		}
+	}
+	sort.Slice(fills, func(i, j int) bool { return fills[i].ID < fills[j].ID })
+	return fills, nil
+}
+
+func (m *MemoryStore) SaveTradeHist(hist TradeHist) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hists = append(m.hists, hist)
+	sort.SliceStable(m.hists, func(i, j int) bool { return m.hists[i].Time.Before(m.hists[j].Time) })
+	return nil
+}
+
+func (m *MemoryStore) TradeHists() ([]TradeHist, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	hists := make([]TradeHist, len(m.hists))
+	copy(hists, m.hists)
+	return hists, nil
+}
+
+func (m *MemoryStore) AddClientOrder(o ClientOrder) (ClientOrder, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stored, ok := m.clients[o.ClientID]; ok {
+		return stored, false, nil
+	}
+	m.clients[o.ClientID] = o
+	return o, true, nil
+}
+
+func (m *MemoryStore) UpdateClientOrder(o ClientOrder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[o.ClientID] = o
+	return nil
+}
+
+func (m *MemoryStore) ClientOrder(clientID string) (ClientOrder, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.clients[clientID]
+	return o, ok, nil
+}
+
+func (m *MemoryStore) ClientOrders(state string, since time.Time) ([]ClientOrder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	orders := []ClientOrder{}
+	for _, o := range m.clients {
+		if (state == "" || o.State == state) && !o.Created.Before(since) {
+			orders = append(orders, o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].Created.Equal(orders[j].Created) {
+			return orders[i].ClientID < orders[j].ClientID
+		}
+		return orders[i].Created.Before(orders[j].Created)
+	})
+	return orders, nil
+}
+
+func (m *MemoryStore) SaveTrackedOrder(o TrackedOrder, tr *OrderTransition) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tracked[o.OrderID] = o
+	if tr != nil {
+		t := *tr
+		t.ID = uint64(len(m.history) + 1)
+		m.history = append(m.history, t)
+	}
+	return nil
+}
+
+func (m *MemoryStore) TrackedOrder(orderID uint64) (TrackedOrder, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.tracked[orderID]
+	return o, ok, nil
+}
+
+func (m *MemoryStore) TrackedOrders(q TrackedOrderQuery) ([]TrackedOrder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	orders := []TrackedOrder{}
+	for _, o := range m.tracked {
+		if (q.Pair == "" || o.Pair == q.Pair) && (len(q.Statuses) == 0 || containsString(q.Statuses, o.Status)) {
+			orders = append(orders, o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].Created.Equal(orders[j].Created) {
+			return orders[i].OrderID > orders[j].OrderID
+		}
+		return orders[i].Created.After(orders[j].Created)
+	})
+	if q.Limit > 0 && len(orders) > q.Limit {
+		orders = orders[:q.Limit]
+	}
+	return orders, nil
+}
+
+func (m *MemoryStore) AddOrderTransition(tr OrderTransition) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tr.ID = uint64(len(m.history) + 1)
+	m.history = append(m.history, tr)
+	return nil
+}
+
+func (m *MemoryStore) OrderTransitions(q TransitionQuery) ([]OrderTransition, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	transitions := []OrderTransition{}
+	for _, t := range m.history {
+		if t.ID <= q.After || (q.OrderID != 0 && t.OrderID != q.OrderID) {
+			continue
+		}
+		transitions = append(transitions, t)
+		if q.Limit > 0 && len(transitions) == q.Limit {
+			break
+		}
+	}
+	return transitions, nil
+}
+
+func (m *MemoryStore) SaveConditionalOrders(orders ...ConditionalOrder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, o := range orders {
+		m.conds[o.ID] = o
+	}
+	return nil
+}
+
+func (m *MemoryStore) ConditionalOrder(id string) (ConditionalOrder, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.conds[id]
+	return o, ok, nil
+}
+
+func (m *MemoryStore) ConditionalOrders(states ...string) ([]ConditionalOrder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	orders := []ConditionalOrder{}
+	for _, o := range m.conds {
+		if len(states) == 0 || containsString(states, o.State) {
+			orders = append(orders, o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].Created.Equal(orders[j].Created) {
+			return orders[i].ID < orders[j].ID
+		}
+		return orders[i].Created.Before(orders[j].Created)
+	})
+	return orders, nil
+}
+
+func (m *MemoryStore) SaveRecurringOrder(o RecurringOrder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recurs[o.ID] = o
+	return nil
+}
+
+func (m *MemoryStore) RecurringOrder(id string) (RecurringOrder, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.recurs[id]
+	return o, ok, nil
+}
+
+func (m *MemoryStore) RecurringOrders() ([]RecurringOrder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	orders := []RecurringOrder{}
+	for _, o := range m.recurs {
+		orders = append(orders, o)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].Created.Equal(orders[j].Created) {
+			return orders[i].ID < orders[j].ID
+		}
+		return orders[i].Created.Before(orders[j].Created)
+	})
+	return orders, nil
+}
+
+func (m *MemoryStore) AddRecurringRun(r RecurringRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r.ID = uint64(len(m.runs) + 1)
+	m.runs = append(m.runs, r)
+	return nil
+}
+
+func (m *MemoryStore) RecurringRuns(q RecurringRunQuery) ([]RecurringRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	runs := []RecurringRun{}
+	for i := len(m.runs) - 1; i >= 0; i-- {
+		r := m.runs[i]
+		if q.RecurringID != "" && r.RecurringID != q.RecurringID {
+			continue
+		}
+		runs = append(runs, r)
+		if q.Limit > 0 && len(runs) == q.Limit {
+			break
+		}
+	}
+	return runs, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,966 @@
+package bitcocheck
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	// PostgreSQL driver of OpenPostgresStore
+	_ "github.com/lib/pq"
+)
+
+// postgresMigrations The schema history of the PostgreSQL store, one migration
+// per entry. Append new versions, never edit the applied ones.
+var postgresMigrations = [][]string{
+	{
+		`create table tickhist (
+			id text PRIMARY KEY,
+			pair text NOT NULL,
+			ts timestamptz NOT NULL,
+			last double precision NOT NULL,
+			bid double precision NOT NULL,
+			ask double precision NOT NULL,
+			high double precision NOT NULL,
+			low double precision NOT NULL,
+			volume double precision NOT NULL)`,
+		`create index tickhist_pair_ts on tickhist (pair, ts)`,
+		`create table tickhist_rollup (
+			resolution text NOT NULL,
+			pair text NOT NULL,
+			ts timestamptz NOT NULL,
+			open double precision NOT NULL,
+			high double precision NOT NULL,
+			low double precision NOT NULL,
+			close double precision NOT NULL,
+			bid double precision NOT NULL,
+			ask double precision NOT NULL,
+			volume double precision NOT NULL,
+			samples integer NOT NULL,
+			PRIMARY KEY (resolution, pair, ts))`,
+		`create table tickhist_gaps (
+			pair text NOT NULL,
+			start_ts timestamptz NOT NULL,
+			end_ts timestamptz NOT NULL,
+			status text NOT NULL,
+			trades integer NOT NULL,
+			PRIMARY KEY (pair, start_ts))`,
+		`create table trades (
+			id bigint NOT NULL,
+			pair text NOT NULL,
+			ts timestamptz NOT NULL,
+			rate double precision NOT NULL,
+			amount text NOT NULL,
+			order_type text NOT NULL,
+			created_at text NOT NULL,
+			PRIMARY KEY (pair, id))`,
+		`create index trades_pair_ts on trades (pair, ts)`,
+		`create table candles (
+			pair text NOT NULL,
+			interval text NOT NULL,
+			ts timestamptz NOT NULL,
+			open double precision NOT NULL,
+			high double precision NOT NULL,
+			low double precision NOT NULL,
+			close double precision NOT NULL,
+			volume double precision NOT NULL,
+			trades integer NOT NULL,
+			PRIMARY KEY (pair, interval, ts))`,
+		`create table order_info (
+			id text PRIMARY KEY,
+			order_id bigint NOT NULL,
+			order_type text NOT NULL,
+			ts timestamptz NOT NULL,
+			btc text NOT NULL,
+			yen text NOT NULL,
+			item text NOT NULL,
+			pair text NOT NULL,
+			status text NOT NULL,
+			fee text NOT NULL)`,
+		`create index order_info_order_id on order_info (order_id)`,
+		`create table fills (
+			id bigint PRIMARY KEY,
+			order_id bigint NOT NULL,
+			ts timestamptz NOT NULL,
+			pair text NOT NULL,
+			side text NOT NULL,
+			rate text NOT NULL,
+			btc text NOT NULL,
+			jpy text NOT NULL,
+			fee text NOT NULL,
+			fee_currency text NOT NULL,
+			liquidity text NOT NULL)`,
+		`create table trade_hist (
+			id text PRIMARY KEY,
+			ts timestamptz NOT NULL,
+			btc text NOT NULL,
+			yen text NOT NULL,
+			pair text NOT NULL,
+			fee text NOT NULL)`,
+		`create index trade_hist_ts on trade_hist (ts)`,
+	},
+	{
+		`create table client_orders (
+			client_id text PRIMARY KEY,
+			pair text NOT NULL,
+			order_type text NOT NULL,
+			rate text NOT NULL,
+			amount text NOT NULL,
+			stop_loss_rate text NOT NULL,
+			state text NOT NULL,
+			order_id bigint NOT NULL,
+			item text NOT NULL,
+			error text NOT NULL,
+			created timestamptz NOT NULL,
+			updated timestamptz NOT NULL)`,
+		`create index client_orders_state on client_orders (state, created)`,
+	},
+	{
+		`create table tracked_orders (
+			order_id bigint PRIMARY KEY,
+			client_id text NOT NULL,
+			pair text NOT NULL,
+			order_type text NOT NULL,
+			rate text NOT NULL,
+			amount text NOT NULL,
+			status text NOT NULL,
+			filled text NOT NULL,
+			created timestamptz NOT NULL,
+			updated timestamptz NOT NULL,
+			seen timestamptz NOT NULL)`,
+		`create index tracked_orders_status on tracked_orders (status, created)`,
+		`create table order_transitions (
+			id bigserial PRIMARY KEY,
+			order_id bigint NOT NULL,
+			from_status text NOT NULL,
+			to_status text NOT NULL,
+			filled text NOT NULL,
+			ts timestamptz NOT NULL)`,
+		`create index order_transitions_order_id on order_transitions (order_id)`,
+	},
+	{
+		`alter table order_transitions add column client_id text NOT NULL DEFAULT ''`,
+		`alter table order_transitions add column reason text NOT NULL DEFAULT ''`,
+	},
+	{
+		`create table conditional_orders (
+			id text PRIMARY KEY,
+			oco_group text NOT NULL,
+			kind text NOT NULL,
+			pair text NOT NULL,
+			side text NOT NULL,
+			trigger_rate double precision NOT NULL,
+			trail double precision NOT NULL,
+			extreme double precision NOT NULL,
+			amount text NOT NULL,
+			rate text NOT NULL,
+			state text NOT NULL,
+			client_id text NOT NULL,
+			order_id bigint NOT NULL,
+			error text NOT NULL,
+			created timestamptz NOT NULL,
+			updated timestamptz NOT NULL)`,
+		`create index conditional_orders_state on conditional_orders (state, created)`,
+	},
+	{
+		`create table recurring_orders (
+			id text PRIMARY KEY,
+			name text NOT NULL,
+			pair text NOT NULL,
+			side text NOT NULL,
+			amount double precision NOT NULL,
+			schedule text NOT NULL,
+			limit_rate double precision NOT NULL,
+			state text NOT NULL,
+			source text NOT NULL,
+			created timestamptz NOT NULL,
+			updated timestamptz NOT NULL)`,
+		`create table recurring_runs (
+			id bigserial PRIMARY KEY,
+			recurring_id text NOT NULL,
+			ts timestamptz NOT NULL,
+			outcome text NOT NULL,
+			reason text NOT NULL,
+			order_type text NOT NULL,
+			rate text NOT NULL,
+			amount text NOT NULL,
+			client_id text NOT NULL,
+			order_id bigint NOT NULL)`,
+		`create index recurring_runs_recurring_id on recurring_runs (recurring_id)`,
+	},
+	{
+		`alter table fills add column base text NOT NULL DEFAULT ''`,
+	},
+	{
+		`alter table order_transitions add column pair text NOT NULL DEFAULT ''`,
+		`alter table order_transitions add column order_type text NOT NULL DEFAULT ''`,
+		`alter table order_transitions add column amount text NOT NULL DEFAULT ''`,
+	},
+}
+
+// PostgresStore A Store in a PostgreSQL database, for a deployment shared by
+// several servers. Roll-ups of every resolution share the tickhist_rollup table.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// OpenPostgresStore connects to PostgreSQL with a lib/pq connection string and
+// applies the migrations.
+func OpenPostgresStore(dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	s := &PostgresStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *PostgresStore) migrate() error {
+	if _, err := s.db.Exec(`create table if not exists schema_version (
+		version integer PRIMARY KEY,
+		description text NOT NULL,
+		applied_at timestamptz NOT NULL)`); err != nil {
+		return err
+	}
+	var current int
+	if err := s.db.QueryRow(`select coalesce(max(version), 0) from schema_version`).Scan(&current); err != nil {
+		return err
+	}
+	for i, stmts := range postgresMigrations {
+		version := i + 1
+		if version <= current {
+			continue
+		}
+		err := s.withTx(func(tx *sql.Tx) error {
+			for _, stmt := range stmts {
+				if _, err := tx.Exec(stmt); err != nil {
+					return fmt.Errorf("%v, %s", err, stmt)
+				}
+			}
+			_, err := tx.Exec(`insert into schema_version values ($1, $2, $3)`, version, fmt.Sprintf("migration %d", version), time.Now())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d: %v", version, err)
+		}
+	}
+	return nil
+}
+
+func (s *PostgresStore) withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// placeholders returns "$from, ..., $(from+n-1)".
+func placeholders(from, n int) string {
+	ps := make([]string, n)
+	for i := range ps {
+		ps[i] = fmt.Sprintf("$%d", from+i)
+	}
+	return strings.Join(ps, ", ")
+}
+
+func (s *PostgresStore) SaveTicks(ticks []Tick) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`insert into tickhist (id, pair, ts, last, bid, ask, high, low, volume) values (` + placeholders(1, 9) + `)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, t := range ticks {
+			if _, err := stmt.Exec(t.ID, t.Pair, t.Time, t.Last, t.Bid, t.Ask, t.High, t.Low, t.Volume); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) AddTicks(ticks []Tick) (int, error) {
+	n := 0
+	err := s.withTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`insert into tickhist (id, pair, ts, last, bid, ask, high, low, volume)
+			select $1::text, $2::text, $3::timestamptz, $4::float8, $5::float8, $6::float8, $7::float8, $8::float8, $9::float8
+			where not exists (select 1 from tickhist where pair = $2 and ts = $3)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, t := range ticks {
+			res, err := stmt.Exec(t.ID, t.Pair, t.Time, t.Last, t.Bid, t.Ask, t.High, t.Low, t.Volume)
+			if err != nil {
+				return err
+			}
+			added, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			n += int(added)
+		}
+		return nil
+	})
+	return n, err
+}
+
+func (s *PostgresStore) ScanTicks(q TickQuery, fn func(Tick) bool) error {
+	query := `select id, ts, last, bid, ask, high, low, volume from tickhist where pair = $1`
+	args := []interface{}{q.Pair}
+	if !q.From.IsZero() {
+		args = append(args, q.From)
+		query += fmt.Sprintf(` and ts >= $%d`, len(args))
+	}
+	if !q.To.IsZero() {
+		args = append(args, q.To)
+		query += fmt.Sprintf(` and ts < $%d`, len(args))
+	}
+	if q.After != nil {
+		args = append(args, q.After.Time, q.After.ID)
+		op := ">"
+		if q.Desc {
+			op = "<"
+		}
+		query += fmt.Sprintf(` and (ts, id) %s ($%d, $%d)`, op, len(args)-1, len(args))
+	}
+	if q.Desc {
+		query += ` order by ts desc, id desc`
+	} else {
+		query += ` order by ts asc, id asc`
+	}
+	if q.Limit > 0 {
+		args = append(args, q.Limit)
+		query += fmt.Sprintf(` limit $%d`, len(args))
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		t := Tick{Pair: q.Pair}
+		if err := rows.Scan(&t.ID, &t.Time, &t.Last, &t.Bid, &t.Ask, &t.High, &t.Low, &t.Volume); err != nil {
+			return err
+		}
+		if !fn(t) {
+			return nil
+		}
+	}
+	return rows.Err()
+}
+
+func (s *PostgresStore) LastBar(pair, res string) (time.Time, error) {
+	var ts sql.NullTime
+	var err error
+	if res == "raw" {
+		err = s.db.QueryRow(`select max(ts) from tickhist where pair = $1`, pair).Scan(&ts)
+	} else {
+		err = s.db.QueryRow(`select max(ts) from tickhist_rollup where resolution = $1 and pair = $2`, res, pair).Scan(&ts)
+	}
+	if err != nil || !ts.Valid {
+		return time.Time{}, err
+	}
+	return ts.Time, nil
+}
+
+func (s *PostgresStore) Bars(pair, res string, from time.Time) ([]TickBar, error) {
+	bars := []TickBar{}
+	var rows *sql.Rows
+	var err error
+	if res == "raw" {
+		rows, err = s.db.Query(`select ts, last, last, last, last, bid, ask, volume, 1 from tickhist where pair = $1 and ts >= $2 order by ts asc`, pair, from)
+	} else {
+		rows, err = s.db.Query(`select ts, open, high, low, close, bid, ask, volume, samples from tickhist_rollup
+			where resolution = $1 and pair = $2 and ts >= $3 order by ts asc`, res, pair, from)
+	}
+	if err != nil {
+		return bars, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bar TickBar
+		if err := rows.Scan(&bar.Time, &bar.Open, &bar.High, &bar.Low, &bar.Close, &bar.Bid, &bar.Ask, &bar.Volume, &bar.Samples); err != nil {
+			return bars, err
+		}
+		bars = append(bars, bar)
+	}
+	return bars, rows.Err()
+}
+
+func (s *PostgresStore) SaveBars(pair, res string, bars []TickBar) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`insert into tickhist_rollup values (` + placeholders(1, 11) + `)
+			on conflict (resolution, pair, ts) do update set open = excluded.open, high = excluded.high, low = excluded.low,
+			close = excluded.close, bid = excluded.bid, ask = excluded.ask, volume = excluded.volume, samples = excluded.samples`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, bar := range bars {
+			if _, err := stmt.Exec(res, pair, bar.Time, bar.Open, bar.High, bar.Low, bar.Close, bar.Bid, bar.Ask, bar.Volume, bar.Samples); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) Prune(res string, before time.Time) error {
+	var err error
+	if res == "raw" {
+		_, err = s.db.Exec(`delete from tickhist where ts < $1`, before)
+	} else {
+		_, err = s.db.Exec(`delete from tickhist_rollup where resolution = $1 and ts < $2`, res, before)
+	}
+	return err
+}
+
+func (s *PostgresStore) SaveGap(gap GapRecord) error {
+	_, err := s.db.Exec(`insert into tickhist_gaps values ($1, $2, $3, $4, $5)
+		on conflict (pair, start_ts) do update set end_ts = excluded.end_ts, status = excluded.status, trades = excluded.trades`,
+		gap.Pair, gap.From, gap.To, gap.Status, gap.Trades)
+	return err
+}
+
+func (s *PostgresStore) HasGap(pair string, from time.Time) (bool, error) {
+	var n int
+	err := s.db.QueryRow(`select count(*) from tickhist_gaps where pair = $1 and start_ts = $2`, pair, from).Scan(&n)
+	return n > 0, err
+}
+
+func (s *PostgresStore) Gaps(pair string, from, to time.Time) ([]GapRecord, error) {
+	gaps := []GapRecord{}
+	query := `select start_ts, end_ts, status, trades from tickhist_gaps where pair = $1`
+	args := []interface{}{pair}
+	if !from.IsZero() {
+		args = append(args, from)
+		query += fmt.Sprintf(` and end_ts > $%d`, len(args))
+	}
+	if !to.IsZero() {
+		args = append(args, to)
+		query += fmt.Sprintf(` and start_ts < $%d`, len(args))
+	}
+	rows, err := s.db.Query(query+` order by start_ts asc`, args...)
+	if err != nil {
+		return gaps, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		gap := GapRecord{Pair: pair}
+		if err := rows.Scan(&gap.From, &gap.To, &gap.Status, &gap.Trades); err != nil {
+			return gaps, err
+		}
+		gaps = append(gaps, gap)
+	}
+	return gaps, rows.Err()
+}
+
+func (s *PostgresStore) ForgetGaps(pair string, t time.Time) error {
+	_, err := s.db.Exec(`delete from tickhist_gaps where pair = $1 and start_ts < $2 and end_ts > $2`, pair, t)
+	return err
+}
+
+func (s *PostgresStore) MaxTradeID(pair string) (uint32, error) {
+	var id int64
+	err := s.db.QueryRow(`select coalesce(max(id), 0) from trades where pair = $1`, pair).Scan(&id)
+	return uint32(id), err
+}
+
+func (s *PostgresStore) SaveTrades(pair string, trades []*TradeData) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`insert into trades values (` + placeholders(1, 7) + `) on conflict do nothing`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, trade := range trades {
+			tm, err := TradeTime(trade)
+			if err != nil {
+				return err
+			}
+			if _, err := stmt.Exec(int64(trade.ID), pair, tm, trade.RateV2, trade.Amount, trade.OrderType, trade.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) Trades(pair string, from, to time.Time) ([]*TradeData, error) {
+	trades := []*TradeData{}
+	rows, err := s.db.Query(`select id, rate, amount, order_type, created_at from trades where pair = $1 and ts >= $2 and ts < $3 order by id asc`,
+		pair, from, to)
+	if err != nil {
+		return trades, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var rate float64
+		trade := TradeData{Pair: pair}
+		if err := rows.Scan(&id, &rate, &trade.Amount, &trade.OrderType, &trade.CreatedAt); err != nil {
+			return trades, err
+		}
+		trade.ID = uint32(id)
+		trade.setRate(rate)
+		trades = append(trades, &trade)
+	}
+	return trades, rows.Err()
+}
+
+func (s *PostgresStore) SaveCandles(pair, interval string, candles []*Candle) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`insert into candles values (` + placeholders(1, 9) + `)
+			on conflict (pair, interval, ts) do update set open = excluded.open, high = excluded.high, low = excluded.low,
+			close = excluded.close, volume = excluded.volume, trades = excluded.trades`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, c := range candles {
+			tm := time.Unix(int64(c.Timestamp), 0)
+			if _, err := stmt.Exec(pair, interval, tm, c.Open, c.High, c.Low, c.Close, c.Volume, int64(c.Trades)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) Candles(pair, interval string, from, to time.Time) ([]*Candle, error) {
+	candles := []*Candle{}
+	rows, err := s.db.Query(`select ts, open, high, low, close, volume, trades from candles
+		where pair = $1 and interval = $2 and ts >= $3 and ts < $4 order by ts asc`, pair, interval, from, to)
+	if err != nil {
+		return candles, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tm time.Time
+		var trades int64
+		var c Candle
+		if err := rows.Scan(&tm, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &trades); err != nil {
+			return candles, err
+		}
+		c.Timestamp = uint64(tm.Unix())
+		c.Trades = uint32(trades)
+		candles = append(candles, &c)
+	}
+	return candles, rows.Err()
+}
+
+func (s *PostgresStore) SaveOrder(o StoredOrder) error {
+	_, err := s.db.Exec(`insert into order_info values (`+placeholders(1, 10)+`)
+		on conflict (id) do update set order_id = excluded.order_id, order_type = excluded.order_type, ts = excluded.ts,
+		btc = excluded.btc, yen = excluded.yen, item = excluded.item, pair = excluded.pair, status = excluded.status, fee = excluded.fee`,
+		o.ID, int64(o.OrderID), o.OrderType, o.Time, o.Btc, o.Yen, o.Item, o.Pair, o.Status, o.Fee)
+	return err
+}
+
+func (s *PostgresStore) Orders() ([]StoredOrder, error) {
+	orders := []StoredOrder{}
+	rows, err := s.db.Query(`select id, order_id, order_type, ts, btc, yen, item, pair, status, fee from order_info order by ts asc, id asc`)
+	if err != nil {
+		return orders, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var o StoredOrder
+		var orderID int64
+		if err := rows.Scan(&o.ID, &orderID, &o.OrderType, &o.Time, &o.Btc, &o.Yen, &o.Item, &o.Pair, &o.Status, &o.Fee); err != nil {
+			return orders, err
+		}
+		o.OrderID = uint64(orderID)
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
+func (s *PostgresStore) DeleteOrder(id string) error {
+	_, err := s.db.Exec(`delete from order_info where id = $1`, id)
+	return err
+}
+
+func (s *PostgresStore) SaveFills(fills []Fill) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`insert into fills (id, order_id, ts, pair, side, rate, btc, jpy, fee, fee_currency, liquidity, base) values (` + placeholders(1, 12) + `) on conflict do nothing`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, f := range fills {
+			if _, err := stmt.Exec(int64(f.ID), int64(f.OrderID), f.Time, f.Pair, f.Side, f.Rate, f.Btc, f.Jpy, f.Fee, f.FeeCurrency, f.Liquidity, f.Base); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) Fills(orderID uint64) ([]Fill, error) {
+	fills := []Fill{}
+	query := `select id, order_id, ts, pair, side, rate, btc, jpy, fee, fee_currency, liquidity, base from fills`
+	args := []interface{}{}
+	if orderID != 0 {
+		query += ` where order_id = $1`
+		args = append(args, int64(orderID))
+	}
+	rows, err := s.db.Query(query+` order by id asc`, args...)
+	if err != nil {
+		return fills, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var f Fill
+		var id, oid int64
+		if err := rows.Scan(&id, &oid, &f.Time, &f.Pair, &f.Side, &f.Rate, &f.Btc, &f.Jpy, &f.Fee, &f.FeeCurrency, &f.Liquidity, &f.Base); err != nil {
+			return fills, err
+		}
+		f.ID, f.OrderID = uint64(id), uint64(oid)
+		fills = append(fills, f)
+	}
+	return fills, rows.Err()
+}
+
+func (s *PostgresStore) SaveTradeHist(h TradeHist) error {
+	_, err := s.db.Exec(`insert into trade_hist values (`+placeholders(1, 6)+`)`, h.ID, h.Time, h.Btc, h.Yen, h.Pair, h.Fee)
+	return err
+}
+
+func (s *PostgresStore) TradeHists() ([]TradeHist, error) {
+	hists := []TradeHist{}
+	rows, err := s.db.Query(`select id, ts, btc, yen, pair, fee from trade_hist order by ts asc`)
+	if err != nil {
+		return hists, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var h TradeHist
+		if err := rows.Scan(&h.ID, &h.Time, &h.Btc, &h.Yen, &h.Pair, &h.Fee); err != nil {
+			return hists, err
+		}
+		hists = append(hists, h)
+	}
+	return hists, rows.Err()
+}
+
+func (s *PostgresStore) AddClientOrder(o ClientOrder) (ClientOrder, bool, error) {
+	res, err := s.db.Exec(`insert into client_orders (`+clientOrderColumns+`) values (`+placeholders(1, 12)+`) on conflict do nothing`,
+		o.ClientID, o.Pair, o.OrderType, o.Rate, o.Amount, o.StopLossRate, o.State, int64(o.OrderID), o.Item, o.Error, o.Created, o.Updated)
+	if err != nil {
+		return o, false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 1 {
+		return o, err == nil, err
+	}
+	stored, ok, err := s.ClientOrder(o.ClientID)
+	if err != nil || !ok {
+		return o, false, err
+	}
+	return stored, false, nil
+}
+
+func (s *PostgresStore) UpdateClientOrder(o ClientOrder) error {
+	_, err := s.db.Exec(`insert into client_orders (`+clientOrderColumns+`) values (`+placeholders(1, 12)+`)
+		on conflict (client_id) do update set pair = excluded.pair, order_type = excluded.order_type, rate = excluded.rate,
+		amount = excluded.amount, stop_loss_rate = excluded.stop_loss_rate, state = excluded.state, order_id = excluded.order_id,
+		item = excluded.item, error = excluded.error, created = excluded.created, updated = excluded.updated`,
+		o.ClientID, o.Pair, o.OrderType, o.Rate, o.Amount, o.StopLossRate, o.State, int64(o.OrderID), o.Item, o.Error, o.Created, o.Updated)
+	return err
+}
+
+func (s *PostgresStore) ClientOrder(clientID string) (ClientOrder, bool, error) {
+	orders, err := s.clientOrders(`where client_id = $1`, clientID)
+	if err != nil || len(orders) == 0 {
+		return ClientOrder{}, false, err
+	}
+	return orders[0], true, nil
+}
+
+func (s *PostgresStore) ClientOrders(state string, since time.Time) ([]ClientOrder, error) {
+	where := `where created >= $1`
+	args := []interface{}{since}
+	if state != "" {
+		where += ` and state = $2`
+		args = append(args, state)
+	}
+	return s.clientOrders(where+` order by created asc, client_id asc`, args...)
+}
+
+func (s *PostgresStore) clientOrders(where string, args ...interface{}) ([]ClientOrder, error) {
+	orders := []ClientOrder{}
+	rows, err := s.db.Query(`select `+clientOrderColumns+` from client_orders `+where, args...)
+	if err != nil {
+		return orders, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var o ClientOrder
+		var orderID int64
+		if err := rows.Scan(&o.ClientID, &o.Pair, &o.OrderType, &o.Rate, &o.Amount, &o.StopLossRate, &o.State, &orderID, &o.Item, &o.Error, &o.Created, &o.Updated); err != nil {
+			return orders, err
+		}
+		o.OrderID = uint64(orderID)
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
+func (s *PostgresStore) SaveTrackedOrder(o TrackedOrder, tr *OrderTransition) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`insert into tracked_orders (`+trackedOrderColumns+`) values (`+placeholders(1, 11)+`)
+			on conflict (order_id) do update set client_id = excluded.client_id, pair = excluded.pair, order_type = excluded.order_type,
+			rate = excluded.rate, amount = excluded.amount, status = excluded.status, filled = excluded.filled,
+			created = excluded.created, updated = excluded.updated, seen = excluded.seen`,
+			int64(o.OrderID), o.ClientID, o.Pair, o.OrderType, o.Rate, o.Amount, o.Status, o.Filled, o.Created, o.Updated, o.Seen)
+		if err != nil || tr == nil {
+			return err
+		}
+		return addOrderTransition(tx, *tr)
+	})
+}
+
+// addOrderTransition appends a transition in tx. The bigserial IDs are taken
+// before the commits, so the table stays locked against the other writers
+// until tx ends: the transitions then commit in the order of their IDs, and
+// a reader resuming after the highest ID it saw skips none.
+func addOrderTransition(tx *sql.Tx, tr OrderTransition) error {
+	if _, err := tx.Exec(`lock table order_transitions in exclusive mode`); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`insert into order_transitions (order_id, client_id, from_status, to_status, filled, reason, ts, pair, order_type, amount)
+		values (`+placeholders(1, 10)+`)`,
+		int64(tr.OrderID), tr.ClientID, tr.From, tr.To, tr.Filled, tr.Reason, tr.Time, tr.Pair, tr.OrderType, tr.Amount)
+	return err
+}
+
+func (s *PostgresStore) AddOrderTransition(tr OrderTransition) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		return addOrderTransition(tx, tr)
+	})
+}
+
+func (s *PostgresStore) TrackedOrder(orderID uint64) (TrackedOrder, bool, error) {
+	orders, err := s.trackedOrders(`where order_id = $1`, int64(orderID))
+	if err != nil || len(orders) == 0 {
+		return TrackedOrder{}, false, err
+	}
+	return orders[0], true, nil
+}
+
+func (s *PostgresStore) TrackedOrders(q TrackedOrderQuery) ([]TrackedOrder, error) {
+	conds := []string{}
+	args := []interface{}{}
+	if q.Pair != "" {
+		args = append(args, q.Pair)
+		conds = append(conds, fmt.Sprintf(`pair = $%d`, len(args)))
+	}
+	if len(q.Statuses) > 0 {
+		conds = append(conds, `status in (`+placeholders(len(args)+1, len(q.Statuses))+`)`)
+		for _, status := range q.Statuses {
+			args = append(args, status)
+		}
+	}
+	where := ``
+	if len(conds) > 0 {
+		where = `where ` + strings.Join(conds, ` and `)
+	}
+	where += ` order by created desc, order_id desc`
+	if q.Limit > 0 {
+		where += fmt.Sprintf(` limit %d`, q.Limit)
+	}
+	return s.trackedOrders(where, args...)
+}
+
+func (s *PostgresStore) trackedOrders(where string, args ...interface{}) ([]TrackedOrder, error) {
+	orders := []TrackedOrder{}
+	rows, err := s.db.Query(`select `+trackedOrderColumns+` from tracked_orders `+where, args...)
+	if err != nil {
+		return orders, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var o TrackedOrder
+		var orderID int64
+		if err := rows.Scan(&orderID, &o.ClientID, &o.Pair, &o.OrderType, &o.Rate, &o.Amount, &o.Status, &o.Filled, &o.Created, &o.Updated, &o.Seen); err != nil {
+			return orders, err
+		}
+		o.OrderID = uint64(orderID)
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
+func (s *PostgresStore) OrderTransitions(q TransitionQuery) ([]OrderTransition, error) {
+	transitions := []OrderTransition{}
+	query := `select id, order_id, client_id, from_status, to_status, filled, reason, ts, pair, order_type, amount from order_transitions where id > $1`
+	args := []interface{}{int64(q.After)}
+	if q.OrderID != 0 {
+		query += ` and order_id = $2`
+		args = append(args, int64(q.OrderID))
+	}
+	query += ` order by id asc`
+	if q.Limit > 0 {
+		query += fmt.Sprintf(` limit %d`, q.Limit)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return transitions, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t OrderTransition
+		var id, orderID int64
+		if err := rows.Scan(&id, &orderID, &t.ClientID, &t.From, &t.To, &t.Filled, &t.Reason, &t.Time, &t.Pair, &t.OrderType, &t.Amount); err != nil {
+			return transitions, err
+		}
+		t.ID, t.OrderID = uint64(id), uint64(orderID)
+		transitions = append(transitions, t)
+	}
+	return transitions, rows.Err()
+}
+
+func (s *PostgresStore) SaveConditionalOrders(orders ...ConditionalOrder) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		for _, o := range orders {
+			_, err := tx.Exec(`insert into conditional_orders (`+conditionalOrderColumns+`) values (`+placeholders(1, 16)+`)
+				on conflict (id) do update set oco_group = excluded.oco_group, kind = excluded.kind, pair = excluded.pair, side = excluded.side,
+				trigger_rate = excluded.trigger_rate, trail = excluded.trail, extreme = excluded.extreme, amount = excluded.amount, rate = excluded.rate,
+				state = excluded.state, client_id = excluded.client_id, order_id = excluded.order_id, error = excluded.error,
+				created = excluded.created, updated = excluded.updated`,
+				o.ID, o.Group, o.Kind, o.Pair, o.Side, o.Trigger, o.Trail, o.Extreme, o.Amount, o.Rate, o.State, o.ClientID, int64(o.OrderID), o.Error,
+				o.Created, o.Updated)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) ConditionalOrder(id string) (ConditionalOrder, bool, error) {
+	orders, err := s.conditionalOrders(`where id = $1`, id)
+	if err != nil || len(orders) == 0 {
+		return ConditionalOrder{}, false, err
+	}
+	return orders[0], true, nil
+}
+
+func (s *PostgresStore) ConditionalOrders(states ...string) ([]ConditionalOrder, error) {
+	where := ``
+	args := []interface{}{}
+	if len(states) > 0 {
+		where = `where state in (` + placeholders(1, len(states)) + `)`
+		for _, state := range states {
+			args = append(args, state)
+		}
+	}
+	return s.conditionalOrders(where+` order by created asc, id asc`, args...)
+}
+
+func (s *PostgresStore) conditionalOrders(where string, args ...interface{}) ([]ConditionalOrder, error) {
+	orders := []ConditionalOrder{}
+	rows, err := s.db.Query(`select `+conditionalOrderColumns+` from conditional_orders `+where, args...)
+	if err != nil {
+		return orders, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var o ConditionalOrder
+		var orderID int64
+		if err := rows.Scan(&o.ID, &o.Group, &o.Kind, &o.Pair, &o.Side, &o.Trigger, &o.Trail, &o.Extreme, &o.Amount, &o.Rate, &o.State, &o.ClientID,
+			&orderID, &o.Error, &o.Created, &o.Updated); err != nil {
+			return orders, err
+		}
+		o.OrderID = uint64(orderID)
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
+func (s *PostgresStore) SaveRecurringOrder(o RecurringOrder) error {
+	_, err := s.db.Exec(`insert into recurring_orders (`+recurringOrderColumns+`) values (`+placeholders(1, 11)+`)
+		on conflict (id) do update set name = excluded.name, pair = excluded.pair, side = excluded.side, amount = excluded.amount,
+		schedule = excluded.schedule, limit_rate = excluded.limit_rate, state = excluded.state, source = excluded.source,
+		created = excluded.created, updated = excluded.updated`,
+		o.ID, o.Name, o.Pair, o.Side, o.Amount, o.Schedule, o.LimitRate, o.State, o.Source, o.Created, o.Updated)
+	return err
+}
+
+func (s *PostgresStore) RecurringOrder(id string) (RecurringOrder, bool, error) {
+	orders, err := s.recurringOrders(`where id = $1`, id)
+	if err != nil || len(orders) == 0 {
+		return RecurringOrder{}, false, err
+	}
+	return orders[0], true, nil
+}
+
+func (s *PostgresStore) RecurringOrders() ([]RecurringOrder, error) {
+	return s.recurringOrders(`order by created asc, id asc`)
+}
+
+func (s *PostgresStore) recurringOrders(where string, args ...interface{}) ([]RecurringOrder, error) {
+	orders := []RecurringOrder{}
+	rows, err := s.db.Query(`select `+recurringOrderColumns+` from recurring_orders `+where, args...)
+	if err != nil {
+		return orders, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var o RecurringOrder
+		if err := rows.Scan(&o.ID, &o.Name, &o.Pair, &o.Side, &o.Amount, &o.Schedule, &o.LimitRate, &o.State, &o.Source, &o.Created, &o.Updated); err != nil {
+			return orders, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
+
+func (s *PostgresStore) AddRecurringRun(r RecurringRun) error {
+	_, err := s.db.Exec(`insert into recurring_runs (recurring_id, ts, outcome, reason, order_type, rate, amount, client_id, order_id) values (`+placeholders(1, 9)+`)`,
+		r.RecurringID, r.Time, r.Outcome, r.Reason, r.OrderType, r.Rate, r.Amount, r.ClientID, int64(r.OrderID))
+	return err
+}
+
+func (s *PostgresStore) RecurringRuns(q RecurringRunQuery) ([]RecurringRun, error) {
+	runs := []RecurringRun{}
+	query := `select id, recurring_id, ts, outcome, reason, order_type, rate, amount, client_id, order_id from recurring_runs`
+	args := []interface{}{}
+	if q.RecurringID != "" {
+		query += ` where recurring_id = $1`
+		args = append(args, q.RecurringID)
+	}
+	query += ` order by id desc`
+	if q.Limit > 0 {
+		query += fmt.Sprintf(` limit %d`, q.Limit)
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return runs, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r RecurringRun
+		var id, orderID int64
+		if err := rows.Scan(&id, &r.RecurringID, &r.Time, &r.Outcome, &r.Reason, &r.OrderType, &r.Rate, &r.Amount, &r.ClientID, &orderID); err != nil {
+			return runs, err
+		}
+		r.ID, r.OrderID = uint64(id), uint64(orderID)
+		runs = append(runs, r)
+	}
+	return runs, rows.Err()
+}
+
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,1341 @@
+package bitcocheck
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bvinc/go-sqlite-lite/sqlite3"
+)
+
+// sqliteLayout Timestamps are stored as local time in this layout.
+const sqliteLayout = "2006-01-02 15:04:05"
+
+const sqliteTickHist = `create table if not exists tickhist (
+	id text PRIMARY_kEY,
+	ts timestamp NOT NULL,
+	last real NOT NULL,
+	bid real NOT NULL,
+	ask real NOT NULL,
+	high real NOT NULL,
+	low real NOT NULL,
+	volume real NOT NULL,
+	pair text NOT NULL DEFAULT 'btc_jpy')`
+
+const sqliteTickHistPair = `alter table tickhist add column pair text NOT NULL DEFAULT 'btc_jpy'`
+
+const sqliteTickHistIndex = `create index if not exists tickhist_pair_ts on tickhist (pair, ts)`
+
+// sqliteTickHistRollup is formatted with a resolution name such as "1m".
+const sqliteTickHistRollup = `create table if not exists tickhist_%s (
+	pair text NOT NULL,
+	ts timestamp NOT NULL,
+	open real NOT NULL,
+	high real NOT NULL,
+	low real NOT NULL,
+	close real NOT NULL,
+	bid real NOT NULL,
+	ask real NOT NULL,
+	volume real NOT NULL,
+	samples integer NOT NULL,
+	PRIMARY KEY (pair, ts))`
+
+const sqliteTickHistGaps = `create table if not exists tickhist_gaps (
+	pair text NOT NULL,
+	start_ts timestamp NOT NULL,
+	end_ts timestamp NOT NULL,
+	status text NOT NULL,
+	trades integer NOT NULL,
+	PRIMARY KEY (pair, start_ts))`
+
+const sqliteTrades = `create table if not exists trades (
+	id integer NOT NULL,
+	pair text NOT NULL,
+	ts timestamp NOT NULL,
+	rate real NOT NULL,
+	amount text NOT NULL,
+	order_type text NOT NULL,
+	created_at text NOT NULL,
+	PRIMARY KEY (pair, id))`
+
+const sqliteCandles = `create table if not exists candles (
+	pair text NOT NULL,
+	interval text NOT NULL,
+	ts timestamp NOT NULL,
+	open real NOT NULL,
+	high real NOT NULL,
+	low real NOT NULL,
+	close real NOT NULL,
+	volume real NOT NULL,
+	trades integer NOT NULL,
+	PRIMARY KEY (pair, interval, ts))`
+
+const sqliteOrderInfo = `create table if not exists order_info (
+	id text PRIMARY_KEY,
+	order_id int NOT NULL,
+	order_type text NOT NULL,
+	ts timestamp NOT NULL,
+	btc text NOT NULL,
+	yen text NOT NULL,
+	item json NOT NULL
+)
+`
+
+const sqliteTradeHist = `create table if not exists trade_hist (
+	id text PRIMARY_KEY,
+	ts timestamp NOT NULL,
+	btc text not NULL,
+	yen text NOT NULL
+)
+`
+
+const sqliteOrderInfoV2 = `create table order_info_v2 (
+	id text PRIMARY KEY,
+	order_id int NOT NULL,
+	order_type text NOT NULL,
+	ts timestamp NOT NULL,
+	btc text NOT NULL,
+	yen text NOT NULL,
+	item json NOT NULL,
+	pair text NOT NULL DEFAULT 'btc_jpy',
+	status text NOT NULL DEFAULT 'open',
+	fee text NOT NULL DEFAULT '0')`
+
+const sqliteTradeHistV2 = `create table trade_hist_v2 (
+	id text PRIMARY KEY,
+	ts timestamp NOT NULL,
+	btc text NOT NULL,
+	yen text NOT NULL,
+	pair text NOT NULL DEFAULT 'btc_jpy',
+	fee text NOT NULL DEFAULT '0')`
+
+const sqliteFills = `create table if not exists fills (
+	id integer PRIMARY KEY,
+	order_id integer NOT NULL,
+	ts timestamp NOT NULL,
+	pair text NOT NULL,
+	side text NOT NULL,
+	rate text NOT NULL,
+	btc text NOT NULL,
+	jpy text NOT NULL,
+	fee text NOT NULL,
+	fee_currency text NOT NULL,
+	liquidity text NOT NULL)`
+
+// sqliteFillsBase The amount of the base currency of a fill, for the pairs
+// other than btc_jpy.
+const sqliteFillsBase = `alter table fills add column base text NOT NULL DEFAULT ''`
+
+const sqliteClientOrders = `create table if not exists client_orders (
+	client_id text PRIMARY KEY,
+	pair text NOT NULL,
+	order_type text NOT NULL,
+	rate text NOT NULL,
+	amount text NOT NULL,
+	stop_loss_rate text NOT NULL,
+	state text NOT NULL,
+	order_id integer NOT NULL,
+	item text NOT NULL,
+	error text NOT NULL,
+	created timestamp NOT NULL,
+	updated timestamp NOT NULL)`
+
+const sqliteTrackedOrders = `create table if not exists tracked_orders (
+	order_id integer PRIMARY KEY,
+	client_id text NOT NULL,
+	pair text NOT NULL,
+	order_type text NOT NULL,
+	rate text NOT NULL,
+	amount text NOT NULL,
+	status text NOT NULL,
+	filled text NOT NULL,
+	created timestamp NOT NULL,
+	updated timestamp NOT NULL,
+	seen timestamp NOT NULL)`
+
+const sqliteOrderTransitions = `create table if not exists order_transitions (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	order_id integer NOT NULL,
+	from_status text NOT NULL,
+	to_status text NOT NULL,
+	filled text NOT NULL,
+	ts timestamp NOT NULL)`
+
+const sqliteConditionalOrders = `create table if not exists conditional_orders (
+	id text PRIMARY KEY,
+	oco_group text NOT NULL,
+	kind text NOT NULL,
+	pair text NOT NULL,
+	side text NOT NULL,
+	trigger_rate real NOT NULL,
+	trail real NOT NULL,
+	extreme real NOT NULL,
+	amount text NOT NULL,
+	rate text NOT NULL,
+	state text NOT NULL,
+	client_id text NOT NULL,
+	order_id integer NOT NULL,
+	error text NOT NULL,
+	created timestamp NOT NULL,
+	updated timestamp NOT NULL)`
+
+const sqliteRecurringOrders = `create table if not exists recurring_orders (
+	id text PRIMARY KEY,
+	name text NOT NULL,
+	pair text NOT NULL,
+	side text NOT NULL,
+	amount real NOT NULL,
+	schedule text NOT NULL,
+	limit_rate real NOT NULL,
+	state text NOT NULL,
+	source text NOT NULL,
+	created timestamp NOT NULL,
+	updated timestamp NOT NULL)`
+
+const sqliteRecurringRuns = `create table if not exists recurring_runs (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	recurring_id text NOT NULL,
+	ts timestamp NOT NULL,
+	outcome text NOT NULL,
+	reason text NOT NULL,
+	order_type text NOT NULL,
+	rate text NOT NULL,
+	amount text NOT NULL,
+	client_id text NOT NULL,
+	order_id integer NOT NULL)`
+
+// orderTablesV2 Builds the order tables with primary keys, pair, status and fee
+// from the tables of the baseline.
+var orderTablesV2 = []string{
+	sqliteOrderInfoV2,
+	`insert or ignore into order_info_v2 (id, order_id, order_type, ts, btc, yen, item)
+		select id, order_id, order_type, ts, btc, yen, item from order_info where id is not null`,
+	`drop table order_info`,
+	`alter table order_info_v2 rename to order_info`,
+	`create index order_info_order_id on order_info (order_id)`,
+	sqliteTradeHistV2,
+	`insert or ignore into trade_hist_v2 (id, ts, btc, yen)
+		select id, ts, btc, yen from trade_hist where id is not null`,
+	`drop table trade_hist`,
+	`alter table trade_hist_v2 rename to trade_hist`,
+	`create index trade_hist_ts on trade_hist (ts)`,
+}
+
+func hasColumn(conn *sqlite3.Conn, table, column string) (bool, error) {
+	stmt, err := conn.Prepare(fmt.Sprintf("pragma table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return false, err
+		}
+		if !hasRow {
+			break
+		}
+		name, _, err := stmt.ColumnText(1)
+		if err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// marketBaseline creates the tables of the bitcocheck databases made before
+// schema_version existed.
+func marketBaseline(conn *sqlite3.Conn) error {
+	stmts := []string{sqliteTickHist, sqliteTrades, sqliteCandles, sqliteTickHistGaps}
+	for _, res := range Resolutions {
+		stmts = append(stmts, fmt.Sprintf(sqliteTickHistRollup, res.Name))
+	}
+	for _, stmt := range stmts {
+		if err := conn.Exec(stmt); err != nil {
+			return fmt.Errorf("%v, %s", err, stmt)
+		}
+	}
+	ok, err := hasColumn(conn, "tickhist", "pair")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := conn.Exec(sqliteTickHistPair); err != nil {
+			return fmt.Errorf("%v, %s", err, sqliteTickHistPair)
+		}
+	}
+	if err := conn.Exec(sqliteTickHistIndex); err != nil {
+		return fmt.Errorf("%v, %s", err, sqliteTickHistIndex)
+	}
+	return nil
+}
+
+// MarketMigrations The schema history of the bitcocheck db. Append new
+// versions, never edit the applied ones.
+var MarketMigrations = []Migration{
+	{Version: 1, Description: "baseline", Up: marketBaseline},
+	{Version: 2, Description: "tickhist primary key", SQL: []string{
+		`create table tickhist_v2 (
+			id text PRIMARY KEY,
+			ts timestamp NOT NULL,
+			last real NOT NULL,
+			bid real NOT NULL,
+			ask real NOT NULL,
+			high real NOT NULL,
+			low real NOT NULL,
+			volume real NOT NULL,
+			pair text NOT NULL DEFAULT 'btc_jpy')`,
+		`insert or ignore into tickhist_v2 (id, ts, last, bid, ask, high, low, volume, pair)
+			select id, ts, last, bid, ask, high, low, volume, pair from tickhist where id is not null`,
+		`drop table tickhist`,
+		`alter table tickhist_v2 rename to tickhist`,
+		sqliteTickHistIndex,
+	}},
+	{Version: 3, Description: "trades time index", SQL: []string{
+		`create index if not exists trades_pair_ts on trades (pair, ts)`,
+	}},
+	{Version: 4, Description: "order tables", SQL: append(append([]string{sqliteOrderInfo, sqliteTradeHist}, orderTablesV2...), sqliteFills)},
+	{Version: 5, Description: "client orders", SQL: []string{
+		sqliteClientOrders,
+		`create index if not exists client_orders_state on client_orders (state, created)`,
+	}},
+	{Version: 6, Description: "order tracking", SQL: []string{
+		sqliteTrackedOrders,
+		`create index if not exists tracked_orders_status on tracked_orders (status, created)`,
+		sqliteOrderTransitions,
+		`create index if not exists order_transitions_order_id on order_transitions (order_id)`,
+	}},
+	{Version: 7, Description: "order events", SQL: []string{
+		`alter table order_transitions add column client_id text NOT NULL DEFAULT ''`,
+		`alter table order_transitions add column reason text NOT NULL DEFAULT ''`,
+	}},
+	{Version: 8, Description: "conditional orders", SQL: []string{
+		sqliteConditionalOrders,
+		`create index if not exists conditional_orders_state on conditional_orders (state, created)`,
+	}},
+	{Version: 9, Description: "recurring orders", SQL: []string{
+		sqliteRecurringOrders,
+		sqliteRecurringRuns,
+		`create index if not exists recurring_runs_recurring_id on recurring_runs (recurring_id)`,
+	}},
+	{Version: 10, Description: "fill base amount", SQL: []string{sqliteFillsBase}},
+	{Version: 11, Description: "rejected orders", SQL: []string{
+		`alter table order_transitions add column pair text NOT NULL DEFAULT ''`,
+		`alter table order_transitions add column order_type text NOT NULL DEFAULT ''`,
+		`alter table order_transitions add column amount text NOT NULL DEFAULT ''`,
+	}},
+}
+
+// OrderMigrations The schema history of the bitcobuy db. Append new versions,
+// never edit the applied ones.
+var OrderMigrations = []Migration{
+	{Version: 1, Description: "baseline", SQL: []string{sqliteOrderInfo, sqliteTradeHist}},
+	{Version: 2, Description: "primary keys, pair, status and fee", SQL: orderTablesV2},
+	{Version: 3, Description: "fills", SQL: []string{sqliteFills}},
+	{Version: 4, Description: "fill base amount", SQL: []string{sqliteFillsBase}},
+}
+
+// SQLiteStore A Store in a go-sqlite-lite database, safe for concurrent use.
+// Writes are serialized on one connection; reads take a read-only connection
+// from a pool and, in WAL mode, never wait for a write.
+type SQLiteStore struct {
+	mu      sync.Mutex // serializes the writes on conn
+	conn    *sqlite3.Conn
+	readers chan *sqlite3.Conn
+}
+
+// OpenSQLiteStore opens the sqlite file conf.DSN in WAL mode, applies the
+// migrations and opens the read connections. An in-memory database has no
+// read connections and serializes the reads with the writes.
+func OpenSQLiteStore(conf StoreConfig, migrations []Migration) (*SQLiteStore, error) {
+	timeout, err := conf.BusyTimeoutDuration()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlite3.Open(conf.DSN)
+	if err != nil {
+		return nil, err
+	}
+	s := &SQLiteStore{conn: conn}
+	conn.BusyTimeout(timeout)
+	if err := conn.Exec(`pragma journal_mode = WAL`); err != nil {
+		s.Close()
+		return nil, err
+	}
+	if err := Migrate(conn, migrations); err != nil {
+		s.Close()
+		return nil, err
+	}
+	if conf.DSN == "" || conf.DSN == ":memory:" {
+		return s, nil
+	}
+	s.readers = make(chan *sqlite3.Conn, conf.ReadConnCount())
+	for i := 0; i < cap(s.readers); i++ {
+		reader, err := sqlite3.Open(conf.DSN, sqlite3.OPEN_READONLY)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		reader.BusyTimeout(timeout)
+		s.readers <- reader
+	}
+	return s, nil
+}
+
+// reader takes a read connection from the pool; release puts it back.
+func (s *SQLiteStore) reader() (conn *sqlite3.Conn, release func()) {
+	if s.readers == nil {
+		s.mu.Lock()
+		return s.conn, s.mu.Unlock
+	}
+	conn = <-s.readers
+	return conn, func() { s.readers <- conn }
+}
+
+func sqliteTime(t time.Time) string {
+	return t.Local().Format(sqliteLayout)
+}
+
+func parseSQLiteTime(ts string) (time.Time, error) {
+	return time.ParseInLocation(sqliteLayout, ts, time.Local)
+}
+
+func rollupTable(res string) string {
+	if res == "raw" {
+		return "tickhist"
+	}
+	return fmt.Sprintf("tickhist_%s", res)
+}
+
+func (s *SQLiteStore) SaveTicks(ticks []Tick) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WithTx(func() error {
+		stmt, err := s.conn.Prepare(`insert into tickhist (id, ts, last, bid, ask, high, low, volume, pair) values (?,?,?,?,?,?,?,?,?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, t := range ticks {
+			if err := stmt.Exec(t.ID, sqliteTime(t.Time), t.Last, t.Bid, t.Ask, t.High, t.Low, t.Volume, t.Pair); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) AddTicks(ticks []Tick) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	err := s.conn.WithTx(func() error {
+		stmt, err := s.conn.Prepare(`insert into tickhist (id, ts, last, bid, ask, high, low, volume, pair)
+			select ?,?,?,?,?,?,?,?,? where not exists (select 1 from tickhist where pair = ? and ts = ?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, t := range ticks {
+			ts := sqliteTime(t.Time)
+			if err := stmt.Exec(t.ID, ts, t.Last, t.Bid, t.Ask, t.High, t.Low, t.Volume, t.Pair, t.Pair, ts); err != nil {
+				return err
+			}
+			n += s.conn.Changes()
+		}
+		return nil
+	})
+	return n, err
+}
+
+func (s *SQLiteStore) ScanTicks(q TickQuery, fn func(Tick) bool) error {
+	conn, release := s.reader()
+	defer release()
+	query := `select id, ts, last, bid, ask, high, low, volume from tickhist where pair = ?`
+	args := []interface{}{q.Pair}
+	if !q.From.IsZero() {
+		query += ` and ts >= ?`
+		args = append(args, sqliteTime(q.From))
+	}
+	if !q.To.IsZero() {
+		query += ` and ts < ?`
+		args = append(args, sqliteTime(q.To))
+	}
+	if q.After != nil {
+		ts := sqliteTime(q.After.Time)
+		if q.Desc {
+			query += ` and (ts < ? or (ts = ? and id < ?))`
+		} else {
+			query += ` and (ts > ? or (ts = ? and id > ?))`
+		}
+		args = append(args, ts, ts, q.After.ID)
+	}
+	if q.Desc {
+		query += ` order by ts desc, id desc`
+	} else {
+		query += ` order by ts asc, id asc`
+	}
+	if q.Limit > 0 {
+		query += ` limit ?`
+		args = append(args, q.Limit)
+	}
+	stmt, err := conn.Prepare(query, args...)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return err
+		}
+		if !hasRow {
+			return nil
+		}
+		var ts string
+		t := Tick{Pair: q.Pair}
+		if err := stmt.Scan(&t.ID, &ts, &t.Last, &t.Bid, &t.Ask, &t.High, &t.Low, &t.Volume); err != nil {
+			return err
+		}
+		if t.Time, err = parseSQLiteTime(ts); err != nil {
+			return err
+		}
+		if !fn(t) {
+			return nil
+		}
+	}
+}
+
+func (s *SQLiteStore) LastBar(pair, res string) (time.Time, error) {
+	conn, release := s.reader()
+	defer release()
+	stmt, err := conn.Prepare(fmt.Sprintf(`select max(ts) from %s where pair = ?`, rollupTable(res)), pair)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer stmt.Close()
+	hasRow, err := stmt.Step()
+	if err != nil || !hasRow {
+		return time.Time{}, err
+	}
+	ts, ok, err := stmt.ColumnText(0)
+	if err != nil || !ok {
+		return time.Time{}, err
+	}
+	return parseSQLiteTime(ts)
+}
+
+func (s *SQLiteStore) Bars(pair, res string, from time.Time) ([]TickBar, error) {
+	conn, release := s.reader()
+	defer release()
+	bars := []TickBar{}
+	query := fmt.Sprintf(`select ts, open, high, low, close, bid, ask, volume, samples from %s where pair = ? and ts >= ? order by ts asc`, rollupTable(res))
+	if res == "raw" {
+		query = `select ts, last, last, last, last, bid, ask, volume, 1 from tickhist where pair = ? and ts >= ? order by ts asc`
+	}
+	fromTs := ""
+	if !from.IsZero() {
+		fromTs = sqliteTime(from)
+	}
+	stmt, err := conn.Prepare(query, pair, fromTs)
+	if err != nil {
+		return bars, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return bars, err
+		}
+		if !hasRow {
+			break
+		}
+		var ts string
+		var bar TickBar
+		if err := stmt.Scan(&ts, &bar.Open, &bar.High, &bar.Low, &bar.Close, &bar.Bid, &bar.Ask, &bar.Volume, &bar.Samples); err != nil {
+			return bars, err
+		}
+		if bar.Time, err = parseSQLiteTime(ts); err != nil {
+			return bars, err
+		}
+		bars = append(bars, bar)
+	}
+	return bars, nil
+}
+
+func (s *SQLiteStore) SaveBars(pair, res string, bars []TickBar) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WithTx(func() error {
+		stmt, err := s.conn.Prepare(fmt.Sprintf(`insert or replace into %s values (?,?,?,?,?,?,?,?,?,?)`, rollupTable(res)))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, bar := range bars {
+			if err := stmt.Exec(pair, sqliteTime(bar.Time), bar.Open, bar.High, bar.Low, bar.Close, bar.Bid, bar.Ask, bar.Volume, bar.Samples); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) Prune(res string, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Exec(fmt.Sprintf(`delete from %s where ts < ?`, rollupTable(res)), sqliteTime(before))
+}
+
+func (s *SQLiteStore) SaveGap(gap GapRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Exec(`insert or replace into tickhist_gaps values (?,?,?,?,?)`,
+		gap.Pair, sqliteTime(gap.From), sqliteTime(gap.To), gap.Status, gap.Trades)
+}
+
+func (s *SQLiteStore) HasGap(pair string, from time.Time) (bool, error) {
+	conn, release := s.reader()
+	defer release()
+	stmt, err := conn.Prepare(`select count(*) from tickhist_gaps where pair = ? and start_ts = ?`, pair, sqliteTime(from))
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+	if _, err := stmt.Step(); err != nil {
+		return false, err
+	}
+	n, _, err := stmt.ColumnInt64(0)
+	return n > 0, err
+}
+
+func (s *SQLiteStore) Gaps(pair string, from, to time.Time) ([]GapRecord, error) {
+	conn, release := s.reader()
+	defer release()
+	gaps := []GapRecord{}
+	query := `select start_ts, end_ts, status, trades from tickhist_gaps where pair = ?`
+	args := []interface{}{pair}
+	if !from.IsZero() {
+		query += ` and end_ts > ?`
+		args = append(args, sqliteTime(from))
+	}
+	if !to.IsZero() {
+		query += ` and start_ts < ?`
+		args = append(args, sqliteTime(to))
+	}
+	stmt, err := conn.Prepare(query+` order by start_ts asc`, args...)
+	if err != nil {
+		return gaps, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return gaps, err
+		}
+		if !hasRow {
+			break
+		}
+		var start, end string
+		gap := GapRecord{Pair: pair}
+		if err := stmt.Scan(&start, &end, &gap.Status, &gap.Trades); err != nil {
+			return gaps, err
+		}
+		if gap.From, err = parseSQLiteTime(start); err != nil {
+			return gaps, err
+		}
+		if gap.To, err = parseSQLiteTime(end); err != nil {
+			return gaps, err
+		}
+		gaps = append(gaps, gap)
+	}
+	return gaps, nil
+}
+
+func (s *SQLiteStore) ForgetGaps(pair string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := sqliteTime(t)
+	return s.conn.Exec(`delete from tickhist_gaps where pair = ? and start_ts < ? and end_ts > ?`, pair, ts, ts)
+}
+
+func (s *SQLiteStore) MaxTradeID(pair string) (uint32, error) {
+	conn, release := s.reader()
+	defer release()
+	stmt, err := conn.Prepare(`select coalesce(max(id), 0) from trades where pair = ?`, pair)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	if _, err := stmt.Step(); err != nil {
+		return 0, err
+	}
+	id, _, err := stmt.ColumnInt64(0)
+	return uint32(id), err
+}
+
+func (s *SQLiteStore) SaveTrades(pair string, trades []*TradeData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WithTx(func() error {
+		stmt, err := s.conn.Prepare(`insert or ignore into trades values (?,?,?,?,?,?,?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, trade := range trades {
+			tm, err := TradeTime(trade)
+			if err != nil {
+				return err
+			}
+			if err := stmt.Exec(int64(trade.ID), pair, sqliteTime(tm), trade.RateV2, trade.Amount, trade.OrderType, trade.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) Trades(pair string, from, to time.Time) ([]*TradeData, error) {
+	conn, release := s.reader()
+	defer release()
+	trades := []*TradeData{}
+	stmt, err := conn.Prepare(`select id, rate, amount, order_type, created_at from trades where pair = ? and ts >= ? and ts < ? order by id asc`,
+		pair, sqliteTime(from), sqliteTime(to))
+	if err != nil {
+		return trades, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return trades, err
+		}
+		if !hasRow {
+			break
+		}
+		var id int64
+		var rate float64
+		trade := TradeData{Pair: pair}
+		if err := stmt.Scan(&id, &rate, &trade.Amount, &trade.OrderType, &trade.CreatedAt); err != nil {
+			return trades, err
+		}
+		trade.ID = uint32(id)
+		trade.setRate(rate)
+		trades = append(trades, &trade)
+	}
+	return trades, nil
+}
+
+func (s *SQLiteStore) SaveCandles(pair, interval string, candles []*Candle) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WithTx(func() error {
+		stmt, err := s.conn.Prepare(`insert or replace into candles values (?,?,?,?,?,?,?,?,?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, c := range candles {
+			tm := time.Unix(int64(c.Timestamp), 0)
+			if err := stmt.Exec(pair, interval, sqliteTime(tm), c.Open, c.High, c.Low, c.Close, c.Volume, int64(c.Trades)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) Candles(pair, interval string, from, to time.Time) ([]*Candle, error) {
+	conn, release := s.reader()
+	defer release()
+	candles := []*Candle{}
+	stmt, err := conn.Prepare(`select ts, open, high, low, close, volume, trades from candles where pair = ? and interval = ? and ts >= ? and ts < ? order by ts asc`,
+		pair, interval, sqliteTime(from), sqliteTime(to))
+	if err != nil {
+		return candles, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return candles, err
+		}
+		if !hasRow {
+			break
+		}
+		var ts string
+		var trades int64
+		var c Candle
+		if err := stmt.Scan(&ts, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &trades); err != nil {
+			return candles, err
+		}
+		tm, err := parseSQLiteTime(ts)
+		if err != nil {
+			return candles, err
+		}
+		c.Timestamp = uint64(tm.Unix())
+		c.Trades = uint32(trades)
+		candles = append(candles, &c)
+	}
+	return candles, nil
+}
+
+func (s *SQLiteStore) SaveOrder(o StoredOrder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Exec(`insert or replace into order_info (id, order_id, order_type, ts, btc, yen, item, pair, status, fee) values (?,?,?,?,?,?,?,?,?,?)`,
+		o.ID, int64(o.OrderID), o.OrderType, sqliteTime(o.Time), o.Btc, o.Yen, o.Item, o.Pair, o.Status, o.Fee)
+}
+
+func (s *SQLiteStore) Orders() ([]StoredOrder, error) {
+	conn, release := s.reader()
+	defer release()
+	orders := []StoredOrder{}
+	stmt, err := conn.Prepare(`select id, order_id, order_type, ts, btc, yen, item, pair, status, fee from order_info order by ts asc, id asc`)
+	if err != nil {
+		return orders, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return orders, err
+		}
+		if !hasRow {
+			break
+		}
+		var o StoredOrder
+		var orderID int64
+		var ts string
+		if err := stmt.Scan(&o.ID, &orderID, &o.OrderType, &ts, &o.Btc, &o.Yen, &o.Item, &o.Pair, &o.Status, &o.Fee); err != nil {
+			return orders, err
+		}
+		o.OrderID = uint64(orderID)
+		if o.Time, err = parseSQLiteTime(ts); err != nil {
+			return orders, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+func (s *SQLiteStore) DeleteOrder(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Exec(`delete from order_info where id = ?`, id)
+}
+
+func (s *SQLiteStore) SaveFills(fills []Fill) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WithTx(func() error {
+		stmt, err := s.conn.Prepare(`insert or ignore into fills (id, order_id, ts, pair, side, rate, btc, jpy, fee, fee_currency, liquidity, base) values (?,?,?,?,?,?,?,?,?,?,?,?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, f := range fills {
+			if err := stmt.Exec(int64(f.ID), int64(f.OrderID), sqliteTime(f.Time), f.Pair, f.Side, f.Rate, f.Btc, f.Jpy, f.Fee, f.FeeCurrency, f.Liquidity, f.Base); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) Fills(orderID uint64) ([]Fill, error) {
+	conn, release := s.reader()
+	defer release()
+	fills := []Fill{}
+	query := `select id, order_id, ts, pair, side, rate, btc, jpy, fee, fee_currency, liquidity, base from fills`
+	args := []interface{}{}
+	if orderID != 0 {
+		query += ` where order_id = ?`
+		args = append(args, int64(orderID))
+	}
+	stmt, err := conn.Prepare(query+` order by id asc`, args...)
+	if err != nil {
+		return fills, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return fills, err
+		}
+		if !hasRow {
+			break
+		}
+		var f Fill
+		var id, oid int64
+		var ts string
+		if err := stmt.Scan(&id, &oid, &ts, &f.Pair, &f.Side, &f.Rate, &f.Btc, &f.Jpy, &f.Fee, &f.FeeCurrency, &f.Liquidity, &f.Base); err != nil {
+			return fills, err
+		}
+		f.ID, f.OrderID = uint64(id), uint64(oid)
+		if f.Time, err = parseSQLiteTime(ts); err != nil {
+			return fills, err
+		}
+		fills = append(fills, f)
+	}
+	return fills, nil
+}
+
+func (s *SQLiteStore) SaveTradeHist(h TradeHist) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Exec(`insert into trade_hist (id, ts, btc, yen, pair, fee) values (?,?,?,?,?,?)`,
+		h.ID, sqliteTime(h.Time), h.Btc, h.Yen, h.Pair, h.Fee)
+}
+
+func (s *SQLiteStore) TradeHists() ([]TradeHist, error) {
+	conn, release := s.reader()
+	defer release()
+	hists := []TradeHist{}
+	stmt, err := conn.Prepare(`select id, ts, btc, yen, pair, fee from trade_hist order by ts asc`)
+	if err != nil {
+		return hists, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return hists, err
+		}
+		if !hasRow {
+			break
+		}
+		var h TradeHist
+		var ts string
+		if err := stmt.Scan(&h.ID, &ts, &h.Btc, &h.Yen, &h.Pair, &h.Fee); err != nil {
+			return hists, err
+		}
+		if h.Time, err = parseSQLiteTime(ts); err != nil {
+			return hists, err
+		}
+		hists = append(hists, h)
+	}
+	return hists, nil
+}
+
+const clientOrderColumns = `client_id, pair, order_type, rate, amount, stop_loss_rate, state, order_id, item, error, created, updated`
+
+func (s *SQLiteStore) AddClientOrder(o ClientOrder) (ClientOrder, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.conn.Exec(`insert or ignore into client_orders (`+clientOrderColumns+`) values (?,?,?,?,?,?,?,?,?,?,?,?)`,
+		o.ClientID, o.Pair, o.OrderType, o.Rate, o.Amount, o.StopLossRate, o.State, int64(o.OrderID), o.Item, o.Error, sqliteTime(o.Created), sqliteTime(o.Updated))
+	if err != nil {
+		return o, false, err
+	}
+	if s.conn.Changes() == 1 {
+		return o, true, nil
+	}
+	orders, err := queryClientOrders(s.conn, `where client_id = ?`, o.ClientID)
+	if err != nil || len(orders) == 0 {
+		return o, false, err
+	}
+	return orders[0], false, nil
+}
+
+func (s *SQLiteStore) UpdateClientOrder(o ClientOrder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Exec(`insert or replace into client_orders (`+clientOrderColumns+`) values (?,?,?,?,?,?,?,?,?,?,?,?)`,
+		o.ClientID, o.Pair, o.OrderType, o.Rate, o.Amount, o.StopLossRate, o.State, int64(o.OrderID), o.Item, o.Error, sqliteTime(o.Created), sqliteTime(o.Updated))
+}
+
+func (s *SQLiteStore) ClientOrder(clientID string) (ClientOrder, bool, error) {
+	conn, release := s.reader()
+	defer release()
+	orders, err := queryClientOrders(conn, `where client_id = ?`, clientID)
+	if err != nil || len(orders) == 0 {
+		return ClientOrder{}, false, err
+	}
+	return orders[0], true, nil
+}
+
+func (s *SQLiteStore) ClientOrders(state string, since time.Time) ([]ClientOrder, error) {
+	conn, release := s.reader()
+	defer release()
+	where := `where created >= ?`
+	args := []interface{}{sqliteTime(since)}
+	if state != "" {
+		where += ` and state = ?`
+		args = append(args, state)
+	}
+	return queryClientOrders(conn, where+` order by created asc, client_id asc`, args...)
+}
+
+func queryClientOrders(conn *sqlite3.Conn, where string, args ...interface{}) ([]ClientOrder, error) {
+	orders := []ClientOrder{}
+	stmt, err := conn.Prepare(`select `+clientOrderColumns+` from client_orders `+where, args...)
+	if err != nil {
+		return orders, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return orders, err
+		}
+		if !hasRow {
+			break
+		}
+		var o ClientOrder
+		var orderID int64
+		var created, updated string
+		if err := stmt.Scan(&o.ClientID, &o.Pair, &o.OrderType, &o.Rate, &o.Amount, &o.StopLossRate, &o.State, &orderID, &o.Item, &o.Error, &created, &updated); err != nil {
+			return orders, err
+		}
+		o.OrderID = uint64(orderID)
+		if o.Created, err = parseSQLiteTime(created); err != nil {
+			return orders, err
+		}
+		if o.Updated, err = parseSQLiteTime(updated); err != nil {
+			return orders, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+const trackedOrderColumns = `order_id, client_id, pair, order_type, rate, amount, status, filled, created, updated, seen`
+
+func (s *SQLiteStore) SaveTrackedOrder(o TrackedOrder, tr *OrderTransition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WithTx(func() error {
+		err := s.conn.Exec(`insert or replace into tracked_orders (`+trackedOrderColumns+`) values (?,?,?,?,?,?,?,?,?,?,?)`,
+			int64(o.OrderID), o.ClientID, o.Pair, o.OrderType, o.Rate, o.Amount, o.Status, o.Filled, sqliteTime(o.Created), sqliteTime(o.Updated), sqliteTime(o.Seen))
+		if err != nil || tr == nil {
+			return err
+		}
+		return s.addOrderTransition(*tr)
+	})
+}
+
+func (s *SQLiteStore) AddOrderTransition(tr OrderTransition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addOrderTransition(tr)
+}
+
+func (s *SQLiteStore) addOrderTransition(tr OrderTransition) error {
+	return s.conn.Exec(`insert into order_transitions (order_id, client_id, from_status, to_status, filled, reason, ts, pair, order_type, amount) values (?,?,?,?,?,?,?,?,?,?)`,
+		int64(tr.OrderID), tr.ClientID, tr.From, tr.To, tr.Filled, tr.Reason, sqliteTime(tr.Time), tr.Pair, tr.OrderType, tr.Amount)
+}
+
+func (s *SQLiteStore) TrackedOrder(orderID uint64) (TrackedOrder, bool, error) {
+	conn, release := s.reader()
+	defer release()
+	orders, err := queryTrackedOrders(conn, `where order_id = ?`, int64(orderID))
+	if err != nil || len(orders) == 0 {
+		return TrackedOrder{}, false, err
+	}
+	return orders[0], true, nil
+}
+
+func (s *SQLiteStore) TrackedOrders(q TrackedOrderQuery) ([]TrackedOrder, error) {
+	conn, release := s.reader()
+	defer release()
+	conds := []string{}
+	args := []interface{}{}
+	if q.Pair != "" {
+		conds = append(conds, `pair = ?`)
+		args = append(args, q.Pair)
+	}
+	if len(q.Statuses) > 0 {
+		conds = append(conds, `status in (?`+strings.Repeat(`,?`, len(q.Statuses)-1)+`)`)
+		for _, status := range q.Statuses {
+			args = append(args, status)
+		}
+	}
+	where := ``
+	if len(conds) > 0 {
+		where = `where ` + strings.Join(conds, ` and `)
+	}
+	where += ` order by created desc, order_id desc`
+	if q.Limit > 0 {
+		where += fmt.Sprintf(` limit %d`, q.Limit)
+	}
+	return queryTrackedOrders(conn, where, args...)
+}
+
+func queryTrackedOrders(conn *sqlite3.Conn, where string, args ...interface{}) ([]TrackedOrder, error) {
+	orders := []TrackedOrder{}
+	stmt, err := conn.Prepare(`select `+trackedOrderColumns+` from tracked_orders `+where, args...)
+	if err != nil {
+		return orders, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return orders, err
+		}
+		if !hasRow {
+			break
+		}
+		var o TrackedOrder
+		var orderID int64
+		var created, updated, seen string
+		if err := stmt.Scan(&orderID, &o.ClientID, &o.Pair, &o.OrderType, &o.Rate, &o.Amount, &o.Status, &o.Filled, &created, &updated, &seen); err != nil {
+			return orders, err
+		}
+		o.OrderID = uint64(orderID)
+		if o.Created, err = parseSQLiteTime(created); err != nil {
+			return orders, err
+		}
+		if o.Updated, err = parseSQLiteTime(updated); err != nil {
+			return orders, err
+		}
+		if o.Seen, err = parseSQLiteTime(seen); err != nil {
+			return orders, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+func (s *SQLiteStore) OrderTransitions(q TransitionQuery) ([]OrderTransition, error) {
+	conn, release := s.reader()
+	defer release()
+	transitions := []OrderTransition{}
+	query := `select id, order_id, client_id, from_status, to_status, filled, reason, ts, pair, order_type, amount from order_transitions where id > ?`
+	args := []interface{}{int64(q.After)}
+	if q.OrderID != 0 {
+		query += ` and order_id = ?`
+		args = append(args, int64(q.OrderID))
+	}
+	query += ` order by id asc`
+	if q.Limit > 0 {
+		query += fmt.Sprintf(` limit %d`, q.Limit)
+	}
+	stmt, err := conn.Prepare(query, args...)
+	if err != nil {
+		return transitions, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return transitions, err
+		}
+		if !hasRow {
+			break
+		}
+		var t OrderTransition
+		var id, orderID int64
+		var ts string
+		if err := stmt.Scan(&id, &orderID, &t.ClientID, &t.From, &t.To, &t.Filled, &t.Reason, &ts, &t.Pair, &t.OrderType, &t.Amount); err != nil {
+			return transitions, err
+		}
+		t.ID, t.OrderID = uint64(id), uint64(orderID)
+		if t.Time, err = parseSQLiteTime(ts); err != nil {
+			return transitions, err
+		}
+		transitions = append(transitions, t)
+	}
+	return transitions, nil
+}
+
+const conditionalOrderColumns = `id, oco_group, kind, pair, side, trigger_rate, trail, extreme, amount, rate, state, client_id, order_id, error, created, updated`
+
+func (s *SQLiteStore) SaveConditionalOrders(orders ...ConditionalOrder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.WithTx(func() error {
+		for _, o := range orders {
+			err := s.conn.Exec(`insert or replace into conditional_orders (`+conditionalOrderColumns+`) values (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+				o.ID, o.Group, o.Kind, o.Pair, o.Side, o.Trigger, o.Trail, o.Extreme, o.Amount, o.Rate, o.State, o.ClientID, int64(o.OrderID), o.Error,
+				sqliteTime(o.Created), sqliteTime(o.Updated))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) ConditionalOrder(id string) (ConditionalOrder, bool, error) {
+	conn, release := s.reader()
+	defer release()
+	orders, err := queryConditionalOrders(conn, `where id = ?`, id)
+	if err != nil || len(orders) == 0 {
+		return ConditionalOrder{}, false, err
+	}
+	return orders[0], true, nil
+}
+
+func (s *SQLiteStore) ConditionalOrders(states ...string) ([]ConditionalOrder, error) {
+	conn, release := s.reader()
+	defer release()
+	where := ``
+	args := []interface{}{}
+	if len(states) > 0 {
+		where = `where state in (?` + strings.Repeat(`,?`, len(states)-1) + `)`
+		for _, state := range states {
+			args = append(args, state)
+		}
+	}
+	return queryConditionalOrders(conn, where+` order by created asc, id asc`, args...)
+}
+
+func queryConditionalOrders(conn *sqlite3.Conn, where string, args ...interface{}) ([]ConditionalOrder, error) {
+	orders := []ConditionalOrder{}
+	stmt, err := conn.Prepare(`select `+conditionalOrderColumns+` from conditional_orders `+where, args...)
+	if err != nil {
+		return orders, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return orders, err
+		}
+		if !hasRow {
+			break
+		}
+		var o ConditionalOrder
+		var orderID int64
+		var created, updated string
+		if err := stmt.Scan(&o.ID, &o.Group, &o.Kind, &o.Pair, &o.Side, &o.Trigger, &o.Trail, &o.Extreme, &o.Amount, &o.Rate, &o.State, &o.ClientID,
+			&orderID, &o.Error, &created, &updated); err != nil {
+			return orders, err
+		}
+		o.OrderID = uint64(orderID)
+		if o.Created, err = parseSQLiteTime(created); err != nil {
+			return orders, err
+		}
+		if o.Updated, err = parseSQLiteTime(updated); err != nil {
+			return orders, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+const recurringOrderColumns = `id, name, pair, side, amount, schedule, limit_rate, state, source, created, updated`
+
+func (s *SQLiteStore) SaveRecurringOrder(o RecurringOrder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Exec(`insert or replace into recurring_orders (`+recurringOrderColumns+`) values (?,?,?,?,?,?,?,?,?,?,?)`,
+		o.ID, o.Name, o.Pair, o.Side, o.Amount, o.Schedule, o.LimitRate, o.State, o.Source, sqliteTime(o.Created), sqliteTime(o.Updated))
+}
+
+func (s *SQLiteStore) RecurringOrder(id string) (RecurringOrder, bool, error) {
+	conn, release := s.reader()
+	defer release()
+	orders, err := queryRecurringOrders(conn, `where id = ?`, id)
+	if err != nil || len(orders) == 0 {
+		return RecurringOrder{}, false, err
+	}
+	return orders[0], true, nil
+}
+
+func (s *SQLiteStore) RecurringOrders() ([]RecurringOrder, error) {
+	conn, release := s.reader()
+	defer release()
+	return queryRecurringOrders(conn, `order by created asc, id asc`)
+}
+
+func queryRecurringOrders(conn *sqlite3.Conn, where string, args ...interface{}) ([]RecurringOrder, error) {
+	orders := []RecurringOrder{}
+	stmt, err := conn.Prepare(`select `+recurringOrderColumns+` from recurring_orders `+where, args...)
+	if err != nil {
+		return orders, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return orders, err
+		}
+		if !hasRow {
+			break
+		}
+		var o RecurringOrder
+		var created, updated string
+		if err := stmt.Scan(&o.ID, &o.Name, &o.Pair, &o.Side, &o.Amount, &o.Schedule, &o.LimitRate, &o.State, &o.Source, &created, &updated); err != nil {
+			return orders, err
+		}
+		if o.Created, err = parseSQLiteTime(created); err != nil {
+			return orders, err
+		}
+		if o.Updated, err = parseSQLiteTime(updated); err != nil {
+			return orders, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+func (s *SQLiteStore) AddRecurringRun(r RecurringRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Exec(`insert into recurring_runs (recurring_id, ts, outcome, reason, order_type, rate, amount, client_id, order_id) values (?,?,?,?,?,?,?,?,?)`,
+		r.RecurringID, sqliteTime(r.Time), r.Outcome, r.Reason, r.OrderType, r.Rate, r.Amount, r.ClientID, int64(r.OrderID))
+}
+
+func (s *SQLiteStore) RecurringRuns(q RecurringRunQuery) ([]RecurringRun, error) {
+	conn, release := s.reader()
+	defer release()
+	runs := []RecurringRun{}
+	query := `select id, recurring_id, ts, outcome, reason, order_type, rate, amount, client_id, order_id from recurring_runs`
+	args := []interface{}{}
+	if q.RecurringID != "" {
+		query += ` where recurring_id = ?`
+		args = append(args, q.RecurringID)
+	}
+	query += ` order by id desc`
+	if q.Limit > 0 {
+		query += fmt.Sprintf(` limit %d`, q.Limit)
+	}
+	stmt, err := conn.Prepare(query, args...)
+	if err != nil {
+		return runs, err
+	}
+	defer stmt.Close()
+	for {
+		hasRow, err := stmt.Step()
+		if err != nil {
+			return runs, err
+		}
+		if !hasRow {
+			break
+		}
+		var r RecurringRun
+		var id, orderID int64
+		var ts string
+		if err := stmt.Scan(&id, &r.RecurringID, &ts, &r.Outcome, &r.Reason, &r.OrderType, &r.Rate, &r.Amount, &r.ClientID, &orderID); err != nil {
+			return runs, err
+		}
+		r.ID, r.OrderID = uint64(id), uint64(orderID)
+		if r.Time, err = parseSQLiteTime(ts); err != nil {
+			return runs, err
+		}
+		runs = append(runs, r)
+	}
+	return runs, nil
+}
+
+// Close waits for the running reads and writes and closes the connections.
+func (s *SQLiteStore) Close() error {
+	if s.readers != nil {
+		for i := 0; i < cap(s.readers); i++ {
+			if err := (<-s.readers).Close(); err != nil {
+				return err
+			}
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn.Close()
+}
@@ -0,0 +1,485 @@
+package bitcocheck
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testStores returns the backends to test. PostgreSQL is tested when
+// BITCOCHECK_POSTGRES_DSN points to an empty database.
+func testStores(t *testing.T, dir string) map[string]Store {
+	stores := map[string]Store{"memory": NewMemoryStore()}
+	market, err := OpenSQLiteStore(StoreConfig{DSN: filepath.Join(dir, "market.db")}, MarketMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stores["sqlite"] = market
+	if dsn := os.Getenv("BITCOCHECK_POSTGRES_DSN"); dsn != "" {
+		pg, err := OpenPostgresStore(dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stores["postgres"] = pg
+	}
+	return stores
+}
+
+func TestStoreTicks(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.Local)
+	ticks := []Tick{
+		{ID: "a", Pair: "btc_jpy", Time: base, Last: 100, Bid: 99, Ask: 101, High: 110, Low: 90, Volume: 1},
+		{ID: "b", Pair: "btc_jpy", Time: base.Add(time.Hour), Last: 105, Bid: 104, Ask: 106, High: 110, Low: 90, Volume: 2},
+		{ID: "c", Pair: "btc_jpy", Time: base.Add(2 * time.Hour), Last: 110, Bid: 109, Ask: 111, High: 110, Low: 90, Volume: 3},
+		{ID: "d", Pair: "fct_jpy", Time: base, Last: 1, Bid: 1, Ask: 1, High: 1, Low: 1, Volume: 1},
+	}
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, s := range testStores(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			defer s.Close()
+			if err := s.SaveTicks(ticks); err != nil {
+				t.Fatalf("SaveTicks() error = %v", err)
+			}
+			n, err := s.AddTicks([]Tick{
+				{ID: "e", Pair: "btc_jpy", Time: base.Add(time.Hour), Last: 1},
+				{ID: "f", Pair: "btc_jpy", Time: base.Add(3 * time.Hour), Last: 115, Bid: 114, Ask: 116, High: 115, Low: 90, Volume: 4},
+			})
+			if err != nil || n != 1 {
+				t.Fatalf("AddTicks() = %v, %v, want 1", n, err)
+			}
+			ids := func(q TickQuery) []string {
+				got := []string{}
+				if err := s.ScanTicks(q, func(tick Tick) bool {
+					got = append(got, tick.ID)
+					return true
+				}); err != nil {
+					t.Fatalf("ScanTicks() error = %v", err)
+				}
+				return got
+			}
+			tests := []struct {
+				name string
+				q    TickQuery
+				want []string
+			}{
+				{name: "asc", q: TickQuery{Pair: "btc_jpy"}, want: []string{"a", "b", "c", "f"}},
+				{name: "desc limit", q: TickQuery{Pair: "btc_jpy", Desc: true, Limit: 2}, want: []string{"f", "c"}},
+				{name: "range", q: TickQuery{Pair: "btc_jpy", From: base.Add(time.Hour), To: base.Add(3 * time.Hour)}, want: []string{"b", "c"}},
+				{name: "after", q: TickQuery{Pair: "btc_jpy", After: &TickKey{Time: base.Add(time.Hour), ID: "b"}}, want: []string{"c", "f"}},
+				{name: "after desc", q: TickQuery{Pair: "btc_jpy", Desc: true, After: &TickKey{Time: base.Add(time.Hour), ID: "b"}}, want: []string{"a"}},
+			}
+			for _, tt := range tests {
+				if got := ids(tt.q); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ScanTicks(%s) = %v, want %v", tt.name, got, tt.want)
+				}
+			}
+			bars, err := s.Bars("btc_jpy", "raw", time.Time{})
+			if err != nil || len(bars) != 4 || bars[3].Close != 115 {
+				t.Errorf("Bars() = %v, %v", bars, err)
+			}
+			rolled := RollupBars(bars, 2*time.Hour)
+			if err := s.SaveBars("btc_jpy", "1h", rolled); err != nil {
+				t.Fatalf("SaveBars() error = %v", err)
+			}
+			last, err := s.LastBar("btc_jpy", "1h")
+			if err != nil || !last.Equal(rolled[len(rolled)-1].Time) {
+				t.Errorf("LastBar() = %v, %v", last, err)
+			}
+			if err := s.Prune("raw", base.Add(2*time.Hour)); err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+			if got := ids(TickQuery{Pair: "btc_jpy"}); !reflect.DeepEqual(got, []string{"c", "f"}) {
+				t.Errorf("ScanTicks() after Prune = %v", got)
+			}
+		})
+	}
+}
+
+func TestStoreGaps(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.Local)
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, s := range testStores(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			defer s.Close()
+			gap := GapRecord{Pair: "btc_jpy", From: base, To: base.Add(5 * time.Hour), Status: "irrecoverable"}
+			if err := s.SaveGap(gap); err != nil {
+				t.Fatalf("SaveGap() error = %v", err)
+			}
+			if ok, err := s.HasGap("btc_jpy", base); !ok || err != nil {
+				t.Errorf("HasGap() = %v, %v", ok, err)
+			}
+			gaps, err := s.Gaps("btc_jpy", base.Add(time.Hour), time.Time{})
+			if err != nil || len(gaps) != 1 || gaps[0].Status != "irrecoverable" || !gaps[0].To.Equal(gap.To) {
+				t.Errorf("Gaps() = %v, %v", gaps, err)
+			}
+			if err := s.ForgetGaps("btc_jpy", base.Add(time.Hour)); err != nil {
+				t.Fatalf("ForgetGaps() error = %v", err)
+			}
+			if gaps, err := s.Gaps("btc_jpy", time.Time{}, time.Time{}); err != nil || len(gaps) != 0 {
+				t.Errorf("Gaps() after ForgetGaps = %v, %v", gaps, err)
+			}
+		})
+	}
+}
+
+func TestStoreTradesCandles(t *testing.T) {
+	trades := []*TradeData{
+		{ID: 2, Amount: "0.5", Rate: 110, RateV2: 110, OrderType: "sell", CreatedAt: "2020-03-15T10:00:30Z"},
+		{ID: 1, Amount: "0.5", Rate: 100, RateV2: 100, OrderType: "buy", CreatedAt: "2020-03-15T10:00:00Z"},
+	}
+	from := time.Date(2020, 3, 15, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Minute)
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, s := range testStores(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			defer s.Close()
+			for i := 0; i < 2; i++ {
+				if err := s.SaveTrades("btc_jpy", trades); err != nil {
+					t.Fatalf("SaveTrades() error = %v", err)
+				}
+			}
+			if id, err := s.MaxTradeID("btc_jpy"); id != 2 || err != nil {
+				t.Errorf("MaxTradeID() = %v, %v", id, err)
+			}
+			got, err := s.Trades("btc_jpy", from, to)
+			if err != nil || len(got) != 2 || got[0].ID != 1 || got[1].Amount != "0.5" || got[1].RateV2 != 110 || got[1].Rate != 110 {
+				t.Errorf("Trades() = %v, %v", got, err)
+			}
+			candles, err := BuildCandles(got, time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.SaveCandles("btc_jpy", "1m", candles); err != nil {
+				t.Fatalf("SaveCandles() error = %v", err)
+			}
+			stored, err := s.Candles("btc_jpy", "1m", from, to)
+			if err != nil || len(stored) != 1 || stored[0].Close != 110 || stored[0].Trades != 2 {
+				t.Errorf("Candles() = %v, %v", stored, err)
+			}
+		})
+	}
+}
+
+func TestStoreOrders(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.Local)
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, s := range testStores(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			defer s.Close()
+			order := StoredOrder{ID: "o1", OrderID: 12345, OrderType: "buy", Time: base, Btc: "0.1", Yen: "100000", Item: "{}", Pair: "btc_jpy", Status: "open", Fee: "0"}
+			if err := s.SaveOrder(order); err != nil {
+				t.Fatalf("SaveOrder() error = %v", err)
+			}
+			order.Status = "filled"
+			if err := s.SaveOrder(order); err != nil {
+				t.Fatalf("SaveOrder() error = %v", err)
+			}
+			if orders, err := s.Orders(); err != nil || len(orders) != 1 || !orders[0].Time.Equal(base) || orders[0].Status != "filled" {
+				t.Errorf("Orders() = %v, %v", orders, err)
+			}
+			fill := Fill{ID: 38, OrderID: 12345, Time: base, Pair: "btc_jpy", Side: "buy", Rate: "1000000", Btc: "0.1", Jpy: "-100000", Fee: "0", FeeCurrency: "JPY", Liquidity: "T", Base: "0.1"}
+			if err := s.SaveFills([]Fill{fill, fill}); err != nil {
+				t.Fatalf("SaveFills() error = %v", err)
+			}
+			if fills, err := s.Fills(12345); err != nil || len(fills) != 1 || fills[0].ID != 38 || fills[0].Jpy != "-100000" || fills[0].Base != "0.1" {
+				t.Errorf("Fills() = %v, %v", fills, err)
+			}
+			if err := s.DeleteOrder("o1"); err != nil {
+				t.Fatalf("DeleteOrder() error = %v", err)
+			}
+			if orders, err := s.Orders(); err != nil || len(orders) != 0 {
+				t.Errorf("Orders() after DeleteOrder = %v, %v", orders, err)
+			}
+			hist := TradeHist{ID: "h1", Time: base, Btc: "0.1", Yen: "101000", Pair: "btc_jpy", Fee: "0"}
+			if err := s.SaveTradeHist(hist); err != nil {
+				t.Fatalf("SaveTradeHist() error = %v", err)
+			}
+			if hists, err := s.TradeHists(); err != nil || len(hists) != 1 || hists[0].ID != "h1" || !hists[0].Time.Equal(base) {
+				t.Errorf("TradeHists() = %v, %v", hists, err)
+			}
+		})
+	}
+}
+
+func TestStoreClientOrders(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.Local)
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, s := range testStores(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			defer s.Close()
+			o := ClientOrder{ClientID: "c1", Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1", State: ClientOrderPending, Created: base, Updated: base}
+			if stored, added, err := s.AddClientOrder(o); err != nil || !added || stored.ClientID != "c1" {
+				t.Fatalf("AddClientOrder() = %v, %v, %v", stored, added, err)
+			}
+			retry := o
+			retry.Created = base.Add(time.Second)
+			if stored, added, err := s.AddClientOrder(retry); err != nil || added || !stored.Created.Equal(base) {
+				t.Fatalf("AddClientOrder() of a taken ID = %v, %v, %v", stored, added, err)
+			}
+			o.State, o.OrderID, o.Item, o.Updated = ClientOrderPlaced, 12345, `{"id":12345}`, base.Add(time.Minute)
+			if err := s.UpdateClientOrder(o); err != nil {
+				t.Fatalf("UpdateClientOrder() error = %v", err)
+			}
+			if got, ok, err := s.ClientOrder("c1"); err != nil || !ok || got.State != ClientOrderPlaced || got.OrderID != 12345 || !got.Updated.Equal(base.Add(time.Minute)) {
+				t.Errorf("ClientOrder() = %v, %v, %v", got, ok, err)
+			}
+			if _, ok, err := s.ClientOrder("c2"); err != nil || ok {
+				t.Errorf("ClientOrder() of an unknown ID = %v, %v", ok, err)
+			}
+			unknown := ClientOrder{ClientID: "c2", Pair: "btc_jpy", OrderType: "market_buy", Amount: "10000", State: ClientOrderUnknown, Created: base.Add(time.Hour), Updated: base.Add(time.Hour)}
+			if _, _, err := s.AddClientOrder(unknown); err != nil {
+				t.Fatalf("AddClientOrder() error = %v", err)
+			}
+			if orders, err := s.ClientOrders(ClientOrderUnknown, time.Time{}); err != nil || len(orders) != 1 || orders[0].ClientID != "c2" {
+				t.Errorf("ClientOrders(unknown) = %v, %v", orders, err)
+			}
+			if orders, err := s.ClientOrders("", base); err != nil || len(orders) != 2 || orders[0].ClientID != "c1" {
+				t.Errorf("ClientOrders() = %v, %v", orders, err)
+			}
+			if orders, err := s.ClientOrders("", base.Add(time.Minute)); err != nil || len(orders) != 1 || orders[0].ClientID != "c2" {
+				t.Errorf("ClientOrders() since = %v, %v", orders, err)
+			}
+		})
+	}
+}
+
+func TestStoreTrackedOrders(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.Local)
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, s := range testStores(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			defer s.Close()
+			o := TrackedOrder{OrderID: 1, ClientID: "c1", Pair: "btc_jpy", OrderType: "buy", Rate: "1000000", Amount: "0.1", Status: OrderNew, Filled: "0", Created: base, Updated: base}
+			if err := s.SaveTrackedOrder(o, &OrderTransition{OrderID: 1, To: OrderNew, Filled: "0", Time: base}); err != nil {
+				t.Fatalf("SaveTrackedOrder() error = %v", err)
+			}
+			o.Status, o.Updated, o.Seen = OrderOpen, base.Add(time.Minute), base.Add(time.Minute)
+			if err := s.SaveTrackedOrder(o, &OrderTransition{OrderID: 1, ClientID: "c1", From: OrderNew, To: OrderOpen, Filled: "0", Time: base.Add(time.Minute)}); err != nil {
+				t.Fatalf("SaveTrackedOrder() error = %v", err)
+			}
+			other := TrackedOrder{OrderID: 2, Pair: "eth_jpy", OrderType: "market_buy", Amount: "10000", Status: OrderFilled, Filled: "10000", Created: base.Add(time.Hour), Updated: base.Add(time.Hour)}
+			if err := s.SaveTrackedOrder(other, &OrderTransition{OrderID: 2, To: OrderFilled, Filled: "10000", Time: base.Add(time.Hour)}); err != nil {
+				t.Fatalf("SaveTrackedOrder() error = %v", err)
+			}
+			if got, ok, err := s.TrackedOrder(1); err != nil || !ok || got.Status != OrderOpen || got.ClientID != "c1" || !got.Seen.Equal(base.Add(time.Minute)) {
+				t.Errorf("TrackedOrder() = %v, %v, %v", got, ok, err)
+			}
+			if _, ok, err := s.TrackedOrder(3); err != nil || ok {
+				t.Errorf("TrackedOrder() of an unknown order = %v, %v", ok, err)
+			}
+			if orders, err := s.TrackedOrders(TrackedOrderQuery{}); err != nil || len(orders) != 2 || orders[0].OrderID != 2 {
+				t.Errorf("TrackedOrders() = %v, %v", orders, err)
+			}
+			if orders, err := s.TrackedOrders(TrackedOrderQuery{Statuses: ActiveOrderStatuses}); err != nil || len(orders) != 1 || orders[0].OrderID != 1 {
+				t.Errorf("TrackedOrders(active) = %v, %v", orders, err)
+			}
+			if orders, err := s.TrackedOrders(TrackedOrderQuery{Pair: "eth_jpy", Statuses: []string{OrderFilled, OrderCancelled}}); err != nil || len(orders) != 1 || orders[0].OrderID != 2 {
+				t.Errorf("TrackedOrders(eth_jpy) = %v, %v", orders, err)
+			}
+			if orders, err := s.TrackedOrders(TrackedOrderQuery{Limit: 1}); err != nil || len(orders) != 1 {
+				t.Errorf("TrackedOrders(limit) = %v, %v", orders, err)
+			}
+			history, err := s.OrderTransitions(TransitionQuery{OrderID: 1})
+			if err != nil || len(history) != 2 || history[0].To != OrderNew || history[1].From != OrderNew || history[1].To != OrderOpen {
+				t.Fatalf("OrderTransitions() = %v, %v", history, err)
+			}
+			rejection := OrderTransition{ClientID: "c2", To: OrderRejected, Reason: "insufficient balance", Time: base.Add(2 * time.Hour), Pair: "btc_jpy", OrderType: "market_buy", Amount: "5000"}
+			if err := s.AddOrderTransition(rejection); err != nil {
+				t.Fatalf("AddOrderTransition() error = %v", err)
+			}
+			all, err := s.OrderTransitions(TransitionQuery{After: history[0].ID})
+			if err != nil || len(all) != 3 || all[0].ID != history[1].ID || all[0].ClientID != "c1" || all[1].OrderID != 2 {
+				t.Fatalf("OrderTransitions(after) = %v, %v", all, err)
+			}
+			if r := all[2]; r.OrderID != 0 || r.ClientID != "c2" || r.To != OrderRejected || r.Reason != "insufficient balance" ||
+				r.Pair != "btc_jpy" || r.OrderType != "market_buy" || r.Amount != "5000" {
+				t.Errorf("OrderTransitions() rejection = %+v", r)
+			}
+			if page, err := s.OrderTransitions(TransitionQuery{After: all[0].ID, Limit: 1}); err != nil || len(page) != 1 || page[0].ID != all[1].ID {
+				t.Errorf("OrderTransitions(limit) = %v, %v", page, err)
+			}
+		})
+	}
+}
+
+func TestSQLiteStoreConcurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s, err := OpenSQLiteStore(StoreConfig{DSN: filepath.Join(dir, "market.db"), ReadConns: 2}, MarketMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.Local)
+	const writes = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < writes; i++ {
+			tick := Tick{ID: strconv.Itoa(i), Pair: "btc_jpy", Time: base.Add(time.Duration(i) * time.Minute), Last: float64(i)}
+			if err := s.SaveTicks([]Tick{tick}); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < writes; i++ {
+				if err := s.ScanTicks(TickQuery{Pair: "btc_jpy", Desc: true, Limit: 10}, func(Tick) bool { return true }); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent access error = %v", err)
+	}
+	n := 0
+	if err := s.ScanTicks(TickQuery{Pair: "btc_jpy"}, func(Tick) bool {
+		n++
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n != writes {
+		t.Errorf("ScanTicks() saw %d ticks, want %d", n, writes)
+	}
+}
+
+func TestStoreConditionalOrders(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.Local)
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, s := range testStores(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			defer s.Close()
+			stop := ConditionalOrder{ID: "a", Group: "a", Kind: StopLoss, Pair: "btc_jpy", Side: "sell", Trigger: 900000, Amount: "0.1", Rate: "890000",
+				State: ConditionalActive, Created: base, Updated: base}
+			profit := ConditionalOrder{ID: "b", Group: "a", Kind: TakeProfit, Pair: "btc_jpy", Side: "sell", Trigger: 1100000, Amount: "0.1", Rate: "1100000",
+				State: ConditionalActive, Created: base.Add(time.Second), Updated: base.Add(time.Second)}
+			trail := ConditionalOrder{ID: "c", Kind: TrailingStop, Pair: "eth_jpy", Side: "sell", Trail: 1000, Extreme: 30000.5, Trigger: 29000.5, Amount: "1",
+				State: ConditionalActive, Created: base.Add(time.Minute), Updated: base.Add(time.Minute)}
+			if err := s.SaveConditionalOrders(stop, profit, trail); err != nil {
+				t.Fatalf("SaveConditionalOrders() error = %v", err)
+			}
+			stop.State, stop.ClientID, stop.OrderID, stop.Updated = ConditionalTriggered, "cond-a", 12, base.Add(time.Hour)
+			profit.State, profit.Error, profit.Updated = ConditionalCancelled, "OCO order a triggered", base.Add(time.Hour)
+			if err := s.SaveConditionalOrders(stop, profit); err != nil {
+				t.Fatalf("SaveConditionalOrders() error = %v", err)
+			}
+			if got, ok, err := s.ConditionalOrder("a"); err != nil || !ok || got != stop {
+				t.Errorf("ConditionalOrder() = %+v, %v, %v, want %+v", got, ok, err, stop)
+			}
+			if _, ok, err := s.ConditionalOrder("d"); err != nil || ok {
+				t.Errorf("ConditionalOrder() of an unknown order = %v, %v", ok, err)
+			}
+			if orders, err := s.ConditionalOrders(); err != nil || len(orders) != 3 || orders[0].ID != "a" || orders[2].ID != "c" {
+				t.Errorf("ConditionalOrders() = %v, %v", orders, err)
+			}
+			if orders, err := s.ConditionalOrders(ConditionalActive); err != nil || len(orders) != 1 || orders[0] != trail {
+				t.Errorf("ConditionalOrders(active) = %v, %v", orders, err)
+			}
+			if orders, err := s.ConditionalOrders(ConditionalTriggered, ConditionalCancelled); err != nil || len(orders) != 2 {
+				t.Errorf("ConditionalOrders(triggered, cancelled) = %v, %v", orders, err)
+			}
+		})
+	}
+}
+
+func TestStoreRecurringOrders(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.Local)
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, s := range testStores(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			defer s.Close()
+			weekly := RecurringConfig{Name: "weekly", Pair: "btc_jpy", Side: "buy", Amount: 10000, Schedule: "0 9 * * 1"}.Order(base)
+			rpc := RecurringOrder{ID: "b", Pair: "btc_jpy", Side: "sell", Amount: 5000, Schedule: "@daily", LimitRate: 1200000.5,
+				State: RecurringActive, Source: RecurringFromRPC, Created: base.Add(time.Hour), Updated: base.Add(time.Hour)}
+			for _, o := range []RecurringOrder{rpc, weekly} {
+				if err := s.SaveRecurringOrder(o); err != nil {
+					t.Fatalf("SaveRecurringOrder() error = %v", err)
+				}
+			}
+			rpc.State, rpc.Updated = RecurringCancelled, base.Add(2*time.Hour)
+			if err := s.SaveRecurringOrder(rpc); err != nil {
+				t.Fatalf("SaveRecurringOrder() error = %v", err)
+			}
+			if got, ok, err := s.RecurringOrder("b"); err != nil || !ok || got != rpc {
+				t.Errorf("RecurringOrder() = %+v, %v, %v, want %+v", got, ok, err, rpc)
+			}
+			if _, ok, err := s.RecurringOrder("c"); err != nil || ok {
+				t.Errorf("RecurringOrder() of an unknown order = %v, %v", ok, err)
+			}
+			if orders, err := s.RecurringOrders(); err != nil || len(orders) != 2 || orders[0] != weekly || orders[1].ID != "b" {
+				t.Errorf("RecurringOrders() = %v, %v", orders, err)
+			}
+			runs := []RecurringRun{
+				{RecurringID: weekly.ID, Time: base, Outcome: RunPlaced, OrderType: "market_buy", Amount: "10000", ClientID: "dca-1", OrderID: 7},
+				{RecurringID: "b", Time: base.Add(time.Hour), Outcome: RunSkipped, Reason: "bid 1000000 below the limit rate 1200000.5"},
+				{RecurringID: weekly.ID, Time: base.Add(time.Hour), Outcome: RunFailed, Reason: "trading is halted"},
+			}
+			for _, r := range runs {
+				if err := s.AddRecurringRun(r); err != nil {
+					t.Fatalf("AddRecurringRun() error = %v", err)
+				}
+			}
+			all, err := s.RecurringRuns(RecurringRunQuery{})
+			if err != nil || len(all) != 3 || all[0].Outcome != RunFailed || all[2].OrderID != 7 || !all[2].Time.Equal(base) || all[0].ID <= all[2].ID {
+				t.Fatalf("RecurringRuns() = %+v, %v", all, err)
+			}
+			if got, err := s.RecurringRuns(RecurringRunQuery{RecurringID: weekly.ID, Limit: 1}); err != nil || len(got) != 1 || got[0].Outcome != RunFailed {
+				t.Errorf("RecurringRuns(weekly, limit) = %+v, %v", got, err)
+			}
+			if got, err := s.RecurringRuns(RecurringRunQuery{RecurringID: "b"}); err != nil || len(got) != 1 || got[0].Reason != runs[1].Reason {
+				t.Errorf("RecurringRuns(b) = %+v, %v", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package bitcocheck
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor The cursor of a TickHistQuery is not one of a page.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// histCursorLayout The local time of a history cursor.
+const histCursorLayout = "2006-01-02 15:04:05"
+
+// TickHistQuery A page of the ticker history of a pair.
+type TickHistQuery struct {
+	Pair  string
+	From  time.Time // inclusive, unbounded when zero
+	To    time.Time // exclusive, unbounded when zero
+	Desc  bool
+	Limit int // 1000 when not positive
+	// Step downsamples the page to the newest tick of every bucket of Step,
+	// every tick is kept when zero.
+	Step time.Duration
+	// Cursor is the next cursor of the previous page, "" for the first page.
+	Cursor string
+}
+
+// histCursor is the position after the last point of a page. A downsampled
+// page ends on a bucket boundary and has no id.
+type histCursor struct {
+	Ts string `json:"ts"`
+	ID string `json:"id,omitempty"`
+}
+
+func encodeHistCursor(tm time.Time, id string) string {
+	b, _ := json.Marshal(histCursor{Ts: tm.Local().Format(histCursorLayout), ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeHistCursor(s string) (time.Time, string, error) {
+	var c histCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	tm, err := time.ParseInLocation(histCursorLayout, c.Ts, time.Local)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	return tm, c.ID, nil
+}
+
+// TickHistPage returns a page of up to q.Limit ticks of the history and the
+// cursor of the next page, "" on the last page. A downsampled page resumes at
+// the next bucket, so a bucket is never split between two pages.
+func TickHistPage(s TickStore, q TickHistQuery) ([]Tick, string, error) {
+	if q.Limit <= 0 {
+		q.Limit = 1000
+	}
+	scan := TickQuery{Pair: q.Pair, From: q.From, To: q.To, Desc: q.Desc}
+	if q.Cursor != "" {
+		tm, id, err := decodeHistCursor(q.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		switch {
+		case id != "":
+			scan.After = &TickKey{Time: tm, ID: id}
+		case q.Desc:
+			if scan.To.IsZero() || tm.Before(scan.To) {
+				scan.To = tm
+			}
+		default:
+			if tm.After(scan.From) {
+				scan.From = tm
+			}
+		}
+	}
+	if q.Step == 0 {
+		// One more tick tells whether there is a next page.
+		scan.Limit = q.Limit + 1
+	}
+
+	page := []Tick{}
+	next := ""
+	var bucket time.Time
+	err := s.ScanTicks(scan, func(tick Tick) bool {
+		if q.Step == 0 {
+			if len(page) == q.Limit {
+				last := page[len(page)-1]
+				next = encodeHistCursor(last.Time, last.ID)
+				return false
+			}
+			page = append(page, tick)
+			return true
+		}
+		b := tick.Time.Truncate(q.Step)
+		if len(page) > 0 && b.Equal(bucket) {
+			if !q.Desc {
+				page[len(page)-1] = tick
+			}
+			return true
+		}
+		if len(page) == q.Limit {
+			resume := bucket
+			if !q.Desc {
+				resume = bucket.Add(q.Step)
+			}
+			next = encodeHistCursor(resume, "")
+			return false
+		}
+		bucket = b
+		page = append(page, tick)
+		return true
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return page, next, nil
+}
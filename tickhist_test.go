@@ -0,0 +1,113 @@
+package bitcocheck
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTickHistPage(t *testing.T) {
+	base := time.Date(2020, 3, 15, 10, 0, 0, 0, time.UTC)
+	// Ticks every 2 minutes from 10:00 to 10:18, two of them at 10:04. Last
+	// is the index of the tick.
+	ticks := []Tick{}
+	for i, minute := range []int{0, 2, 4, 4, 6, 8, 10, 12, 14, 16, 18} {
+		ticks = append(ticks, Tick{ID: "t" + strconv.Itoa(i), Pair: "btc_jpy", Time: base.Add(time.Duration(minute) * time.Minute), Last: float64(i)})
+	}
+	s := NewMemoryStore()
+	if err := s.SaveTicks(ticks); err != nil {
+		t.Fatal(err)
+	}
+	at := func(minute int) time.Time { return base.Add(time.Duration(minute) * time.Minute) }
+	tests := []struct {
+		name string
+		q    TickHistQuery
+		want [][]float64
+	}{
+		{
+			name: "ascending",
+			q:    TickHistQuery{Limit: 4},
+			want: [][]float64{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9, 10}},
+		},
+		{
+			name: "page boundary between ticks of the same time",
+			q:    TickHistQuery{Limit: 3},
+			want: [][]float64{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {9, 10}},
+		},
+		{
+			name: "descending",
+			q:    TickHistQuery{Desc: true, Limit: 4},
+			want: [][]float64{{10, 9, 8, 7}, {6, 5, 4, 3}, {2, 1, 0}},
+		},
+		{
+			name: "full last page has no next page",
+			q:    TickHistQuery{Limit: 11},
+			want: [][]float64{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		},
+		{
+			name: "from and to",
+			q:    TickHistQuery{From: at(4), To: at(12), Limit: 3},
+			want: [][]float64{{2, 3, 4}, {5, 6}},
+		},
+		{
+			name: "descending from and to",
+			q:    TickHistQuery{From: at(4), To: at(12), Desc: true, Limit: 3},
+			want: [][]float64{{6, 5, 4}, {3, 2}},
+		},
+		{
+			name: "downsampled ascending keeps the newest tick of a bucket",
+			q:    TickHistQuery{Step: 5 * time.Minute, Limit: 2},
+			want: [][]float64{{3, 5}, {8, 10}},
+		},
+		{
+			name: "downsampled descending",
+			q:    TickHistQuery{Step: 5 * time.Minute, Desc: true, Limit: 3},
+			want: [][]float64{{10, 8, 5}, {3}},
+		},
+		{
+			name: "from inside a bucket",
+			q:    TickHistQuery{Step: 5 * time.Minute, From: at(2), Limit: 1},
+			want: [][]float64{{3}, {5}, {8}, {10}},
+		},
+		{
+			name: "to inside a bucket",
+			q:    TickHistQuery{Step: 5 * time.Minute, To: at(12), Desc: true, Limit: 1},
+			want: [][]float64{{6}, {5}, {3}},
+		},
+		{
+			name: "pages end at the limit of buckets, not of ticks",
+			q:    TickHistQuery{Step: 10 * time.Minute, Limit: 1},
+			want: [][]float64{{5}, {10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.q
+			q.Pair = "btc_jpy"
+			got := [][]float64{}
+			for i := 0; i < 20; i++ {
+				page, next, err := TickHistPage(s, q)
+				if err != nil {
+					t.Fatalf("TickHistPage() error = %v", err)
+				}
+				lasts := []float64{}
+				for _, tick := range page {
+					lasts = append(lasts, tick.Last)
+				}
+				got = append(got, lasts)
+				if next == "" {
+					break
+				}
+				q.Cursor = next
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, _, err := TickHistPage(s, TickHistQuery{Pair: "btc_jpy", Cursor: "not a cursor"}); err != ErrInvalidCursor {
+		t.Errorf("TickHistPage(bad cursor) error = %v, want ErrInvalidCursor", err)
+	}
+}
@@ -0,0 +1,246 @@
+package bitcocheck
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSConfig TLS settings of the gRPC server or a client. On the server CA
+// requires client certificates signed by it (mutual TLS); on a client it
+// verifies the server certificate instead of the system roots.
+type TLSConfig struct {
+	Enable     bool   `toml:"enable"`
+	Cert       string `toml:"cert"`
+	Key        string `toml:"key"`
+	CA         string `toml:"ca"`
+	ServerName string `toml:"server_name"`
+}
+
+// Enabled tells whether the connection uses TLS, which any certificate implies.
+func (c TLSConfig) Enabled() bool {
+	return c.Enable || c.Cert != "" || c.CA != ""
+}
+
+// certReloader keeps a certificate and a CA pool loaded from files and loads
+// them again when a file changes, so renewed certificates are used without a
+// restart. A file that fails to load keeps the previous one in use.
+type certReloader struct {
+	conf TLSConfig
+
+	mu      sync.Mutex
+	modTime map[string]time.Time
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+func newCertReloader(conf TLSConfig) (*certReloader, error) {
+	if (conf.Cert == "") != (conf.Key == "") {
+		return nil, errors.New("tls cert and key must be set together")
+	}
+	r := &certReloader{conf: conf, modTime: map[string]time.Time{}}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// changed returns the modification times of the files when one was modified
+// since they were last loaded, nil when none was. The times are kept by
+// loaded once the files load, so a file read while half written is loaded
+// again on the next handshake.
+func (r *certReloader) changed(names ...string) (map[string]time.Time, error) {
+	mods := map[string]time.Time{}
+	changed := false
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		fi, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		mods[name] = fi.ModTime()
+		if !fi.ModTime().Equal(r.modTime[name]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	return mods, nil
+}
+
+// loaded keeps the modification times of files just loaded.
+func (r *certReloader) loaded(mods map[string]time.Time) {
+	for name, mod := range mods {
+		r.modTime[name] = mod
+	}
+}
+
+func (r *certReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mods, err := r.changed(r.conf.Cert, r.conf.Key)
+	if err != nil {
+		return fmt.Errorf("tls cert: %v", err)
+	}
+	if mods != nil {
+		cert, err := tls.LoadX509KeyPair(r.conf.Cert, r.conf.Key)
+		if err != nil {
+			return fmt.Errorf("tls cert: %v", err)
+		}
+		r.cert = &cert
+		r.loaded(mods)
+	}
+	mods, err = r.changed(r.conf.CA)
+	if err != nil {
+		return fmt.Errorf("tls ca: %v", err)
+	}
+	if mods != nil {
+		pem, err := ioutil.ReadFile(r.conf.CA)
+		if err != nil {
+			return fmt.Errorf("tls ca: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("tls ca: no certificate in %s", r.conf.CA)
+		}
+		r.pool = pool
+		r.loaded(mods)
+	}
+	return nil
+}
+
+// current returns the certificate and the CA pool, reloading changed files.
+func (r *certReloader) current() (*tls.Certificate, *x509.CertPool) {
+	if err := r.reload(); err != nil {
+		log.Printf("tls reload error, keeping the loaded certificates: %v\n", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cert, r.pool
+}
+
+// ServerTLSConfig returns the TLS config of the gRPC server. The certificate
+// and the client CA are reloaded on the handshake after their files change.
+func ServerTLSConfig(conf TLSConfig) (*tls.Config, error) {
+	if conf.Cert == "" {
+		return nil, errors.New("tls needs a server cert and key")
+	}
+	r, err := newCertReloader(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool := r.current()
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+			}
+			if pool != nil {
+				c.ClientCAs = pool
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return c, nil
+		},
+	}, nil
+}
+
+// ClientTLSConfig returns the TLS config of a client. The client certificate
+// and the CA are reloaded on the handshake after their files change: the
+// server certificate is checked against the CA of each handshake and the
+// server name when set, and against the system roots without a CA.
+func ClientTLSConfig(conf TLSConfig) (*tls.Config, error) {
+	r, err := newCertReloader(conf)
+	if err != nil {
+		return nil, err
+	}
+	c := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: conf.ServerName,
+	}
+	if conf.CA != "" {
+		// The pool of the config is fixed once built, the chain is verified
+		// here with the pool of the time instead.
+		c.InsecureSkipVerify = true
+		c.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			_, pool := r.current()
+			return verifyServer(rawCerts, pool, conf.ServerName)
+		}
+	}
+	if conf.Cert != "" {
+		c.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		}
+	}
+	return c, nil
+}
+
+// verifyServer verifies the certificate chain of a server against the pool
+// and, when set, the server name.
+func verifyServer(rawCerts [][]byte, pool *x509.CertPool, serverName string) error {
+	if len(rawCerts) == 0 {
+		return errors.New("tls: no server certificate")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("tls: server certificate: %v", err)
+		}
+		certs[i] = cert
+	}
+	opts := x509.VerifyOptions{Roots: pool, DNSName: serverName, Intermediates: x509.NewCertPool()}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// ServerCredentials returns the server option of the TLS config, none when
+// TLS is not enabled.
+func ServerCredentials(conf TLSConfig) ([]grpc.ServerOption, error) {
+	if !conf.Enabled() {
+		return nil, nil
+	}
+	c, err := ServerTLSConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(c))}, nil
+}
+
+// DialCredentials returns the dial option of the TLS config to connect to
+// addr, a plaintext connection when TLS is not enabled. The server name
+// expected in the server certificate defaults to the host of addr.
+func DialCredentials(conf TLSConfig, addr string) (grpc.DialOption, error) {
+	if !conf.Enabled() {
+		return grpc.WithInsecure(), nil
+	}
+	if conf.ServerName == "" {
+		conf.ServerName = addr
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			conf.ServerName = host
+		}
+	}
+	c, err := ClientTLSConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(c)), nil
+}
@@ -0,0 +1,221 @@
+package bitcocheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// issue creates a certificate for localhost signed by parent, self-signed
+// when parent is nil, and writes it as name.crt and name.key.
+func issue(t *testing.T, dir, name string, parent *testCert, isCA bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(dir, name+".crt"), "CERTIFICATE", der)
+	writePEM(t, filepath.Join(dir, name+".key"), "EC PRIVATE KEY", keyDER)
+	return &testCert{cert: cert, key: key}
+}
+
+// writePEM writes the file and moves its modification time forward, so the
+// reloader sees a change within the resolution of the file system clock.
+func writePEM(t *testing.T, name, typ string, der []byte) {
+	if err := ioutil.WriteFile(name, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	mod := time.Now()
+	if fi, err := os.Stat(name); err == nil {
+		mod = fi.ModTime()
+	}
+	mod = mod.Add(time.Duration(time.Now().UnixNano()%1000+1) * time.Second)
+	if err := os.Chtimes(name, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTLSCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := func(name string) string { return filepath.Join(dir, name) }
+	ca := issue(t, dir, "ca", nil, true)
+	issue(t, dir, "server", ca, false)
+	issue(t, dir, "client", ca, false)
+	other := issue(t, dir, "other", nil, true)
+	issue(t, dir, "stranger", other, false)
+
+	opts, err := ServerCredentials(TLSConfig{Cert: path("server.crt"), Key: path("server.key"), CA: path("ca.crt")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.Serve(lis)
+	defer s.Stop()
+
+	dialCheck := func(creds grpc.DialOption) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, lis.Addr().String(), creds)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+	check := func(conf TLSConfig) error {
+		creds, err := DialCredentials(conf, lis.Addr().String())
+		if err != nil {
+			return err
+		}
+		return dialCheck(creds)
+	}
+	tests := []struct {
+		name    string
+		conf    TLSConfig
+		wantErr bool
+	}{
+		{name: "mutual tls", conf: TLSConfig{CA: path("ca.crt"), Cert: path("client.crt"), Key: path("client.key"), ServerName: "localhost"}},
+		{name: "no client cert", conf: TLSConfig{CA: path("ca.crt"), ServerName: "localhost"}, wantErr: true},
+		{name: "client cert of another ca", conf: TLSConfig{CA: path("ca.crt"), Cert: path("stranger.crt"), Key: path("stranger.key"), ServerName: "localhost"}, wantErr: true},
+		{name: "plaintext", conf: TLSConfig{}, wantErr: true},
+		{name: "server of another ca", conf: TLSConfig{CA: path("other.crt"), Cert: path("client.crt"), Key: path("client.key"), ServerName: "localhost"}, wantErr: true},
+		{name: "server name of the address", conf: TLSConfig{CA: path("ca.crt"), Cert: path("client.crt"), Key: path("client.key")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := check(tt.conf); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// A renewed server certificate of the other CA is used without a restart,
+	// and a client trusts the CA its file then holds with the same credentials.
+	writePEM(t, path("client-ca.crt"), "CERTIFICATE", ca.cert.Raw)
+	creds, err := DialCredentials(TLSConfig{CA: path("client-ca.crt"), Cert: path("client.crt"), Key: path("client.key"), ServerName: "localhost"}, lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dialCheck(creds); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	issue(t, dir, "server", other, false)
+	if err := dialCheck(creds); err == nil {
+		t.Error("Check() trusted a server of a CA not in the client CA file")
+	}
+	writePEM(t, path("client-ca.crt"), "CERTIFICATE", other.cert.Raw)
+	if err := dialCheck(creds); err != nil {
+		t.Errorf("Check() after reload error = %v", err)
+	}
+}
+
+func TestCertReloaderRetry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "ca.crt")
+	issue(t, dir, "ca", nil, true)
+	other := issue(t, dir, "other", nil, true)
+	r, err := newCertReloader(TLSConfig{CA: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A file read while half written fails to load and keeps the old pool.
+	if err := ioutil.WriteFile(name, []byte("-----BEGIN CERT"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	mod := time.Now().Add(time.Hour)
+	if err := os.Chtimes(name, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+	if _, pool := r.current(); pool == nil || len(pool.Subjects()) != 1 {
+		t.Fatalf("pool after a failed load = %v", pool)
+	}
+	// Its complete content, within the same modification time, is loaded.
+	if err := ioutil.WriteFile(name, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: other.cert.Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(name, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+	if _, pool := r.current(); pool == nil || len(pool.Subjects()) != 1 || !bytes.Equal(pool.Subjects()[0], other.cert.RawSubject) {
+		t.Error("CA not loaded after a failed load of the same modification time")
+	}
+}
+
+func TestTLSConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		conf TLSConfig
+	}{
+		{name: "cert without key", conf: TLSConfig{Cert: "server.crt"}},
+		{name: "missing cert", conf: TLSConfig{Cert: "missing.crt", Key: "missing.key"}},
+		{name: "ca without server cert", conf: TLSConfig{CA: "ca.crt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ServerCredentials(tt.conf); err == nil {
+				t.Errorf("ServerCredentials() error = nil, want an error")
+			}
+		})
+	}
+}
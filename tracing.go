@@ -0,0 +1,137 @@
+package bitcocheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+// TracerName is the name of the tracer of the Coincheck API requests.
+const TracerName = "github.com/hypoballad/bitcocheck"
+
+// Trace attributes of the Coincheck API requests.
+const (
+	EndpointKey  = label.Key("coincheck.endpoint")
+	PairKey      = label.Key("coincheck.pair")
+	OrderTypeKey = label.Key("coincheck.order_type")
+)
+
+// TracingConfig OpenTelemetry tracing, exported with OTLP. Disabled by default.
+type TracingConfig struct {
+	Enable bool `toml:"enable"`
+	// Endpoint is the OTLP gRPC address of the collector, localhost:55680 when unset.
+	Endpoint string `toml:"endpoint"`
+	// SampleRatio is the fraction of traces recorded, all when 0.
+	SampleRatio float64 `toml:"sample_ratio"`
+	ServiceName string  `toml:"service_name"`
+}
+
+// StartTracing installs the global tracer provider exporting to the
+// collector. stop flushes the spans and closes the connection.
+func StartTracing(conf TracingConfig, defaultService string) (stop func(), err error) {
+	if !conf.Enable {
+		return func() {}, nil
+	}
+	if conf.SampleRatio < 0 || conf.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing sample_ratio %v is not in [0, 1]", conf.SampleRatio)
+	}
+	endpoint := conf.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("%s:%d", otlp.DefaultCollectorHost, otlp.DefaultCollectorPort)
+	}
+	service := conf.ServiceName
+	if service == "" {
+		service = defaultService
+	}
+	exp, err := otlp.NewExporter(otlp.WithInsecure(), otlp.WithAddress(endpoint))
+	if err != nil {
+		return nil, err
+	}
+	sampler := sdktrace.AlwaysSample()
+	if conf.SampleRatio > 0 {
+		sampler = sdktrace.ParentSample(sdktrace.ProbabilitySampler(conf.SampleRatio))
+	}
+	bsp, err := sdktrace.NewBatchSpanProcessor(exp)
+	if err != nil {
+		exp.Stop()
+		return nil, err
+	}
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sampler}),
+		sdktrace.WithResource(resource.New(semconv.ServiceNameKey.String(service))),
+	)
+	if err != nil {
+		exp.Stop()
+		return nil, err
+	}
+	tp.RegisterSpanProcessor(bsp)
+	global.SetTraceProvider(tp)
+	return func() {
+		tp.UnregisterSpanProcessor(bsp)
+		exp.Stop()
+	}, nil
+}
+
+// requestAttributes returns the pair and the order type of a request, from
+// its query or its JSON body.
+func requestAttributes(rawurl, body string) []label.KeyValue {
+	var pair, orderType string
+	if u, err := url.Parse(rawurl); err == nil {
+		pair = u.Query().Get("pair")
+	}
+	var payload struct {
+		Pair      string `json:"pair"`
+		OrderType string `json:"order_type"`
+	}
+	if body != "" && json.Unmarshal([]byte(body), &payload) == nil {
+		if payload.Pair != "" {
+			pair = payload.Pair
+		}
+		orderType = payload.OrderType
+	}
+	attrs := []label.KeyValue{EndpointKey.String(Endpoint(rawurl))}
+	if pair != "" {
+		attrs = append(attrs, PairKey.String(pair))
+	}
+	if orderType != "" {
+		attrs = append(attrs, OrderTypeKey.String(orderType))
+	}
+	return attrs
+}
+
+// startSpan starts the client span of a request, a child of the span in a.Ctx.
+func (a APIInfo) startSpan(method string) trace.Span {
+	ctx := a.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	attrs := append(requestAttributes(a.Url, a.Body), semconv.HTTPMethodKey.String(method))
+	_, span := global.Tracer(TracerName).Start(ctx, "coincheck "+method+" "+Endpoint(a.Url),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return span
+}
+
+func endSpan(span trace.Span, e RequestEvent) {
+	if e.Status != 0 {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(e.Status))
+	}
+	if e.Err != nil {
+		span.RecordError(context.Background(), e.Err)
+	}
+	if e.Failed() {
+		span.SetStatus(codes.Unavailable, fmt.Sprintf("status %d", e.Status))
+	}
+	span.End()
+}
@@ -0,0 +1,120 @@
+package bitcocheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestRequestAttributes(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		body string
+		want []label.KeyValue
+	}{
+		{name: "query", url: "https://coincheck.com/api/ticker?pair=btc_jpy", want: []label.KeyValue{
+			EndpointKey.String("/api/ticker"), PairKey.String("btc_jpy"),
+		}},
+		{name: "order", url: "https://coincheck.com/api/exchange/orders", body: `{"pair":"btc_jpy","order_type":"market_buy"}`, want: []label.KeyValue{
+			EndpointKey.String("/api/exchange/orders"), PairKey.String("btc_jpy"), OrderTypeKey.String("market_buy"),
+		}},
+		{name: "cancel", url: "https://coincheck.com/api/exchange/orders/12345", want: []label.KeyValue{
+			EndpointKey.String("/api/exchange/orders/:id"),
+		}},
+		{name: "not json", url: "https://coincheck.com/api/accounts", body: "{", want: []label.KeyValue{
+			EndpointKey.String("/api/accounts"),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestAttributes(tt.url, tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("requestAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []*export.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(ctx context.Context, s *export.SpanData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, s)
+}
+
+func TestRequestSpan(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer ts.Close()
+	rec := &spanRecorder{}
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+		sdktrace.WithSyncer(rec),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := global.TraceProvider()
+	global.SetTraceProvider(tp)
+	defer global.SetTraceProvider(prev)
+
+	tests := []struct {
+		name      string
+		path      string
+		wantError bool
+	}{
+		{name: "ok", path: "/api/exchange/orders"},
+		{name: "unavailable", path: "/down", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec.mu.Lock()
+			rec.spans = nil
+			rec.mu.Unlock()
+			ctx, parent := tp.Tracer("test").Start(context.Background(), "rpc")
+			conf := Config{}.WithContext(ctx)
+			conf.apiInfo(ts.URL+tt.path, `{"pair":"btc_jpy","order_type":"buy"}`).PostRequest()
+			parent.End()
+
+			rec.mu.Lock()
+			defer rec.mu.Unlock()
+			if len(rec.spans) != 2 {
+				t.Fatalf("%d spans exported, want 2", len(rec.spans))
+			}
+			s := rec.spans[0]
+			if s.ParentSpanID != parent.SpanContext().SpanID || s.SpanContext.TraceID != parent.SpanContext().TraceID {
+				t.Errorf("request span is not a child of the RPC span")
+			}
+			attrs := map[label.Key]string{}
+			for _, kv := range s.Attributes {
+				attrs[kv.Key] = kv.Value.Emit()
+			}
+			want := map[label.Key]string{EndpointKey: Endpoint(ts.URL + tt.path), PairKey: "btc_jpy", OrderTypeKey: "buy"}
+			for k, v := range want {
+				if attrs[k] != v {
+					t.Errorf("attribute %s = %q, want %q", k, attrs[k], v)
+				}
+			}
+			if got := s.StatusCode != 0; got != tt.wantError {
+				t.Errorf("span status %v, want error %v", s.StatusCode, tt.wantError)
+			}
+		})
+	}
+}